@@ -0,0 +1,279 @@
+package mmath
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+// CalculationBigInt represents a calculation that returns an arbitrary-precision
+// integer.
+//
+// All calculations provided by this package return a newly allocated *big.Int
+// on every call and never modify values returned by their operands, so callers
+// may freely modify results. Custom implementations should follow the same
+// rules.
+type CalculationBigInt interface {
+	// CalculateBigInt returns the *big.Int value calculated by this calculator.
+	CalculateBigInt() (*big.Int, error)
+}
+
+// CalculationBigIntFunc implements CalculationBigInt by wrapping a function.
+type CalculationBigIntFunc func() (*big.Int, error)
+
+// CalculateBigInt calls f and returns its result.
+func (f CalculationBigIntFunc) CalculateBigInt() (*big.Int, error) {
+	return f()
+}
+
+// NewConstantBigInt returns a calculation which always returns the same value
+// and no error. c is copied, so modifying it afterwards does not change the
+// calculation.
+func NewConstantBigInt(c *big.Int) CalculationBigIntFunc {
+	value := new(big.Int).Set(c)
+	return func() (*big.Int, error) {
+		return new(big.Int).Set(value), nil
+	}
+}
+
+// NewVariableBigInt creates a variable. In calculations, it returns the value
+// it was set to, initially zero. Calculating the result of a variable never
+// fails. The variable is safe for concurrent use.
+func NewVariableBigInt() VariableBigInt {
+	return &variableBigInt{
+		value: new(big.Int),
+	}
+}
+
+// VariableBigInt represents a variable value, which can be set from the
+// outside.
+type VariableBigInt interface {
+	CalculationBigInt
+
+	// Set sets the variable. Afterwards, calling CalculateBigInt() will return
+	// the value of i. i is copied, so modifying it afterwards does not change
+	// the variable.
+	Set(i *big.Int)
+}
+
+type variableBigInt struct {
+	mutex sync.RWMutex
+	value *big.Int
+}
+
+func (v *variableBigInt) CalculateBigInt() (*big.Int, error) {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+	return new(big.Int).Set(v.value), nil
+}
+
+func (v *variableBigInt) Set(i *big.Int) {
+	value := new(big.Int).Set(i)
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.value = value
+}
+
+// NewSumBigInt returns a calculation which returns the sum of all calculations
+// passed to it. If one or more calculations fail, an error wrapping all those
+// individual errors is returned.
+func NewSumBigInt(calculations ...CalculationBigInt) CalculationBigInt {
+	return NewReduceLeftBigInt(
+		func(current, next *big.Int) (*big.Int, error) {
+			return new(big.Int).Add(current, next), nil
+		},
+		NewConstantBigInt(big.NewInt(0)),
+		calculations,
+	)
+}
+
+// NewProductBigInt returns a calculation which returns the product of all
+// calculations passed to it. If one or more calculations fail, an error
+// wrapping all those individual errors is returned.
+func NewProductBigInt(calculations ...CalculationBigInt) CalculationBigInt {
+	return NewReduceLeftBigInt(
+		func(current, next *big.Int) (*big.Int, error) {
+			return new(big.Int).Mul(current, next), nil
+		},
+		NewConstantBigInt(big.NewInt(1)),
+		calculations,
+	)
+}
+
+// NewDifferenceBigInt returns a calculation which subtracts the result of
+// subtrahend from the result of minuend. If one or both fail, an error
+// combining those errors is returned.
+func NewDifferenceBigInt(minuend, subtrahend CalculationBigInt) CalculationBigIntFunc {
+	return NewCreateFallibleBinaryBigInt(
+		func(left, right *big.Int) (*big.Int, error) {
+			return new(big.Int).Sub(left, right), nil
+		},
+	)(minuend, subtrahend)
+}
+
+// NewNegationBigInt returns a calculation which negates the result of another
+// calculation. If that calculation fails, that error is returned.
+func NewNegationBigInt(calculation CalculationBigInt) CalculationBigIntFunc {
+	return NewCreateFallibleUnaryBigInt(
+		func(value *big.Int) (*big.Int, error) {
+			return new(big.Int).Neg(value), nil
+		},
+	)(calculation)
+}
+
+// NewQuotientBigInt returns a calculation which divides the result of dividend
+// by the result of divisor, truncating towards zero. If one or both fail, an
+// error combining those errors is returned. If the divisor is zero, a
+// *DivisionByZeroError is returned.
+func NewQuotientBigInt(dividend, divisor CalculationBigInt) CalculationBigIntFunc {
+	return NewCreateFallibleBinaryBigInt(
+		func(left, right *big.Int) (*big.Int, error) {
+			if right.Sign() == 0 {
+				return nil, newDivisionByZeroError("quotient", left)
+			}
+			return new(big.Int).Quo(left, right), nil
+		},
+	)(dividend, divisor)
+}
+
+// NewRemainderBigInt returns a calculation which returns the remainder matching
+// NewQuotientBigInt. The result has the sign of the dividend. Errors are
+// handled like in NewQuotientBigInt.
+func NewRemainderBigInt(dividend, divisor CalculationBigInt) CalculationBigIntFunc {
+	return NewCreateFallibleBinaryBigInt(
+		func(left, right *big.Int) (*big.Int, error) {
+			if right.Sign() == 0 {
+				return nil, newDivisionByZeroError("remainder", left)
+			}
+			return new(big.Int).Rem(left, right), nil
+		},
+	)(dividend, divisor)
+}
+
+// NewPowBigInt returns a calculation which returns base**exponent. If one or
+// both fail, an error combining those errors is returned. Negative exponents
+// are not supported and result in an error. Be aware that large exponents
+// create huge numbers.
+func NewPowBigInt(base, exponent CalculationBigInt) CalculationBigIntFunc {
+	return NewCreateFallibleBinaryBigInt(
+		func(left, right *big.Int) (*big.Int, error) {
+			if right.Sign() < 0 {
+				return nil, fmt.Errorf("negative exponent %s in pow of %s", right, left)
+			}
+			return new(big.Int).Exp(left, right, nil), nil
+		},
+	)(base, exponent)
+}
+
+// NewGCDBigInt returns a calculation which returns the greatest common divisor
+// of the results of two calculations. The result is never negative, the
+// greatest common divisor of zero and zero is zero. If one or both fail, an
+// error combining those errors is returned.
+func NewGCDBigInt(first, second CalculationBigInt) CalculationBigIntFunc {
+	return NewCreateFallibleBinaryBigInt(
+		func(left, right *big.Int) (*big.Int, error) {
+			return new(big.Int).GCD(nil, nil, new(big.Int).Abs(left), new(big.Int).Abs(right)), nil
+		},
+	)(first, second)
+}
+
+func runCalculationsBigInt(calculations ...CalculationBigInt) ([]*big.Int, error) {
+	var errs Errors
+	results := make([]*big.Int, len(calculations))
+
+	for i := range calculations {
+		result, err := calculations[i].CalculateBigInt()
+		results[i] = result
+		if err != nil {
+			errs.add(i, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return results, nil
+}
+
+// NewConditionalBigInt returns a calculation which returns the result of ifTrue
+// or ifFalse, depending on wether boolCalc returns true or false. If boolCalc
+// returns an error, that error is returned instead.
+func NewConditionalBigInt(boolCalc CalculationBool, ifTrue, ifFalse CalculationBigInt) CalculationBigInt {
+	return ToBigInt(Conditional(FromBool(boolCalc), FromBigInt(ifTrue), FromBigInt(ifFalse)))
+}
+
+// NewCreateFallibleBinaryBigInt wraps a binary function on *big.Int values and
+// returns a calculation constructor representing the same calculation. If one
+// or both operands fail, an error combining those errors is returned, else the
+// result of f. f must not modify its arguments.
+func NewCreateFallibleBinaryBigInt(
+	f func(left, right *big.Int) (*big.Int, error),
+) func(left, right CalculationBigInt) CalculationBigIntFunc {
+	return func(left, right CalculationBigInt) CalculationBigIntFunc {
+		return func() (*big.Int, error) {
+			values, err := runCalculationsBigInt(left, right)
+			if err != nil {
+				return nil, err
+			}
+			return f(values[0], values[1])
+		}
+	}
+}
+
+// NewCreateFallibleUnaryBigInt wraps a unary function on a *big.Int value and
+// returns a calculation constructor representing the same calculation. If the
+// operand fails, that error is returned, else the result of f. f must not
+// modify its argument.
+func NewCreateFallibleUnaryBigInt(
+	f func(value *big.Int) (*big.Int, error),
+) func(calculation CalculationBigInt) CalculationBigIntFunc {
+	return func(calculation CalculationBigInt) CalculationBigIntFunc {
+		return func() (*big.Int, error) {
+			v, err := calculation.CalculateBigInt()
+			if err != nil {
+				return nil, err
+			}
+			return f(v)
+		}
+	}
+}
+
+// NewReduceLeftBigInt works like NewReduceLeft, but for *big.Int values. The
+// result of reduce is passed as current to the next call of reduce, so reduce
+// may modify current and return it, but must not modify next.
+func NewReduceLeftBigInt(
+	reduce func(current *big.Int, next *big.Int) (*big.Int, error),
+	initialValue CalculationBigInt,
+	calculations []CalculationBigInt,
+) CalculationBigInt {
+	return ToBigInt(ReduceLeft(reduce, FromBigInt(initialValue), fromSlice(calculations, FromBigInt)))
+}
+
+// NewInt64ToBigInt returns a calculation which converts the result of an int64
+// calculation to a *big.Int. If that calculation fails, its error is returned.
+func NewInt64ToBigInt(calculation CalculationInt64) CalculationBigIntFunc {
+	return func() (*big.Int, error) {
+		v, err := calculation.CalculateInt64()
+		if err != nil {
+			return nil, err
+		}
+		return big.NewInt(v), nil
+	}
+}
+
+// NewBigIntToInt64 returns a calculation which converts the result of a
+// *big.Int calculation to an int64. If that calculation fails, its error is
+// returned. If the value does not fit into an int64, a *RangeError is returned.
+func NewBigIntToInt64(calculation CalculationBigInt) CalculationInt64Func {
+	return func() (int64, error) {
+		v, err := calculation.CalculateBigInt()
+		if err != nil {
+			return 0, err
+		}
+		if !v.IsInt64() {
+			return 0, newRangeError(v, "int64")
+		}
+		return v.Int64(), nil
+	}
+}
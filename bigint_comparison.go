@@ -0,0 +1,82 @@
+package mmath
+
+// NewBigIntEquals returns wether the results of the first and second
+// calculations are equal. If one or both return an error, return an error
+// combining those errors instead.
+func NewBigIntEquals(first, second CalculationBigInt) CalculationBoolFunc {
+	return newBigIntComparison(
+		func(cmp int) bool {
+			return cmp == 0
+		},
+	)(first, second)
+}
+
+// NewBigIntNotEquals returns wether the results of the first and second
+// calculations differ. Errors are handled like in NewBigIntEquals.
+func NewBigIntNotEquals(first, second CalculationBigInt) CalculationBoolFunc {
+	return newBigIntComparison(
+		func(cmp int) bool {
+			return cmp != 0
+		},
+	)(first, second)
+}
+
+// NewBigIntLess returns wether the result of the first calculation is less than
+// the result of the second calculation. Errors are handled like in
+// NewBigIntEquals.
+func NewBigIntLess(first, second CalculationBigInt) CalculationBoolFunc {
+	return newBigIntComparison(
+		func(cmp int) bool {
+			return cmp < 0
+		},
+	)(first, second)
+}
+
+// NewBigIntLessOrEqual returns wether the result of the first calculation is
+// less than or equal to the result of the second calculation. Errors are
+// handled like in NewBigIntEquals.
+func NewBigIntLessOrEqual(first, second CalculationBigInt) CalculationBoolFunc {
+	return newBigIntComparison(
+		func(cmp int) bool {
+			return cmp <= 0
+		},
+	)(first, second)
+}
+
+// NewBigIntGreater returns wether the result of the first calculation is
+// greater than the result of the second calculation. Errors are handled like
+// in NewBigIntEquals.
+func NewBigIntGreater(first, second CalculationBigInt) CalculationBoolFunc {
+	return newBigIntComparison(
+		func(cmp int) bool {
+			return cmp > 0
+		},
+	)(first, second)
+}
+
+// NewBigIntGreaterOrEqual returns wether the result of the first calculation is
+// greater than or equal to the result of the second calculation. Errors are
+// handled like in NewBigIntEquals.
+func NewBigIntGreaterOrEqual(first, second CalculationBigInt) CalculationBoolFunc {
+	return newBigIntComparison(
+		func(cmp int) bool {
+			return cmp >= 0
+		},
+	)(first, second)
+}
+
+// newBigIntComparison creates a calculation constructor comparing the results
+// of two calculations. check receives the result of big.Int's Cmp method.
+func newBigIntComparison(
+	check func(cmp int) bool,
+) func(first, second CalculationBigInt) CalculationBoolFunc {
+	return func(first, second CalculationBigInt) CalculationBoolFunc {
+		return func() (bool, error) {
+			values, err := runCalculationsBigInt(first, second)
+			if err != nil {
+				return false, err
+			}
+			return check(values[0].Cmp(values[1])), nil
+		}
+	}
+}
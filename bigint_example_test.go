@@ -0,0 +1,43 @@
+package mmath_test
+
+import (
+	"github.com/GodsBoss/mmath"
+
+	"fmt"
+	"math/big"
+)
+
+func ExampleNewSumBigInt() {
+	balance := mmath.NewVariableBigInt()
+	total := mmath.NewSumBigInt(
+		balance,
+		mmath.NewConstantBigInt(big.NewInt(9000000000000000000)),
+	)
+
+	balance.Set(big.NewInt(9000000000000000000))
+
+	v, err := total.CalculateBigInt()
+
+	fmt.Printf("Value is %s.\n", v)
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+	}
+
+	// Output:
+	// Value is 18000000000000000000.
+}
+
+func ExampleNewGCDBigInt() {
+	v, err := mmath.NewGCDBigInt(
+		mmath.NewConstantBigInt(big.NewInt(84)),
+		mmath.NewConstantBigInt(big.NewInt(36)),
+	).CalculateBigInt()
+
+	fmt.Printf("Value is %s.\n", v)
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+	}
+
+	// Output:
+	// Value is 12.
+}
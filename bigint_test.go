@@ -0,0 +1,295 @@
+package mmath_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+)
+
+func TestBigInt(t *testing.T) {
+	t.Parallel()
+
+	c := func(i int64) mmath.CalculationBigInt {
+		return mmath.NewConstantBigInt(big.NewInt(i))
+	}
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	testcases := map[string]testcaseBigInt{
+		"sum/no_summands": {
+			calculation:   mmath.NewSumBigInt(),
+			expectedValue: big.NewInt(0),
+		},
+		"sum/beyond_int64": {
+			calculation: mmath.NewSumBigInt(
+				c(math.MaxInt64),
+				c(math.MaxInt64),
+			),
+			expectedValue: new(big.Int).Mul(big.NewInt(math.MaxInt64), big.NewInt(2)),
+		},
+		"sum/errors": {
+			calculation: mmath.NewSumBigInt(
+				mmath.NewFailingCalculation(fmt.Errorf("foobar")),
+				mmath.NewFailingCalculation(fmt.Errorf("xyz")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("foobar"),
+				errorContainsString("xyz"),
+			),
+		},
+		"product/no_factors": {
+			calculation:   mmath.NewProductBigInt(),
+			expectedValue: big.NewInt(1),
+		},
+		"product/huge": {
+			calculation:   mmath.NewProductBigInt(mmath.NewConstantBigInt(huge), c(-1)),
+			expectedValue: new(big.Int).Neg(huge),
+		},
+		"product/errors": {
+			calculation: mmath.NewProductBigInt(
+				mmath.NewFailingCalculation(fmt.Errorf("abc")),
+				mmath.NewFailingCalculation(fmt.Errorf("123")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("abc"),
+				errorContainsString("123"),
+			),
+		},
+		"difference/value": {
+			calculation:   mmath.NewDifferenceBigInt(c(3), c(10)),
+			expectedValue: big.NewInt(-7),
+		},
+		"negation/value": {
+			calculation:   mmath.NewNegationBigInt(c(3)),
+			expectedValue: big.NewInt(-3),
+		},
+		"quotient/value": {
+			calculation:   mmath.NewQuotientBigInt(c(-7), c(2)),
+			expectedValue: big.NewInt(-3),
+		},
+		"quotient/by_zero": {
+			calculation:       mmath.NewQuotientBigInt(c(7), c(0)),
+			expectedErrorFunc: errorIsDivisionByZero("quotient"),
+		},
+		"remainder/value": {
+			calculation:   mmath.NewRemainderBigInt(c(-7), c(2)),
+			expectedValue: big.NewInt(-1),
+		},
+		"remainder/by_zero": {
+			calculation:       mmath.NewRemainderBigInt(c(7), c(0)),
+			expectedErrorFunc: errorIsDivisionByZero("remainder"),
+		},
+		"pow/value": {
+			calculation:   mmath.NewPowBigInt(c(2), c(100)),
+			expectedValue: new(big.Int).Lsh(big.NewInt(1), 100),
+		},
+		"pow/negative_exponent": {
+			calculation:       mmath.NewPowBigInt(c(2), c(-1)),
+			expectedErrorFunc: errorContainsString("negative exponent"),
+		},
+		"pow/errors": {
+			calculation: mmath.NewPowBigInt(
+				mmath.NewFailingCalculation(fmt.Errorf("base")),
+				mmath.NewFailingCalculation(fmt.Errorf("exponent")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("base"),
+				errorContainsString("exponent"),
+			),
+		},
+		"gcd/value": {
+			calculation:   mmath.NewGCDBigInt(c(-12), c(18)),
+			expectedValue: big.NewInt(6),
+		},
+		"gcd/zeros": {
+			calculation:   mmath.NewGCDBigInt(c(0), c(0)),
+			expectedValue: big.NewInt(0),
+		},
+		"conditional/true": {
+			calculation:   mmath.NewConditionalBigInt(mmath.NewTrue(), c(1), c(2)),
+			expectedValue: big.NewInt(1),
+		},
+		"conditional/error": {
+			calculation:       mmath.NewConditionalBigInt(mmath.NewFailingCalculation(fmt.Errorf("hello")), c(1), c(2)),
+			expectedErrorFunc: errorContainsString("hello"),
+		},
+		"reduceLeft/reduceError": {
+			calculation: mmath.NewReduceLeftBigInt(
+				func(_, _ *big.Int) (*big.Int, error) {
+					return nil, fmt.Errorf("reduce failure")
+				},
+				c(0),
+				[]mmath.CalculationBigInt{c(0)},
+			),
+			expectedErrorFunc: errorContainsString("reduce failure"),
+		},
+		"fromInt64/value": {
+			calculation:   mmath.NewInt64ToBigInt(mmath.NewConstantInt64(math.MinInt64)),
+			expectedValue: big.NewInt(math.MinInt64),
+		},
+		"fromInt64/error": {
+			calculation:       mmath.NewInt64ToBigInt(mmath.NewFailingCalculation(fmt.Errorf("int failed"))),
+			expectedErrorFunc: errorContainsString("int failed"),
+		},
+	}
+
+	runTestcasesBigInt(t, testcases)
+}
+
+func TestBigIntToInt64(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]testcaseInt64{
+		"value": {
+			calculation:   mmath.NewBigIntToInt64(mmath.NewConstantBigInt(big.NewInt(math.MaxInt64))),
+			expectedValue: math.MaxInt64,
+		},
+		"tooLarge": {
+			calculation: mmath.NewBigIntToInt64(
+				mmath.NewSumBigInt(
+					mmath.NewConstantBigInt(big.NewInt(math.MaxInt64)),
+					mmath.NewConstantBigInt(big.NewInt(1)),
+				),
+			),
+			expectedErrorFunc: errorIsRange("int64"),
+		},
+	}
+
+	runTestcasesInt64(t, testcases)
+}
+
+func TestBigIntComparison(t *testing.T) {
+	t.Parallel()
+
+	operators := map[string]struct {
+		create func(first, second mmath.CalculationBigInt) mmath.CalculationBoolFunc
+
+		// Expected results for comparing 1 with 2, 2 with 2 and 3 with 2.
+		less, equal, greater bool
+	}{
+		"equals":         {create: mmath.NewBigIntEquals, less: false, equal: true, greater: false},
+		"notEquals":      {create: mmath.NewBigIntNotEquals, less: true, equal: false, greater: true},
+		"less":           {create: mmath.NewBigIntLess, less: true, equal: false, greater: false},
+		"lessOrEqual":    {create: mmath.NewBigIntLessOrEqual, less: true, equal: true, greater: false},
+		"greater":        {create: mmath.NewBigIntGreater, less: false, equal: false, greater: true},
+		"greaterOrEqual": {create: mmath.NewBigIntGreaterOrEqual, less: false, equal: true, greater: true},
+	}
+
+	c := func(i int64) mmath.CalculationBigInt {
+		return mmath.NewConstantBigInt(big.NewInt(i))
+	}
+
+	testcases := make(map[string]testcaseBool)
+	for name, operator := range operators {
+		testcases[name+"/less"] = testcaseBool{
+			calculation:   operator.create(c(1), c(2)),
+			expectedValue: operator.less,
+		}
+		testcases[name+"/equal"] = testcaseBool{
+			calculation:   operator.create(c(2), c(2)),
+			expectedValue: operator.equal,
+		}
+		testcases[name+"/greater"] = testcaseBool{
+			calculation:   operator.create(c(3), c(2)),
+			expectedValue: operator.greater,
+		}
+		testcases[name+"/errors"] = testcaseBool{
+			calculation: operator.create(
+				mmath.NewFailingCalculation(fmt.Errorf("broken")),
+				mmath.NewFailingCalculation(fmt.Errorf("meh")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("broken"),
+				errorContainsString("meh"),
+			),
+		}
+	}
+
+	runTestcasesBool(t, testcases)
+}
+
+func TestBigIntResultsAreNotShared(t *testing.T) {
+	t.Parallel()
+
+	input := big.NewInt(5)
+	constant := mmath.NewConstantBigInt(input)
+	variable := mmath.NewVariableBigInt()
+	variable.Set(input)
+	sum := mmath.NewSumBigInt(constant, variable)
+
+	input.SetInt64(100)
+
+	for i := 0; i < 2; i++ {
+		for name, calculation := range map[string]mmath.CalculationBigInt{"constant": constant, "variable": variable} {
+			v, err := calculation.CalculateBigInt()
+			if err != nil {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			if v.Int64() != 5 {
+				t.Errorf("expected %s to return 5, got %s", name, v)
+			}
+			v.SetInt64(1000)
+		}
+
+		v, err := sum.CalculateBigInt()
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if v.Int64() != 10 {
+			t.Errorf("expected sum to return 10, got %s", v)
+		}
+		v.SetInt64(1000)
+	}
+}
+
+func runTestcasesBigInt(t *testing.T, testcases map[string]testcaseBigInt) {
+	for name := range testcases {
+		testcaseBigInt := testcases[name]
+
+		t.Run(
+			name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				actualValue, actualErr := testcaseBigInt.calculation.CalculateBigInt()
+
+				if testcaseBigInt.expectedValue != nil && (actualValue == nil || actualValue.Cmp(testcaseBigInt.expectedValue) != 0) {
+					t.Errorf(
+						"expected calculation result value to be %s, but got %s",
+						testcaseBigInt.expectedValue,
+						actualValue,
+					)
+				}
+
+				if testcaseBigInt.expectedErrorFunc == nil && actualErr != nil {
+					t.Errorf("expected no error, but got %+v", actualErr)
+				}
+
+				if testcaseBigInt.expectedErrorFunc != nil {
+					if actualErr != nil {
+						testcaseBigInt.expectedErrorFunc(t, actualErr)
+					}
+					if actualErr == nil {
+						t.Errorf("expected non-nil error")
+					}
+				}
+			},
+		)
+	}
+}
+
+type testcaseBigInt struct {
+	// calculation is the calculation executed by the test. It's result is
+	// compared against the expected values.
+	calculation mmath.CalculationBigInt
+
+	// expectedValue is the value the calculation should return. If nil, the
+	// value is not checked.
+	expectedValue *big.Int
+
+	// expectedErrorFunc checks the error. This being nil is equivalent to
+	// checking wether the error should be nil.
+	expectedErrorFunc errorTest
+}
@@ -1,5 +1,9 @@
 package mmath
 
+import (
+	"sync"
+)
+
 // CalculationBool represents a calculation that returns an bool.
 type CalculationBool interface {
 	// CalculateBool returns the bool value calculated by this calculator.
@@ -16,10 +20,16 @@ func (f CalculationBoolFunc) CalculateBool() (bool, error) {
 
 // NewConstantBool returns a calculation which always returns the value passed
 // on creation.
-func NewConstantBool(b bool) CalculationBoolFunc {
-	return func() (bool, error) {
-		return b, nil
-	}
+func NewConstantBool(b bool) CalculationBool {
+	return newBoolNode(
+		KindConstant,
+		nil,
+		[]interface{}{b},
+		nil,
+		func() (bool, error) {
+			return b, nil
+		},
+	)
 }
 
 // NewTrue returns a calculation which is always true.
@@ -33,11 +43,26 @@ func NewFalse() CalculationBool {
 }
 
 // NewVariableBool creates a variable. In calculations, it returns the value
-// it was set to. Calculating the result of a variable never fails.
+// it was set to. Calculating the result of a variable never fails. The
+// variable is safe for concurrent use.
 func NewVariableBool() VariableBool {
 	return &variableBool{}
 }
 
+// NewObservableVariableBool works like NewObservableVariableInt64, but for
+// bool variables.
+func NewObservableVariableBool() ObservableVariableBool {
+	return &variableBool{}
+}
+
+// NewNamedVariableBool works like NewObservableVariableBool, but the variable
+// has a name, which is used when formatting calculations containing it.
+func NewNamedVariableBool(name string) ObservableVariableBool {
+	return &variableBool{
+		name: name,
+	}
+}
+
 // VariableBool represents a variable value, which can be set from the outside.
 type VariableBool interface {
 	CalculationBool
@@ -45,51 +70,220 @@ type VariableBool interface {
 	Set(b bool)
 }
 
+// ObservableVariableBool is a variable notifying subscribers about changes of
+// its value, see ObservableVariableInt64.
+type ObservableVariableBool interface {
+	VariableBool
+
+	// Subscribe registers f, which is called with the new value every time the
+	// value of the variable changes. Calling unsubscribe unregisters f.
+	Subscribe(f func(value bool)) (unsubscribe func())
+}
+
 type variableBool struct {
-	b bool
+	mutex         sync.RWMutex
+	b             bool
+	name          string
+	version       uint64
+	subscriptions subscriptions
 }
 
 func (v *variableBool) CalculateBool() (bool, error) {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
 	return v.b, nil
 }
 
 func (v *variableBool) Set(b bool) {
+	if v.set(b) {
+		notify(v.subscriptions.list())
+	}
+}
+
+// set sets the variable without notifying subscribers and returns wether the
+// value changed.
+func (v *variableBool) set(b bool) bool {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	changed := v.b != b
 	v.b = b
+	v.version++
+	return changed
+}
+
+func (v *variableBool) Subscribe(f func(value bool)) func() {
+	return v.subscriptions.add(
+		nil,
+		func() {
+			value, _ := v.CalculateBool()
+			f(value)
+		},
+	)
+}
+
+func (v *variableBool) subscribe(owner interface{}, notify func()) func() {
+	return v.subscriptions.add(owner, notify)
+}
+
+func (v *variableBool) isVolatile() bool {
+	return false
+}
+
+func (v *variableBool) snapshot() func() bool {
+	old, _ := v.CalculateBool()
+	return func() bool {
+		current, _ := v.CalculateBool()
+		return current != old
+	}
+}
+
+func (v *variableBool) notifications() []*subscription {
+	return v.subscriptions.list()
+}
+
+func (v *variableBool) currentVersion() uint64 {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+	return v.version
+}
+
+func (v *variableBool) Kind() Kind {
+	return KindVariable
+}
+
+func (v *variableBool) Children() []Node {
+	return nil
+}
+
+func (v *variableBool) Params() []interface{} {
+	return []interface{}{v.name}
+}
+
+func (v *variableBool) WithChildren(children ...Node) (Node, error) {
+	return withoutChildren(v, children)
+}
+
+// String returns the name of the variable, see FormatFormula.
+func (v *variableBool) String() string {
+	return FormatFormula(v)
 }
 
 // NewNot returns the negated value of the wrapped calculation, except for when
 // that calculation returns an error. In that case, the error is returned.
-func NewNot(wrappedCalc CalculationBool) CalculationBoolFunc {
-	return func() (bool, error) {
-		b, err := wrappedCalc.CalculateBool()
-		if err != nil {
-			return false, err
-		}
-		return !b, nil
-	}
+func NewNot(wrappedCalc CalculationBool) CalculationBool {
+	return createFallibleUnaryBool(
+		KindNot,
+		func(b bool) (bool, error) {
+			return !b, nil
+		},
+	)(wrappedCalc)
 }
 
 // NewInt64Equals returns wether the result of the first and second calculations
 // are equal. If one or both return an error, return an error combining those
 // errors instead.
-func NewInt64Equals(first, second CalculationInt64) CalculationBoolFunc {
-	return func() (bool, error) {
-		var errs errors
+func NewInt64Equals(first, second CalculationInt64) CalculationBool {
+	return newInt64Comparison(
+		KindEquals,
+		func(first, second int64) bool {
+			return first == second
+		},
+	)(first, second)
+}
 
-		firstValue, err := first.CalculateInt64()
-		if err != nil {
-			errs = append(errs, err)
-		}
+// NewCreateFallibleUnaryBool wraps a unary function bool -> (bool, error) and
+// returns a calculation constructor representing the same calculation. If the
+// operand fails, that error is returned, else the result of f.
+func NewCreateFallibleUnaryBool(
+	f func(value bool) (bool, error),
+) func(calculation CalculationBool) CalculationBool {
+	return createFallibleUnaryBool(KindFunction, f)
+}
 
-		secondValue, err := second.CalculateInt64()
-		if err != nil {
-			errs = append(errs, err)
-		}
+func createFallibleUnaryBool(
+	kind Kind,
+	f func(value bool) (bool, error),
+) func(calculation CalculationBool) CalculationBool {
+	var create func(calculation CalculationBool) CalculationBool
+	create = func(calculation CalculationBool) CalculationBool {
+		return newBoolNode(kind, boolChildren(calculation), nil, rebuildUnary(kind, create), func() (bool, error) {
+			b, err := calculation.CalculateBool()
+			if err != nil {
+				return false, err
+			}
+			return f(b)
+		}).withOperation(f)
+	}
+	return create
+}
+
+// NewCreateFallibleBinaryBool wraps a binary function (bool, bool) -> (bool, error)
+// and returns a calculation constructor representing the same calculation. If
+// one or both operands fail, an error combining those errors is returned, else
+// the result of f.
+func NewCreateFallibleBinaryBool(
+	f func(left, right bool) (bool, error),
+) func(left, right CalculationBool) CalculationBool {
+	var create func(left, right CalculationBool) CalculationBool
+	create = func(left, right CalculationBool) CalculationBool {
+		return newBoolNode(KindFunction, boolChildren(left, right), nil, rebuildBinary(KindFunction, create), func() (bool, error) {
+			var errs Errors
+
+			leftValue, err := left.CalculateBool()
+			if err != nil {
+				errs.add(0, err)
+			}
+
+			rightValue, err := right.CalculateBool()
+			if err != nil {
+				errs.add(1, err)
+			}
+
+			if len(errs) > 0 {
+				return false, errs
+			}
+
+			return f(leftValue, rightValue)
+		}).withOperation(f)
+	}
+	return create
+}
 
-		if len(errs) > 0 {
-			return false, errs
+// NewCreateFallibleNaryBool wraps a function taking any number of bool values
+// and returns a calculation constructor representing the same calculation. If
+// one or more operands fail, an error combining those errors is returned, else
+// the result of f.
+func NewCreateFallibleNaryBool(
+	f func(values []bool) (bool, error),
+) func(calculations ...CalculationBool) CalculationBool {
+	var create func(calculations ...CalculationBool) CalculationBool
+	create = func(calculations ...CalculationBool) CalculationBool {
+		return newBoolNode(KindFunction, boolChildren(calculations...), nil, rebuildNary(KindFunction, create), func() (bool, error) {
+			values, err := runCalculationsBool(calculations...)
+			if err != nil {
+				return false, err
+			}
+			return f(values)
+		}).withOperation(f)
+	}
+	return create
+}
+
+func runCalculationsBool(calculations ...CalculationBool) ([]bool, error) {
+	var errs Errors
+	results := make([]bool, len(calculations))
+
+	for i := range calculations {
+		result, err := calculations[i].CalculateBool()
+		results[i] = result
+		if err != nil {
+			errs.add(i, err)
 		}
+	}
 
-		return firstValue == secondValue, nil
+	if len(errs) > 0 {
+		return nil, errs
 	}
+
+	return results, nil
 }
@@ -79,3 +79,68 @@ func ExampleNewInt64Equals() {
 	// false
 	// true
 }
+
+func ExampleNewAnd() {
+	hasStock := mmath.NewVariableBool()
+	isActive := mmath.NewVariableBool()
+
+	sellable := mmath.NewAnd(mmath.ShortCircuit, hasStock, isActive)
+
+	hasStock.Set(true)
+	isActive.Set(false)
+
+	b, err := sellable.CalculateBool()
+	fmt.Printf("%t\n", b)
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+	}
+
+	isActive.Set(true)
+
+	b, err = sellable.CalculateBool()
+	fmt.Printf("%t\n", b)
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+	}
+
+	// Output:
+	// false
+	// true
+}
+
+func ExampleNewInt64Less() {
+	stock := mmath.NewVariableInt64()
+	lowStock := mmath.NewInt64Less(stock, mmath.NewConstantInt64(10))
+
+	stock.Set(7)
+
+	b, err := lowStock.CalculateBool()
+	fmt.Printf("%t\n", b)
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+	}
+
+	// Output:
+	// true
+}
+
+func ExampleNewInt64Between() {
+	v := mmath.NewVariableInt64()
+	inRange := mmath.NewInt64Between(v, mmath.NewConstantInt64(1), mmath.NewConstantInt64(5))
+
+	for _, i := range []int64{0, 1, 5, 6} {
+		v.Set(i)
+
+		b, err := inRange.CalculateBool()
+		fmt.Printf("%d: %t\n", i, b)
+		if err != nil {
+			fmt.Printf("Error is: %v\n", err)
+		}
+	}
+
+	// Output:
+	// 0: false
+	// 1: true
+	// 5: true
+	// 6: false
+}
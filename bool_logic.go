@@ -0,0 +1,253 @@
+package mmath
+
+import (
+	"fmt"
+)
+
+// EvaluationStrategy determines how logical operators like NewAnd evaluate
+// their operands. Only ShortCircuit and Strict are valid, logical operators
+// panic for other strategies.
+type EvaluationStrategy int
+
+const (
+	// ShortCircuit evaluates operands from left to right and stops as soon as
+	// the result is known. If an operand fails, its error is returned and no
+	// further operands are evaluated.
+	ShortCircuit EvaluationStrategy = iota
+
+	// Strict always evaluates all operands. If one or more operands fail, an
+	// error combining all those errors is returned.
+	Strict
+)
+
+// String returns a human-readable name for the strategy.
+func (strategy EvaluationStrategy) String() string {
+	switch strategy {
+	case ShortCircuit:
+		return "short-circuit"
+	case Strict:
+		return "strict"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler, using the same names as
+// String. Unknown strategies cannot be marshaled.
+func (strategy EvaluationStrategy) MarshalText() ([]byte, error) {
+	if strategy != ShortCircuit && strategy != Strict {
+		return nil, fmt.Errorf("unknown evaluation strategy %d", int(strategy))
+	}
+	return []byte(strategy.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting the names
+// returned by String.
+func (strategy *EvaluationStrategy) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case ShortCircuit.String():
+		*strategy = ShortCircuit
+	case Strict.String():
+		*strategy = Strict
+	default:
+		return fmt.Errorf("unknown evaluation strategy %q", string(text))
+	}
+	return nil
+}
+
+// NewAnd returns a calculation which is true if all operands are true. Without
+// operands, it is true. With ShortCircuit, evaluation stops at the first false
+// operand.
+func NewAnd(strategy EvaluationStrategy, calculations ...CalculationBool) CalculationBool {
+	return newLogicalOperator(
+		KindAnd,
+		NewAnd,
+		strategy,
+		calculations,
+		func(values []bool) (bool, bool) {
+			if !values[len(values)-1] {
+				return false, true
+			}
+			return false, false
+		},
+		func(values []bool) bool {
+			for i := range values {
+				if !values[i] {
+					return false
+				}
+			}
+			return true
+		},
+	)
+}
+
+// NewOr returns a calculation which is true if at least one operand is true.
+// Without operands, it is false. With ShortCircuit, evaluation stops at the
+// first true operand.
+func NewOr(strategy EvaluationStrategy, calculations ...CalculationBool) CalculationBool {
+	return newLogicalOperator(
+		KindOr,
+		NewOr,
+		strategy,
+		calculations,
+		func(values []bool) (bool, bool) {
+			if values[len(values)-1] {
+				return true, true
+			}
+			return false, false
+		},
+		func(values []bool) bool {
+			for i := range values {
+				if values[i] {
+					return true
+				}
+			}
+			return false
+		},
+	)
+}
+
+// NewXor returns a calculation which is true if an odd number of operands is
+// true. Without operands, it is false. As the result depends on every operand,
+// ShortCircuit only differs from Strict in stopping at the first error.
+func NewXor(strategy EvaluationStrategy, calculations ...CalculationBool) CalculationBool {
+	return newLogicalOperator(
+		KindXor,
+		NewXor,
+		strategy,
+		calculations,
+		nil,
+		func(values []bool) bool {
+			result := false
+			for i := range values {
+				result = result != values[i]
+			}
+			return result
+		},
+	)
+}
+
+// NewImplies returns a calculation which is true if the last operand is true
+// or at least one of the operands before it is false, i.e. a → (b → c) for
+// three operands. Without operands, it is true. With ShortCircuit, evaluation
+// stops at the first false premise.
+func NewImplies(strategy EvaluationStrategy, calculations ...CalculationBool) CalculationBool {
+	premises := len(calculations) - 1
+	return newLogicalOperator(
+		KindImplies,
+		NewImplies,
+		strategy,
+		calculations,
+		func(values []bool) (bool, bool) {
+			if len(values) <= premises && !values[len(values)-1] {
+				return true, true
+			}
+			return false, false
+		},
+		func(values []bool) bool {
+			for i := 0; i < premises; i++ {
+				if !values[i] {
+					return true
+				}
+			}
+			if len(values) == 0 {
+				return true
+			}
+			return values[len(values)-1]
+		},
+	)
+}
+
+// NewEquivalent returns a calculation which is true if all operands have the
+// same value. Without operands or with only one operand, it is true. Note that
+// for more than two operands, this differs from chaining binary equivalences.
+// With ShortCircuit, evaluation stops at the first operand differing from the
+// first one.
+func NewEquivalent(strategy EvaluationStrategy, calculations ...CalculationBool) CalculationBool {
+	return newLogicalOperator(
+		KindEquivalent,
+		NewEquivalent,
+		strategy,
+		calculations,
+		func(values []bool) (bool, bool) {
+			if values[len(values)-1] != values[0] {
+				return false, true
+			}
+			return false, false
+		},
+		func(values []bool) bool {
+			for i := range values {
+				if values[i] != values[0] {
+					return false
+				}
+			}
+			return true
+		},
+	)
+}
+
+// newLogicalOperator creates a logical operator. decide is called by
+// ShortCircuit after every evaluated operand with all values so far and
+// returns the result and wether that result is final. It may be nil if the
+// result can only be known after evaluating all operands. combine is called
+// with all values if all operands have been evaluated. create is the exported
+// constructor, it is used for creating the operator with other children.
+func newLogicalOperator(
+	kind Kind,
+	create func(strategy EvaluationStrategy, calculations ...CalculationBool) CalculationBool,
+	strategy EvaluationStrategy,
+	calculations []CalculationBool,
+	decide func(values []bool) (bool, bool),
+	combine func(values []bool) bool,
+) CalculationBool {
+	mustBeValidEvaluationStrategy(strategy)
+	return newBoolNode(
+		kind,
+		boolChildren(calculations...),
+		[]interface{}{strategy},
+		rebuildNary(kind, func(calculations ...CalculationBool) CalculationBool {
+			return create(strategy, calculations...)
+		}),
+		logicalOperatorCalculation(strategy, calculations, decide, combine),
+	).withOperation(combine)
+}
+
+func mustBeValidEvaluationStrategy(strategy EvaluationStrategy) {
+	if strategy != ShortCircuit && strategy != Strict {
+		panic(fmt.Sprintf("unknown evaluation strategy %d", int(strategy)))
+	}
+}
+
+func logicalOperatorCalculation(
+	strategy EvaluationStrategy,
+	calculations []CalculationBool,
+	decide func(values []bool) (bool, bool),
+	combine func(values []bool) bool,
+) func() (bool, error) {
+	if strategy == Strict {
+		return func() (bool, error) {
+			values, err := runCalculationsBool(calculations...)
+			if err != nil {
+				return false, err
+			}
+			return combine(values), nil
+		}
+	}
+	return func() (bool, error) {
+		values := make([]bool, 0, len(calculations))
+		for i := range calculations {
+			b, err := calculations[i].CalculateBool()
+			if err != nil {
+				return false, err
+			}
+			values = append(values, b)
+			if decide == nil {
+				continue
+			}
+			if result, final := decide(values); final {
+				return result, nil
+			}
+		}
+		return combine(values), nil
+	}
+}
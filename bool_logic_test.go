@@ -0,0 +1,194 @@
+package mmath_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+)
+
+type logicalOperatorConstructor func(mmath.EvaluationStrategy, ...mmath.CalculationBool) mmath.CalculationBool
+
+func TestLogicalOperatorsTruthTables(t *testing.T) {
+	t.Parallel()
+
+	// Truth tables map operands, written as a string of 't' and 'f', to the
+	// expected result.
+	operators := map[string]struct {
+		create     logicalOperatorConstructor
+		truthTable map[string]bool
+	}{
+		"and": {
+			create: mmath.NewAnd,
+			truthTable: map[string]bool{
+				"": true, "f": false, "t": true,
+				"ff": false, "ft": false, "tf": false, "tt": true,
+				"ttt": true, "ttf": false, "ftt": false,
+			},
+		},
+		"or": {
+			create: mmath.NewOr,
+			truthTable: map[string]bool{
+				"": false, "f": false, "t": true,
+				"ff": false, "ft": true, "tf": true, "tt": true,
+				"fff": false, "fft": true, "tff": true,
+			},
+		},
+		"xor": {
+			create: mmath.NewXor,
+			truthTable: map[string]bool{
+				"": false, "f": false, "t": true,
+				"ff": false, "ft": true, "tf": true, "tt": false,
+				"ttt": true, "ttf": false, "tff": true,
+			},
+		},
+		"implies": {
+			create: mmath.NewImplies,
+			truthTable: map[string]bool{
+				"": true, "f": false, "t": true,
+				"ff": true, "ft": true, "tf": false, "tt": true,
+				"ttf": false, "tft": true, "ftf": true, "ttt": true,
+			},
+		},
+		"equivalent": {
+			create: mmath.NewEquivalent,
+			truthTable: map[string]bool{
+				"": true, "f": true, "t": true,
+				"ff": true, "ft": false, "tf": false, "tt": true,
+				"fff": true, "ttt": true, "ttf": false, "ftt": false,
+			},
+		},
+	}
+
+	strategies := map[string]mmath.EvaluationStrategy{
+		"shortCircuit": mmath.ShortCircuit,
+		"strict":       mmath.Strict,
+	}
+
+	testcases := make(map[string]testcaseBool)
+	for operatorName, operator := range operators {
+		for strategyName, strategy := range strategies {
+			for operands, expectedValue := range operator.truthTable {
+				testcases[operatorName+"/"+strategyName+"/"+operands] = testcaseBool{
+					calculation:   operator.create(strategy, boolOperands(operands)...),
+					expectedValue: expectedValue,
+				}
+			}
+		}
+	}
+
+	runTestcasesBool(t, testcases)
+}
+
+func boolOperands(operands string) []mmath.CalculationBool {
+	calculations := make([]mmath.CalculationBool, len(operands))
+	for i := range operands {
+		calculations[i] = mmath.NewConstantBool(operands[i] == 't')
+	}
+	return calculations
+}
+
+func TestLogicalOperatorsShortCircuit(t *testing.T) {
+	t.Parallel()
+
+	failing := func(msg string) mmath.CalculationBool {
+		return mmath.NewFailingCalculation(fmt.Errorf(msg))
+	}
+
+	testcases := map[string]testcaseBool{
+		"and/skipped": {
+			calculation:   mmath.NewAnd(mmath.ShortCircuit, mmath.NewFalse(), failing("skipped")),
+			expectedValue: false,
+		},
+		"or/skipped": {
+			calculation:   mmath.NewOr(mmath.ShortCircuit, mmath.NewTrue(), failing("skipped")),
+			expectedValue: true,
+		},
+		"implies/skipped": {
+			calculation:   mmath.NewImplies(mmath.ShortCircuit, mmath.NewFalse(), failing("skipped"), failing("skipped")),
+			expectedValue: true,
+		},
+		"equivalent/skipped": {
+			calculation:   mmath.NewEquivalent(mmath.ShortCircuit, mmath.NewTrue(), mmath.NewFalse(), failing("skipped")),
+			expectedValue: false,
+		},
+		"and/firstErrorOnly": {
+			calculation: mmath.NewAnd(mmath.ShortCircuit, failing("first"), failing("second")),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("first"),
+				errorNotContainsString("second"),
+			),
+		},
+		"xor/firstErrorOnly": {
+			calculation: mmath.NewXor(mmath.ShortCircuit, mmath.NewTrue(), failing("first"), failing("second")),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("first"),
+				errorNotContainsString("second"),
+			),
+		},
+		"implies/conclusionError": {
+			calculation:       mmath.NewImplies(mmath.ShortCircuit, mmath.NewTrue(), failing("conclusion")),
+			expectedErrorFunc: errorContainsString("conclusion"),
+		},
+	}
+
+	runTestcasesBool(t, testcases)
+}
+
+func TestLogicalOperatorsStrict(t *testing.T) {
+	t.Parallel()
+
+	operators := map[string]logicalOperatorConstructor{
+		"and":        mmath.NewAnd,
+		"or":         mmath.NewOr,
+		"xor":        mmath.NewXor,
+		"implies":    mmath.NewImplies,
+		"equivalent": mmath.NewEquivalent,
+	}
+
+	testcases := make(map[string]testcaseBool)
+	for name, create := range operators {
+		testcases[name] = testcaseBool{
+			calculation: create(
+				mmath.Strict,
+				mmath.NewFalse(),
+				mmath.NewTrue(),
+				mmath.NewFailingCalculation(fmt.Errorf("foo")),
+				mmath.NewFailingCalculation(fmt.Errorf("bar")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("foo"),
+				errorContainsString("bar"),
+			),
+		}
+	}
+
+	runTestcasesBool(t, testcases)
+}
+
+func TestLogicalOperatorsUnknownStrategy(t *testing.T) {
+	t.Parallel()
+
+	operators := map[string]logicalOperatorConstructor{
+		"and":        mmath.NewAnd,
+		"or":         mmath.NewOr,
+		"xor":        mmath.NewXor,
+		"implies":    mmath.NewImplies,
+		"equivalent": mmath.NewEquivalent,
+	}
+
+	for name := range operators {
+		create := operators[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic")
+				}
+			}()
+
+			create(mmath.EvaluationStrategy(42), mmath.NewTrue())
+		})
+	}
+}
@@ -56,6 +56,87 @@ func TestInt64Comparison(t *testing.T) {
 	runTestcasesBool(t, testcases)
 }
 
+func TestCreateFallibleBool(t *testing.T) {
+	t.Parallel()
+
+	xor := mmath.NewCreateFallibleBinaryBool(
+		func(left, right bool) (bool, error) {
+			return left != right, nil
+		},
+	)
+	allTrue := mmath.NewCreateFallibleNaryBool(
+		func(values []bool) (bool, error) {
+			if len(values) == 0 {
+				return false, fmt.Errorf("no values")
+			}
+			for i := range values {
+				if !values[i] {
+					return false, nil
+				}
+			}
+			return true, nil
+		},
+	)
+	mustBeTrue := mmath.NewCreateFallibleUnaryBool(
+		func(b bool) (bool, error) {
+			if !b {
+				return false, fmt.Errorf("must be true")
+			}
+			return b, nil
+		},
+	)
+
+	testcases := map[string]testcaseBool{
+		"binary/value": {
+			calculation:   xor(mmath.NewTrue(), mmath.NewFalse()),
+			expectedValue: true,
+		},
+		"binary/errors": {
+			calculation: xor(
+				mmath.NewFailingCalculation(fmt.Errorf("left")),
+				mmath.NewFailingCalculation(fmt.Errorf("right")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("left"),
+				errorContainsString("right"),
+			),
+		},
+		"nary/value": {
+			calculation:   allTrue(mmath.NewTrue(), mmath.NewTrue(), mmath.NewTrue()),
+			expectedValue: true,
+		},
+		"nary/errors": {
+			calculation: allTrue(
+				mmath.NewFailingCalculation(fmt.Errorf("foo")),
+				mmath.NewTrue(),
+				mmath.NewFailingCalculation(fmt.Errorf("bar")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("foo"),
+				errorContainsString("bar"),
+			),
+		},
+		"nary/failure": {
+			calculation:       allTrue(),
+			expectedErrorFunc: errorContainsString("no values"),
+		},
+		"unary/value": {
+			calculation:   mustBeTrue(mmath.NewTrue()),
+			expectedValue: true,
+		},
+		"unary/error": {
+			calculation:       mustBeTrue(mmath.NewFailingCalculation(fmt.Errorf("broken"))),
+			expectedErrorFunc: errorContainsString("broken"),
+		},
+		"unary/failure": {
+			calculation:       mustBeTrue(mmath.NewFalse()),
+			expectedErrorFunc: errorContainsString("must be true"),
+		},
+	}
+
+	runTestcasesBool(t, testcases)
+}
+
 func runTestcasesBool(t *testing.T, testcases map[string]testcaseBool) {
 	for name := range testcases {
 		testcaseBool := testcases[name]
@@ -0,0 +1,620 @@
+package mmath
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CompileInt64 compiles calculation into a program for a small stack machine,
+// which calculates the same results as calculation, including errors. Instead
+// of calling one closure per operand, the program runs a flat list of
+// instructions in a single loop without allocating, unless functions like
+// those created via NewCreateFallibleNaryInt64 need their operands as slice.
+//
+// Sums, products, differences, negations, checked arithmetic, divisions,
+// reductions, comparisons, conditionals, negations via NewNot and the
+// logical operators NewAnd and NewOr are compiled, as are functions created via
+// NewCreateFallibleUnaryInt64, NewCreateFallibleBinaryInt64 and similar
+// constructors. All other calculations, e.g. variables, calculations which
+// are no nodes or nodes of kinds not created by this package, are called as
+// they are by the program.
+//
+// Compiling pays off for calculations which are calculated many times, e.g.
+// with changing variables. The program is safe for concurrent use if all
+// calculations called by it are.
+func CompileInt64(calculation CalculationInt64) *ProgramInt64 {
+	node := NodeOf(calculation)
+	c := newCompiler(node)
+	c.compileInt64(node)
+	return &ProgramInt64{
+		Node:    node,
+		program: c.program(),
+	}
+}
+
+// CompileBool works like CompileInt64, but for bool calculations.
+func CompileBool(calculation CalculationBool) *ProgramBool {
+	node := NodeOf(calculation)
+	c := newCompiler(node)
+	c.compileBool(node)
+	return &ProgramBool{
+		Node:    node,
+		program: c.program(),
+	}
+}
+
+// ProgramInt64 is a compiled calculation, see CompileInt64. Its Node is the
+// original calculation.
+type ProgramInt64 struct {
+	Node
+	program *program
+}
+
+// CalculateInt64 runs the program.
+func (p *ProgramInt64) CalculateInt64() (int64, error) {
+	return p.program.run()
+}
+
+// Disassemble returns a human-readable listing of the instructions of the
+// program, one per line.
+func (p *ProgramInt64) Disassemble() string {
+	return p.program.disassemble()
+}
+
+// ProgramBool is a compiled calculation, see CompileBool.
+type ProgramBool struct {
+	Node
+	program *program
+}
+
+// CalculateBool runs the program.
+func (p *ProgramBool) CalculateBool() (bool, error) {
+	value, err := p.program.run()
+	return value != 0, err
+}
+
+// Disassemble returns a human-readable listing of the instructions of the
+// program, one per line.
+func (p *ProgramBool) Disassemble() string {
+	return p.program.disassemble()
+}
+
+type opcode uint8
+
+const (
+	// opConstant pushes constants[arg].
+	opConstant opcode = iota
+
+	// opCallInt64 pushes the result of int64s[arg].
+	opCallInt64
+
+	// opCallBool pushes the result of bools[arg].
+	opCallBool
+
+	// opSum replaces the operands on top of the stack by their sum.
+	opSum
+
+	// opProduct replaces the operands on top of the stack by their product.
+	opProduct
+
+	// opUnary replaces the top of the stack by the result of unaries[arg].
+	opUnary
+
+	// opBinary replaces the operands on top of the stack by the result of
+	// binaries[arg].
+	opBinary
+
+	// opNary replaces the operands on top of the stack by the result of
+	// naries[arg].
+	opNary
+
+	// opReduce replaces the operands and the initial value below them by the
+	// result of reductions[arg]. The initial value has already been checked
+	// for errors by opSkipIfFailed.
+	opReduce
+
+	// opCompare replaces the operands on top of the stack by wether
+	// comparisons[arg] holds for all neighbouring operands.
+	opCompare
+
+	// opUnaryBool replaces the top of the stack by the result of
+	// boolUnaries[arg].
+	opUnaryBool
+
+	// opAll replaces the operands on top of the stack by wether all of them
+	// are true.
+	opAll
+
+	// opAny replaces the operands on top of the stack by wether at least one
+	// of them is true.
+	opAny
+
+	// opJump jumps to arg.
+	opJump
+
+	// opBranch pops the top of the stack and jumps to arg if it is false. If
+	// it failed, the error is pushed again and the program continues at the
+	// instruction before arg, which always is an opJump skipping the branch.
+	opBranch
+
+	// opSkipIfFailed jumps to arg if the top of the stack failed.
+	opSkipIfFailed
+
+	// opAndThen jumps to arg if the top of the stack is false or failed, else
+	// pops it.
+	opAndThen
+
+	// opOrElse jumps to arg if the top of the stack is true or failed, else
+	// pops it.
+	opOrElse
+)
+
+var opcodeNames = map[opcode]string{
+	opConstant:     "constant",
+	opCallInt64:    "call",
+	opCallBool:     "call",
+	opSum:          "sum",
+	opProduct:      "product",
+	opUnary:        "unary",
+	opBinary:       "binary",
+	opNary:         "nary",
+	opReduce:       "reduce",
+	opCompare:      "compare",
+	opUnaryBool:    "unary",
+	opAll:          "all",
+	opAny:          "any",
+	opJump:         "jump",
+	opBranch:       "branch",
+	opSkipIfFailed: "skipIfFailed",
+	opAndThen:      "andThen",
+	opOrElse:       "orElse",
+}
+
+type instruction struct {
+	op opcode
+
+	// operands is the number of operands taken from the stack by n-ary
+	// instructions.
+	operands int
+
+	// arg is an index into one of the tables of the program or the target of
+	// a jump, depending on op.
+	arg int
+}
+
+// program is a compiled calculation. Values and errors are kept on two
+// stacks of equal size, bools are stored as 0 or 1.
+type program struct {
+	code []instruction
+
+	// depth is the maximum size of the stacks.
+	depth int
+
+	constants   []int64
+	int64s      []CalculationInt64
+	bools       []CalculationBool
+	unaries     []func(value int64) (int64, error)
+	binaries    []func(left, right int64) (int64, error)
+	naries      []func(values []int64) (int64, error)
+	reductions  []reduceLeft
+	comparisons []func(first, second int64) bool
+	boolUnaries []func(value bool) (bool, error)
+
+	// labels contains a description of every instruction, see disassemble.
+	labels []string
+}
+
+// stackBufferSize is the stack size up to which running a program does not
+// allocate.
+const stackBufferSize = 32
+
+func (p *program) run() (int64, error) {
+	var valueBuffer [stackBufferSize]int64
+	var errBuffer [stackBufferSize]error
+	values, errs := valueBuffer[:], errBuffer[:]
+	if p.depth > stackBufferSize {
+		values, errs = make([]int64, p.depth), make([]error, p.depth)
+	}
+
+	sp := 0
+	for pc := 0; pc < len(p.code); pc++ {
+		in := p.code[pc]
+		switch in.op {
+		case opConstant:
+			values[sp], errs[sp] = p.constants[in.arg], nil
+			sp++
+		case opCallInt64:
+			values[sp], errs[sp] = p.int64s[in.arg].CalculateInt64()
+			sp++
+		case opCallBool:
+			b, err := p.bools[in.arg].CalculateBool()
+			values[sp], errs[sp] = fromBool(b), err
+			sp++
+		case opSum:
+			base := sp - in.operands
+			sp = base + 1
+			if failed(values, errs, base, base, in.operands) {
+				continue
+			}
+			result := int64(0)
+			for i := base; i < base+in.operands; i++ {
+				result += values[i]
+			}
+			values[base] = result
+		case opProduct:
+			base := sp - in.operands
+			sp = base + 1
+			if failed(values, errs, base, base, in.operands) {
+				continue
+			}
+			result := int64(1)
+			for i := base; i < base+in.operands; i++ {
+				result *= values[i]
+			}
+			values[base] = result
+		case opUnary:
+			top := sp - 1
+			if errs[top] != nil {
+				values[top] = 0
+				continue
+			}
+			values[top], errs[top] = p.unaries[in.arg](values[top])
+		case opBinary:
+			base := sp - 2
+			sp = base + 1
+			if failed(values, errs, base, base, 2) {
+				continue
+			}
+			values[base], errs[base] = p.binaries[in.arg](values[base], values[base+1])
+		case opNary:
+			base := sp - in.operands
+			sp = base + 1
+			if failed(values, errs, base, base, in.operands) {
+				continue
+			}
+			operands := append([]int64(nil), values[base:base+in.operands]...)
+			values[base], errs[base] = p.naries[in.arg](operands)
+		case opReduce:
+			rl := p.reductions[in.arg]
+			base := sp - in.operands
+			sp = base
+			if failed(values, errs, base-1, base, in.operands) {
+				if rl.firstIndex != 0 {
+					errs[base-1] = errs[base-1].(Errors).shift(rl.firstIndex)
+				}
+				continue
+			}
+			values[base-1], errs[base-1] = rl.fold(values[base-1], values[base:base+in.operands])
+		case opCompare:
+			base := sp - in.operands
+			sp = base + 1
+			if failed(values, errs, base, base, in.operands) {
+				continue
+			}
+			values[base] = fromBool(holdsForNeighbours(p.comparisons[in.arg], values[base:base+in.operands]))
+		case opUnaryBool:
+			top := sp - 1
+			if errs[top] != nil {
+				values[top] = 0
+				continue
+			}
+			b, err := p.boolUnaries[in.arg](values[top] != 0)
+			values[top], errs[top] = fromBool(b), err
+		case opAll:
+			base := sp - in.operands
+			sp = base + 1
+			if failed(values, errs, base, base, in.operands) {
+				continue
+			}
+			result := true
+			for i := base; i < base+in.operands; i++ {
+				result = result && values[i] != 0
+			}
+			values[base] = fromBool(result)
+		case opAny:
+			base := sp - in.operands
+			sp = base + 1
+			if failed(values, errs, base, base, in.operands) {
+				continue
+			}
+			result := false
+			for i := base; i < base+in.operands; i++ {
+				result = result || values[i] != 0
+			}
+			values[base] = fromBool(result)
+		case opJump:
+			pc = in.arg - 1
+		case opBranch:
+			top := sp - 1
+			switch {
+			case errs[top] != nil:
+				values[top] = 0
+				pc = in.arg - 2
+			case values[top] == 0:
+				sp--
+				pc = in.arg - 1
+			default:
+				sp--
+			}
+		case opSkipIfFailed:
+			top := sp - 1
+			if errs[top] != nil {
+				values[top] = 0
+				pc = in.arg - 1
+			}
+		case opAndThen:
+			top := sp - 1
+			switch {
+			case errs[top] != nil:
+				values[top] = 0
+				pc = in.arg - 1
+			case values[top] == 0:
+				pc = in.arg - 1
+			default:
+				sp--
+			}
+		case opOrElse:
+			top := sp - 1
+			switch {
+			case errs[top] != nil:
+				values[top] = 0
+				pc = in.arg - 1
+			case values[top] != 0:
+				pc = in.arg - 1
+			default:
+				sp--
+			}
+		}
+	}
+	return values[0], errs[0]
+}
+
+// failed checks wether one of the operands values[base:base+n] failed. If so,
+// the combined error is stored at target. Otherwise, errs[target] is reset.
+func failed(values []int64, errs []error, target, base, n int) bool {
+	for i := base; i < base+n; i++ {
+		if errs[i] == nil {
+			continue
+		}
+		var combined Errors
+		for j := i; j < base+n; j++ {
+			if errs[j] != nil {
+				combined.add(j-base, errs[j])
+			}
+		}
+		values[target], errs[target] = 0, combined
+		return true
+	}
+	errs[target] = nil
+	return false
+}
+
+func fromBool(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (p *program) disassemble() string {
+	lines := make([]string, len(p.code))
+	for i, in := range p.code {
+		line := fmt.Sprintf("%d: %s", i, opcodeNames[in.op])
+		switch in.op {
+		case opSum, opProduct, opNary, opReduce, opCompare, opAll, opAny:
+			line += fmt.Sprintf(" %d", in.operands)
+		case opJump, opBranch, opSkipIfFailed, opAndThen, opOrElse:
+			line += fmt.Sprintf(" %d", in.arg)
+		}
+		if p.labels[i] != "" {
+			line += " (" + p.labels[i] + ")"
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+type compiler struct {
+	p         *program
+	formatter *formatter
+
+	// depth is the size of the stacks after the instructions compiled so
+	// far.
+	depth int
+}
+
+func newCompiler(root Node) *compiler {
+	return &compiler{
+		p:         &program{},
+		formatter: newFormatter(root),
+	}
+}
+
+func (c *compiler) program() *program {
+	return c.p
+}
+
+// emit appends an instruction changing the size of the stacks by delta and
+// returns its position.
+func (c *compiler) emit(in instruction, delta int, label string) int {
+	c.p.code = append(c.p.code, in)
+	c.p.labels = append(c.p.labels, label)
+	c.depth += delta
+	if c.depth > c.p.depth {
+		c.p.depth = c.depth
+	}
+	return len(c.p.code) - 1
+}
+
+// emitOperator emits an instruction replacing n operands by one result.
+func (c *compiler) emitOperator(op opcode, n, arg int, node Node) {
+	c.emit(instruction{op: op, operands: n, arg: arg}, 1-n, c.formatter.label(node))
+}
+
+// target sets the jump target of the instruction at position at to the
+// position of the next instruction.
+func (c *compiler) target(at int) {
+	c.p.code[at].arg = len(c.p.code)
+}
+
+func (c *compiler) compileInt64(node Node) {
+	if n, ok := node.(*int64Node); ok && c.compileInt64Node(n) {
+		return
+	}
+	calculation := calculationOf(node).(CalculationInt64)
+	c.p.int64s = append(c.p.int64s, calculation)
+	c.emit(instruction{op: opCallInt64, arg: len(c.p.int64s) - 1}, 1, c.formatter.label(node))
+}
+
+func (c *compiler) compileBool(node Node) {
+	if n, ok := node.(*boolNode); ok && c.compileBoolNode(n) {
+		return
+	}
+	calculation := calculationOf(node).(CalculationBool)
+	c.p.bools = append(c.p.bools, calculation)
+	c.emit(instruction{op: opCallBool, arg: len(c.p.bools) - 1}, 1, c.formatter.label(node))
+}
+
+// compileInt64Node compiles node and returns wether that was possible.
+func (c *compiler) compileInt64Node(node *int64Node) bool {
+	children := node.Children()
+
+	switch node.kind {
+	case KindConstant:
+		c.compileConstant(node.params[0].(int64), node)
+		return true
+	case KindSum, KindProduct:
+		for i := range children {
+			c.compileInt64(children[i])
+		}
+		op := opSum
+		if node.kind == KindProduct {
+			op = opProduct
+		}
+		c.emitOperator(op, len(children), 0, node)
+		return true
+	case KindConditional:
+		c.compileBool(children[0])
+		branch := c.emit(instruction{op: opBranch}, -1, c.formatter.label(node))
+		c.compileInt64(children[1])
+		skip := c.emit(instruction{op: opJump}, 0, "")
+		c.target(branch)
+		c.depth--
+		c.compileInt64(children[2])
+		c.target(skip)
+		return true
+	}
+
+	switch operation := node.operation.(type) {
+	case func(value int64) (int64, error):
+		c.compileInt64(children[0])
+		c.p.unaries = append(c.p.unaries, operation)
+		c.emitOperator(opUnary, 1, len(c.p.unaries)-1, node)
+		return true
+	case func(left, right int64) (int64, error):
+		c.compileInt64(children[0])
+		c.compileInt64(children[1])
+		c.p.binaries = append(c.p.binaries, operation)
+		c.emitOperator(opBinary, 2, len(c.p.binaries)-1, node)
+		return true
+	case func(values []int64) (int64, error):
+		for i := range children {
+			c.compileInt64(children[i])
+		}
+		c.p.naries = append(c.p.naries, operation)
+		c.emitOperator(opNary, len(children), len(c.p.naries)-1, node)
+		return true
+	case reduceLeft:
+		// Reductions created by NewReduceLeft have their initial value as
+		// first child, all others a constant not contained in children.
+		operands := children[operation.firstIndex:]
+		if operation.firstIndex == 0 {
+			c.compileInt64(NodeOf(operation.initialValue))
+		} else {
+			c.compileInt64(children[0])
+		}
+		skip := c.emit(instruction{op: opSkipIfFailed}, 0, "")
+		for i := range operands {
+			c.compileInt64(operands[i])
+		}
+		c.p.reductions = append(c.p.reductions, operation)
+		c.emit(
+			instruction{op: opReduce, operands: len(operands), arg: len(c.p.reductions) - 1},
+			-len(operands),
+			c.formatter.label(node),
+		)
+		c.target(skip)
+		return true
+	}
+	return false
+}
+
+// compileBoolNode compiles node and returns wether that was possible.
+func (c *compiler) compileBoolNode(node *boolNode) bool {
+	children := node.Children()
+
+	switch node.kind {
+	case KindConstant:
+		c.compileConstant(fromBool(node.params[0].(bool)), node)
+		return true
+	case KindAnd, KindOr:
+		if node.params[0] == Strict {
+			for i := range children {
+				c.compileBool(children[i])
+			}
+			op := opAll
+			if node.kind == KindOr {
+				op = opAny
+			}
+			c.emitOperator(op, len(children), 0, node)
+			return true
+		}
+		c.compileShortCircuit(node, children)
+		return true
+	}
+
+	switch operation := node.operation.(type) {
+	case func(value bool) (bool, error):
+		c.compileBool(children[0])
+		c.p.boolUnaries = append(c.p.boolUnaries, operation)
+		c.emitOperator(opUnaryBool, 1, len(c.p.boolUnaries)-1, node)
+		return true
+	case func(first, second int64) bool:
+		for i := range children {
+			c.compileInt64(children[i])
+		}
+		c.p.comparisons = append(c.p.comparisons, operation)
+		c.emitOperator(opCompare, len(children), len(c.p.comparisons)-1, node)
+		return true
+	}
+	return false
+}
+
+// compileShortCircuit compiles NewAnd or NewOr using ShortCircuit. All
+// operands except the last one jump to the end if they decide the result,
+// else the last one is the result.
+func (c *compiler) compileShortCircuit(node *boolNode, children []Node) {
+	if len(children) == 0 {
+		c.compileConstant(fromBool(node.kind == KindAnd), node)
+		return
+	}
+	op := opAndThen
+	if node.kind == KindOr {
+		op = opOrElse
+	}
+	jumps := []int{}
+	for i := range children {
+		c.compileBool(children[i])
+		if i < len(children)-1 {
+			jumps = append(jumps, c.emit(instruction{op: op}, -1, c.formatter.label(node)))
+		}
+	}
+	for _, jump := range jumps {
+		c.target(jump)
+	}
+}
+
+func (c *compiler) compileConstant(value int64, node Node) {
+	c.p.constants = append(c.p.constants, value)
+	c.emit(instruction{op: opConstant, arg: len(c.p.constants) - 1}, 1, c.formatter.label(node))
+}
@@ -0,0 +1,41 @@
+package mmath_test
+
+import (
+	"github.com/GodsBoss/mmath"
+
+	"fmt"
+)
+
+func ExampleCompileInt64() {
+	quantity := mmath.NewNamedVariableInt64("quantity")
+
+	total := mmath.NewProductInt64(
+		quantity,
+		mmath.NewConditionalInt64(
+			mmath.NewInt64GreaterOrEqual(quantity, mmath.NewConstantInt64(10)),
+			mmath.NewConstantInt64(12),
+			mmath.NewConstantInt64(15),
+		),
+	)
+	program := mmath.CompileInt64(total)
+
+	for _, q := range []int64{4, 20} {
+		quantity.Set(q)
+		value, _ := program.CalculateInt64()
+		fmt.Printf("%d pieces cost %d.\n", q, value)
+	}
+	fmt.Println(program.Disassemble())
+
+	// Output:
+	// 4 pieces cost 60.
+	// 20 pieces cost 240.
+	// 0: call (quantity)
+	// 1: call (quantity)
+	// 2: constant (10)
+	// 3: compare 2 (greaterOrEqual)
+	// 4: branch 7 (conditional)
+	// 5: constant (12)
+	// 6: jump 8
+	// 7: constant (15)
+	// 8: product 2 (product)
+}
@@ -0,0 +1,315 @@
+package mmath_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+)
+
+func TestCompileInt64CalculatesLikeTree(t *testing.T) {
+	x := mmath.NewNamedVariableInt64("x")
+	y := mmath.NewNamedVariableInt64("y")
+	b := mmath.NewNamedVariableBool("b")
+	failure := mmath.NewFailingCalculation(errors.New("failure"))
+	clamp := mmath.NewCreateBinaryInt64(func(value, limit int64) int64 {
+		if value > limit {
+			return limit
+		}
+		return value
+	})
+	maximum := mmath.NewCreateFallibleNaryInt64(func(values []int64) (int64, error) {
+		if len(values) == 0 {
+			return 0, errors.New("no values")
+		}
+		result := values[0]
+		for _, value := range values[1:] {
+			if value > result {
+				result = value
+			}
+		}
+		return result, nil
+	})
+	subtract := func(current, next int64) (int64, error) {
+		if next < 0 {
+			return 0, errors.New("negative")
+		}
+		return current - next, nil
+	}
+
+	testcases := map[string]mmath.CalculationInt64{
+		"sum":                mmath.NewSumInt64(x, y, mmath.NewConstantInt64(3)),
+		"empty sum":          mmath.NewSumInt64(),
+		"product":            mmath.NewProductInt64(x, y),
+		"difference":         mmath.NewDifferenceInt64(x, y),
+		"negation":           mmath.NewNegationInt64(x),
+		"signum":             mmath.NewSignumInt64(mmath.NewDifferenceInt64(x, y)),
+		"checked sum":        mmath.NewCheckedSumInt64(x, y, x),
+		"checked product":    mmath.NewCheckedProductInt64(x, y, y),
+		"checked difference": mmath.NewCheckedDifferenceInt64(x, y),
+		"checked negation":   mmath.NewCheckedNegationInt64(x),
+		"abs":                mmath.NewCheckedAbsInt64(x),
+		"quotient":           mmath.NewQuotientInt64(x, y),
+		"remainder":          mmath.NewRemainderInt64(x, y),
+		"floored modulo":     mmath.NewFlooredModuloInt64(x, y),
+		"conditional": mmath.NewConditionalInt64(
+			mmath.NewAnd(mmath.ShortCircuit, b, mmath.NewInt64Less(x, y)),
+			mmath.NewSumInt64(x, mmath.NewConstantInt64(1)),
+			mmath.NewConditionalInt64(mmath.NewNot(b), y, x),
+		),
+		"failing condition": mmath.NewConditionalInt64(failure, x, y),
+		"function":          clamp(mmath.NewSumInt64(x, y), mmath.NewConstantInt64(10)),
+		"nary function":     maximum(x, y, mmath.NewConstantInt64(-2)),
+		"failing function":  maximum(),
+		"reduction":         mmath.NewReduceLeft(subtract, x, []mmath.CalculationInt64{y, mmath.NewConstantInt64(1)}),
+		"failing initial value": mmath.NewReduceLeft(
+			subtract,
+			failure,
+			[]mmath.CalculationInt64{failure},
+		),
+		"failing operands": mmath.NewSumInt64(
+			x,
+			failure,
+			mmath.NewDifferenceInt64(failure, mmath.NewNegationInt64(failure)),
+		),
+		"failing reduction operands": mmath.NewReduceLeft(
+			subtract,
+			x,
+			[]mmath.CalculationInt64{failure, y, failure},
+		),
+		"nested errors": mmath.NewProductInt64(
+			mmath.NewCheckedSumInt64(x, failure),
+			mmath.NewQuotientInt64(failure, failure),
+		),
+		"opaque": mmath.NewSumInt64(
+			x,
+			mmath.CalculationInt64Func(func() (int64, error) {
+				return 5, nil
+			}),
+		),
+	}
+
+	values := []int64{0, 1, -1, 7, -8, math.MaxInt64, math.MinInt64}
+
+	for name := range testcases {
+		calculation := testcases[name]
+		t.Run(name, func(t *testing.T) {
+			program := mmath.CompileInt64(calculation)
+			for _, xValue := range values {
+				for _, yValue := range values {
+					for _, bValue := range []bool{false, true} {
+						x.Set(xValue)
+						y.Set(yValue)
+						b.Set(bValue)
+						expected, expectedErr := calculation.CalculateInt64()
+
+						actual, err := program.CalculateInt64()
+
+						if actual != expected || !reflect.DeepEqual(err, expectedErr) {
+							t.Errorf(
+								"x=%d, y=%d, b=%t: expected %d and %+v, got %d and %+v",
+								xValue, yValue, bValue, expected, expectedErr, actual, err,
+							)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCompileBoolCalculatesLikeTree(t *testing.T) {
+	x := mmath.NewNamedVariableInt64("x")
+	p := mmath.NewNamedVariableBool("p")
+	q := mmath.NewNamedVariableBool("q")
+	failure := mmath.NewFailingCalculation(errors.New("failure"))
+
+	testcases := map[string]mmath.CalculationBool{
+		"constant":     mmath.NewConstantBool(true),
+		"not":          mmath.NewNot(p),
+		"and":          mmath.NewAnd(mmath.ShortCircuit, p, q, mmath.NewInt64Greater(x, mmath.NewConstantInt64(0))),
+		"or":           mmath.NewOr(mmath.ShortCircuit, p, mmath.NewNot(q)),
+		"empty and":    mmath.NewAnd(mmath.ShortCircuit),
+		"empty or":     mmath.NewOr(mmath.ShortCircuit),
+		"strict and":   mmath.NewAnd(mmath.Strict, p, q),
+		"strict or":    mmath.NewOr(mmath.Strict, p, q),
+		"xor":          mmath.NewXor(mmath.ShortCircuit, p, q),
+		"equals":       mmath.NewInt64Equals(x, mmath.NewConstantInt64(7)),
+		"chain":        mmath.NewInt64ChainLess(mmath.NewConstantInt64(-1), x, mmath.NewConstantInt64(10)),
+		"between":      mmath.NewInt64Between(x, mmath.NewConstantInt64(0), mmath.NewConstantInt64(7)),
+		"failing and":  mmath.NewAnd(mmath.ShortCircuit, p, failure, q),
+		"failing or":   mmath.NewOr(mmath.ShortCircuit, p, failure),
+		"strict fails": mmath.NewOr(mmath.Strict, failure, p, mmath.NewAnd(mmath.Strict, failure, q)),
+		"failing comparison": mmath.NewInt64ChainLessOrEqual(
+			failure,
+			x,
+			mmath.NewCheckedNegationInt64(x),
+		),
+	}
+
+	for name := range testcases {
+		calculation := testcases[name]
+		t.Run(name, func(t *testing.T) {
+			program := mmath.CompileBool(calculation)
+			for _, xValue := range []int64{-3, 0, 7, math.MinInt64} {
+				for _, pValue := range []bool{false, true} {
+					for _, qValue := range []bool{false, true} {
+						x.Set(xValue)
+						p.Set(pValue)
+						q.Set(qValue)
+						expected, expectedErr := calculation.CalculateBool()
+
+						actual, err := program.CalculateBool()
+
+						if actual != expected || !reflect.DeepEqual(err, expectedErr) {
+							t.Errorf(
+								"x=%d, p=%t, q=%t: expected %t and %+v, got %t and %+v",
+								xValue, pValue, qValue, expected, expectedErr, actual, err,
+							)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCompiledProgramsOnlyCalculateNeededOperands(t *testing.T) {
+	b := mmath.NewVariableBool()
+	ifTrue := &countingLookup{value: 1}
+	ifFalse := &countingLookup{value: 2}
+	never := &countingLookup{value: 3}
+	program := mmath.CompileInt64(
+		mmath.NewConditionalInt64(
+			mmath.NewOr(mmath.ShortCircuit, b, mmath.NewInt64Equals(never, never)),
+			ifTrue,
+			ifFalse,
+		),
+	)
+	b.Set(true)
+
+	v, err := program.CalculateInt64()
+
+	if v != 1 || err != nil {
+		t.Errorf("expected 1 and no error, got %d and %+v", v, err)
+	}
+	if ifTrue.calls != 1 || ifFalse.calls != 0 || never.calls != 0 {
+		t.Errorf("expected only true branch to be calculated, got %d, %d and %d calls", ifTrue.calls, ifFalse.calls, never.calls)
+	}
+}
+
+func TestCompiledProgramsSupportDeepCalculations(t *testing.T) {
+	x := mmath.NewVariableInt64()
+	x.Set(1)
+	calculation := mmath.CalculationInt64(x)
+	for i := 0; i < 100; i++ {
+		calculation = mmath.NewSumInt64(x, calculation)
+	}
+
+	v, err := mmath.CompileInt64(calculation).CalculateInt64()
+
+	if v != 101 || err != nil {
+		t.Errorf("expected 101 and no error, got %d and %+v", v, err)
+	}
+}
+
+func TestCompiledProgramsDoNotAllocate(t *testing.T) {
+	x := mmath.NewVariableInt64()
+	program := mmath.CompileInt64(benchmarkCalculation(x))
+
+	value := int64(0)
+	allocations := testing.AllocsPerRun(100, func() {
+		value++
+		x.Set(value)
+		program.CalculateInt64()
+	})
+
+	if allocations != 0 {
+		t.Errorf("expected no allocations, got %f", allocations)
+	}
+}
+
+func TestProgramDisassemble(t *testing.T) {
+	x := mmath.NewNamedVariableInt64("x")
+	program := mmath.CompileInt64(
+		mmath.NewConditionalInt64(
+			mmath.NewInt64Less(x, mmath.NewConstantInt64(0)),
+			mmath.NewNegationInt64(x),
+			mmath.NewSumInt64(x, mmath.NewConstantInt64(1)),
+		),
+	)
+	expected := `0: call (x)
+1: constant (0)
+2: compare 2 (less)
+3: branch 7 (conditional)
+4: call (x)
+5: unary (negation)
+6: jump 10
+7: call (x)
+8: constant (1)
+9: sum 2 (sum)`
+
+	if actual := program.Disassemble(); actual != expected {
+		t.Errorf("expected\n%s\n\ngot\n%s", expected, actual)
+	}
+}
+
+// benchmarkCalculation returns a calculation typical for business rules, made
+// of sums, products, comparisons, conditionals and functions.
+func benchmarkCalculation(x mmath.VariableInt64) mmath.CalculationInt64 {
+	clamp := mmath.NewCreateBinaryInt64(func(value, limit int64) int64 {
+		if value > limit {
+			return limit
+		}
+		return value
+	})
+	price := mmath.NewProductInt64(x, mmath.NewConstantInt64(3), mmath.NewConstantInt64(7))
+	discount := mmath.NewConditionalInt64(
+		mmath.NewAnd(
+			mmath.ShortCircuit,
+			mmath.NewInt64Greater(x, mmath.NewConstantInt64(10)),
+			mmath.NewInt64Less(x, mmath.NewConstantInt64(1000000)),
+		),
+		mmath.NewQuotientInt64(price, mmath.NewConstantInt64(10)),
+		mmath.NewConstantInt64(0),
+	)
+	return clamp(
+		mmath.NewCheckedSumInt64(
+			mmath.NewDifferenceInt64(price, discount),
+			mmath.NewReduceLeft(
+				func(current, next int64) (int64, error) {
+					return current + next*next, nil
+				},
+				mmath.NewConstantInt64(0),
+				[]mmath.CalculationInt64{x, mmath.NewNegationInt64(x), mmath.NewConstantInt64(5)},
+			),
+		),
+		mmath.NewConstantInt64(1<<40),
+	)
+}
+
+func BenchmarkCalculateInt64Tree(b *testing.B) {
+	x := mmath.NewVariableInt64()
+	calculation := benchmarkCalculation(x)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		x.Set(int64(i))
+		calculation.CalculateInt64()
+	}
+}
+
+func BenchmarkCalculateInt64Compiled(b *testing.B) {
+	x := mmath.NewVariableInt64()
+	program := mmath.CompileInt64(benchmarkCalculation(x))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		x.Set(int64(i))
+		program.CalculateInt64()
+	}
+}
@@ -0,0 +1,203 @@
+package mmath_test
+
+import (
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+)
+
+// These tests are most useful when run with the race detector, i.e. via
+// "go test -race".
+
+const concurrentGoroutines = 8
+
+// runConcurrently runs f in several goroutines, passing the number of the
+// goroutine, and waits for all of them.
+func runConcurrently(f func(goroutine int)) {
+	var wg sync.WaitGroup
+	for i := 0; i < concurrentGoroutines; i++ {
+		wg.Add(1)
+		go func(goroutine int) {
+			defer wg.Done()
+			f(goroutine)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestVariablesCanBeSetAndCalculatedConcurrently(t *testing.T) {
+	int64Var := mmath.NewVariableInt64()
+	boolVar := mmath.NewVariableBool()
+	float64Var := mmath.NewVariableFloat64()
+	bigIntVar := mmath.NewVariableBigInt()
+	ratVar := mmath.NewVariableRat()
+	decimalVar := mmath.NewVariableDecimal()
+	genericVar := mmath.NewVariable[string]()
+
+	testcases := map[string]struct {
+		set       func(goroutine int)
+		calculate func() bool
+	}{
+		"int64": {
+			set: func(goroutine int) { int64Var.Set(int64(goroutine)) },
+			calculate: func() bool {
+				v, err := int64Var.CalculateInt64()
+				return err == nil && v >= 0 && v < concurrentGoroutines
+			},
+		},
+		"bool": {
+			set: func(goroutine int) { boolVar.Set(goroutine%2 == 0) },
+			calculate: func() bool {
+				_, err := boolVar.CalculateBool()
+				return err == nil
+			},
+		},
+		"float64": {
+			set: func(goroutine int) { float64Var.Set(float64(goroutine)) },
+			calculate: func() bool {
+				v, err := float64Var.CalculateFloat64()
+				return err == nil && v >= 0 && v < concurrentGoroutines
+			},
+		},
+		"bigint": {
+			set: func(goroutine int) { bigIntVar.Set(big.NewInt(int64(goroutine))) },
+			calculate: func() bool {
+				v, err := bigIntVar.CalculateBigInt()
+				return err == nil && v.Sign() >= 0
+			},
+		},
+		"rat": {
+			set: func(goroutine int) { ratVar.Set(big.NewRat(int64(goroutine), 2)) },
+			calculate: func() bool {
+				v, err := ratVar.CalculateRat()
+				return err == nil && v.Sign() >= 0
+			},
+		},
+		"decimal": {
+			set: func(goroutine int) { decimalVar.Set(mmath.NewDecimal(int64(goroutine), 2)) },
+			calculate: func() bool {
+				_, err := decimalVar.CalculateDecimal()
+				return err == nil
+			},
+		},
+		"generic": {
+			set: func(goroutine int) { genericVar.Set(string(rune('a' + goroutine))) },
+			calculate: func() bool {
+				_, err := genericVar.Calculate()
+				return err == nil
+			},
+		},
+	}
+
+	for name := range testcases {
+		testcase := testcases[name]
+		t.Run(
+			name,
+			func(t *testing.T) {
+				failures := int32(0)
+				runConcurrently(
+					func(goroutine int) {
+						for i := 0; i < 100; i++ {
+							testcase.set(goroutine)
+							if !testcase.calculate() {
+								atomic.AddInt32(&failures, 1)
+							}
+						}
+					},
+				)
+				if failures > 0 {
+					t.Errorf("expected all calculations to succeed, got %d failures", failures)
+				}
+			},
+		)
+	}
+}
+
+func TestCalculationsCanBeCalculatedWhileVariablesAreSet(t *testing.T) {
+	x := mmath.NewVariableInt64()
+	p := mmath.NewVariableBool()
+	calc := mmath.NewConditionalInt64(
+		p,
+		mmath.NewSumInt64(x, mmath.NewConstantInt64(1)),
+		mmath.NewNegationInt64(x),
+	)
+
+	runConcurrently(
+		func(goroutine int) {
+			for i := 0; i < 100; i++ {
+				if goroutine%2 == 0 {
+					x.Set(int64(i))
+					p.Set(i%3 == 0)
+					continue
+				}
+				if _, err := calc.CalculateInt64(); err != nil {
+					t.Errorf("expected no error, got %+v", err)
+				}
+			}
+		},
+	)
+}
+
+func TestMemoizedInt64CanBeCalculatedConcurrently(t *testing.T) {
+	x := mmath.NewVariableInt64()
+	memoized := mmath.NewMemoizedInt64(mmath.NewProductInt64(x, mmath.NewSumInt64(x, mmath.NewConstantInt64(1))))
+
+	runConcurrently(
+		func(goroutine int) {
+			for i := 0; i < 100; i++ {
+				x.Set(int64(goroutine))
+				if _, err := memoized.CalculateInt64(); err != nil {
+					t.Errorf("expected no error, got %+v", err)
+				}
+			}
+		},
+	)
+
+	x.Set(3)
+	if v, err := memoized.CalculateInt64(); v != 12 || err != nil {
+		t.Errorf("expected 12 and no error, got %d and %+v", v, err)
+	}
+}
+
+func TestDerivedInt64CanBeUpdatedConcurrently(t *testing.T) {
+	x := mmath.NewObservableVariableInt64()
+	y := mmath.NewVariableInt64()
+	derived := mmath.NewDerivedInt64(mmath.NewSumInt64(x, y))
+	notifications := int32(0)
+	derived.Subscribe(
+		func(int64, error) {
+			atomic.AddInt32(&notifications, 1)
+		},
+	)
+
+	runConcurrently(
+		func(goroutine int) {
+			for i := 0; i < 100; i++ {
+				switch goroutine % 4 {
+				case 0:
+					x.Set(int64(i))
+				case 1:
+					y.Set(int64(-i))
+				case 2:
+					unsubscribe := x.Subscribe(func(int64) {})
+					unsubscribe()
+				case 3:
+					derived.CalculateInt64()
+				}
+			}
+		},
+	)
+
+	if atomic.LoadInt32(&notifications) == 0 {
+		t.Errorf("expected notifications")
+	}
+
+	x.Set(1000)
+	y.Set(1)
+	if v, err := derived.CalculateInt64(); v != 1001 || err != nil {
+		t.Errorf("expected 1001 and no error, got %d and %+v", v, err)
+	}
+}
@@ -0,0 +1,249 @@
+package mmath
+
+import (
+	"context"
+	"sync"
+)
+
+// CalculationInt64Context represents a calculation that returns an int64 and
+// can be cancelled via a context.
+type CalculationInt64Context interface {
+	// CalculateInt64Context returns the int64 value calculated by this
+	// calculator. If ctx is done before the calculation finished, ctx.Err()
+	// is returned.
+	CalculateInt64Context(ctx context.Context) (int64, error)
+}
+
+// CalculationInt64ContextFunc implements CalculationInt64Context by wrapping a
+// function. It also implements CalculationInt64, so it can be used as operand
+// of other calculations. Calculations created by the arithmetic, comparison
+// and logic constructors of this package pass their context on to it, see
+// ToContextInt64.
+type CalculationInt64ContextFunc func(ctx context.Context) (int64, error)
+
+// CalculateInt64Context calls f and returns its result.
+func (f CalculationInt64ContextFunc) CalculateInt64Context(ctx context.Context) (int64, error) {
+	return f(ctx)
+}
+
+// CalculateInt64 calls f with context.Background() and returns its result.
+func (f CalculationInt64ContextFunc) CalculateInt64() (int64, error) {
+	return f(context.Background())
+}
+
+// CalculationBoolContext represents a calculation that returns a bool and can
+// be cancelled via a context.
+type CalculationBoolContext interface {
+	// CalculateBoolContext returns the bool value calculated by this
+	// calculator. If ctx is done before the calculation finished, ctx.Err()
+	// is returned.
+	CalculateBoolContext(ctx context.Context) (bool, error)
+}
+
+// CalculationBoolContextFunc implements CalculationBoolContext and
+// CalculationBool by wrapping a function, see CalculationInt64ContextFunc.
+type CalculationBoolContextFunc func(ctx context.Context) (bool, error)
+
+// CalculateBoolContext calls f and returns its result.
+func (f CalculationBoolContextFunc) CalculateBoolContext(ctx context.Context) (bool, error) {
+	return f(ctx)
+}
+
+// CalculateBool calls f with context.Background() and returns its result.
+func (f CalculationBoolContextFunc) CalculateBool() (bool, error) {
+	return f(context.Background())
+}
+
+// ToContextInt64 converts a CalculationInt64 to a CalculationInt64Context.
+// Calculations created by the arithmetic, comparison and logic constructors
+// of this package and CalculationInt64ContextFunc are returned as is, as they
+// pass contexts on to their operands. Other calculations, e.g. variables,
+// memoized, derived, compiled, parallel and deduplicated calculations or
+// failing calculations, are only cancelled before they are calculated, as
+// they do not know about contexts.
+func ToContextInt64(calculation CalculationInt64) CalculationInt64Context {
+	if c, ok := calculation.(CalculationInt64Context); ok {
+		return c
+	}
+	return int64WithoutContext{calculation: calculation}
+}
+
+// FromContextInt64 converts a CalculationInt64Context to a CalculationInt64,
+// which always uses ctx for calculating.
+func FromContextInt64(calculation CalculationInt64Context, ctx context.Context) CalculationInt64 {
+	if adapter, ok := calculation.(int64WithoutContext); ok {
+		return adapter.calculation
+	}
+	return int64WithContext{calculation: calculation, ctx: ctx}
+}
+
+type int64WithoutContext struct {
+	calculation CalculationInt64
+}
+
+func (adapter int64WithoutContext) CalculateInt64Context(ctx context.Context) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return adapter.calculation.CalculateInt64()
+}
+
+type int64WithContext struct {
+	calculation CalculationInt64Context
+	ctx         context.Context
+}
+
+func (adapter int64WithContext) CalculateInt64() (int64, error) {
+	return adapter.calculation.CalculateInt64Context(adapter.ctx)
+}
+
+// ToContextBool converts a CalculationBool to a CalculationBoolContext, see
+// ToContextInt64.
+func ToContextBool(calculation CalculationBool) CalculationBoolContext {
+	if c, ok := calculation.(CalculationBoolContext); ok {
+		return c
+	}
+	return boolWithoutContext{calculation: calculation}
+}
+
+// FromContextBool converts a CalculationBoolContext to a CalculationBool,
+// which always uses ctx for calculating.
+func FromContextBool(calculation CalculationBoolContext, ctx context.Context) CalculationBool {
+	if adapter, ok := calculation.(boolWithoutContext); ok {
+		return adapter.calculation
+	}
+	return boolWithContext{calculation: calculation, ctx: ctx}
+}
+
+type boolWithoutContext struct {
+	calculation CalculationBool
+}
+
+func (adapter boolWithoutContext) CalculateBoolContext(ctx context.Context) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	return adapter.calculation.CalculateBool()
+}
+
+type boolWithContext struct {
+	calculation CalculationBoolContext
+	ctx         context.Context
+}
+
+func (adapter boolWithContext) CalculateBool() (bool, error) {
+	return adapter.calculation.CalculateBoolContext(adapter.ctx)
+}
+
+// CalculateInt64Context calculates node like CalculateInt64, but passes ctx on
+// to all operands. Operands are only calculated if ctx is not done yet. If ctx
+// is done when the calculation finishes, ctx.Err() is returned instead of the
+// result, so cancellation is reported as is instead of being combined with
+// other errors.
+func (node *int64Node) CalculateInt64Context(ctx context.Context) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	tree := node.contextTrees.get(node)
+	tree.ctx = ctx
+	value, err := tree.node.(CalculationInt64).CalculateInt64()
+	node.contextTrees.put(tree)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return 0, ctxErr
+	}
+	return value, err
+}
+
+// CalculateBoolContext calculates node like CalculateBool, but passes ctx on
+// to all operands, see int64Node.CalculateInt64Context.
+func (node *boolNode) CalculateBoolContext(ctx context.Context) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	tree := node.contextTrees.get(node)
+	tree.ctx = ctx
+	value, err := tree.node.(CalculationBool).CalculateBool()
+	node.contextTrees.put(tree)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return false, ctxErr
+	}
+	return value, err
+}
+
+// contextTrees keeps the copies of a node built for calculating with a
+// context. A copy is only used by one calculation at a time, so new copies are
+// only built if all existing ones are in use.
+type contextTrees struct {
+	mutex sync.Mutex
+	free  []*contextTree
+}
+
+func (trees *contextTrees) get(node Node) *contextTree {
+	trees.mutex.Lock()
+	if n := len(trees.free); n > 0 {
+		tree := trees.free[n-1]
+		trees.free = trees.free[:n-1]
+		trees.mutex.Unlock()
+		return tree
+	}
+	trees.mutex.Unlock()
+	return newContextTree(node)
+}
+
+func (trees *contextTrees) put(tree *contextTree) {
+	tree.ctx = nil
+	trees.mutex.Lock()
+	trees.free = append(trees.free, tree)
+	trees.mutex.Unlock()
+}
+
+// contextTree is a copy of a node with all children replaced by calculations
+// passing ctx on to them. If that is not possible, node is the original node.
+type contextTree struct {
+	ctx  context.Context
+	node Node
+}
+
+func newContextTree(node Node) *contextTree {
+	tree := &contextTree{node: node}
+	children := node.Children()
+	bound := make([]Node, len(children))
+	for i := range children {
+		bound[i] = byResultType(
+			calculationOf(children[i]),
+			func(calculation CalculationInt64) Node {
+				return NodeOf(int64WithTreeContext{calculation: ToContextInt64(calculation), tree: tree})
+			},
+			func(calculation CalculationBool) Node {
+				return NodeOf(boolWithTreeContext{calculation: ToContextBool(calculation), tree: tree})
+			},
+			children[i],
+		)
+	}
+	if rebuilt, err := node.WithChildren(bound...); err == nil {
+		tree.node = rebuilt
+	}
+	return tree
+}
+
+// int64WithTreeContext calculates with the context of the tree currently
+// calculated.
+type int64WithTreeContext struct {
+	calculation CalculationInt64Context
+	tree        *contextTree
+}
+
+func (adapter int64WithTreeContext) CalculateInt64() (int64, error) {
+	return adapter.calculation.CalculateInt64Context(adapter.tree.ctx)
+}
+
+// boolWithTreeContext calculates with the context of the tree currently
+// calculated.
+type boolWithTreeContext struct {
+	calculation CalculationBoolContext
+	tree        *contextTree
+}
+
+func (adapter boolWithTreeContext) CalculateBool() (bool, error) {
+	return adapter.calculation.CalculateBoolContext(adapter.tree.ctx)
+}
@@ -0,0 +1,36 @@
+package mmath_test
+
+import (
+	"github.com/GodsBoss/mmath"
+
+	"context"
+	"fmt"
+)
+
+func ExampleCalculationInt64ContextFunc() {
+	// stock simulates a lookup which respects cancellation, e.g. a database
+	// query.
+	stock := mmath.CalculationInt64ContextFunc(
+		func(ctx context.Context) (int64, error) {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+			return 42, nil
+		},
+	)
+	reserved := mmath.NewVariableInt64()
+	reserved.Set(12)
+	available := mmath.ToContextInt64(mmath.NewDifferenceInt64(stock, reserved))
+
+	v, err := available.CalculateInt64Context(context.Background())
+	fmt.Println(v, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	v, err = available.CalculateInt64Context(ctx)
+	fmt.Println(v, err)
+
+	// Output:
+	// 30 <nil>
+	// 0 context canceled
+}
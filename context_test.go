@@ -0,0 +1,188 @@
+package mmath_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GodsBoss/mmath"
+)
+
+func TestContextIsPassedToOperands(t *testing.T) {
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, int64(7))
+	fromContext := mmath.CalculationInt64ContextFunc(
+		func(ctx context.Context) (int64, error) {
+			v, _ := ctx.Value(key{}).(int64)
+			return v, nil
+		},
+	)
+	x := mmath.NewVariableInt64()
+	x.Set(2)
+
+	testcases := map[string]struct {
+		calculation mmath.CalculationInt64
+		expected    int64
+	}{
+		"sum": {
+			calculation: mmath.NewSumInt64(x, fromContext),
+			expected:    9,
+		},
+		"product": {
+			calculation: mmath.NewProductInt64(x, fromContext),
+			expected:    14,
+		},
+		"conditional": {
+			calculation: mmath.NewConditionalInt64(mmath.NewInt64Less(x, fromContext), fromContext, x),
+			expected:    7,
+		},
+		"reduction": {
+			calculation: mmath.NewReduceLeft(
+				func(current, next int64) (int64, error) {
+					return current*10 + next, nil
+				},
+				fromContext,
+				[]mmath.CalculationInt64{x, fromContext},
+			),
+			expected: 727,
+		},
+		"nested": {
+			calculation: mmath.NewNegationInt64(mmath.NewCheckedSumInt64(mmath.NewDifferenceInt64(fromContext, x))),
+			expected:    -5,
+		},
+	}
+
+	for name := range testcases {
+		testcase := testcases[name]
+		t.Run(
+			name,
+			func(t *testing.T) {
+				v, err := mmath.ToContextInt64(testcase.calculation).CalculateInt64Context(ctx)
+				if v != testcase.expected || err != nil {
+					t.Errorf("expected %d and no error, got %d and %+v", testcase.expected, v, err)
+				}
+			},
+		)
+	}
+}
+
+func TestCancelledCalculationsStopCalculatingOperands(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	operand := mmath.CalculationInt64Func(
+		func() (int64, error) {
+			calls++
+			if calls == 2 {
+				cancel()
+			}
+			return 1, nil
+		},
+	)
+	calculation := mmath.ToContextInt64(
+		mmath.NewSumInt64(
+			operand,
+			mmath.NewProductInt64(operand, operand),
+			operand,
+		),
+	)
+
+	_, err := calculation.CalculateInt64Context(ctx)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected cancellation, got %+v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected operands to be calculated 2 times, got %d", calls)
+	}
+}
+
+func TestConcurrentCalculationsUseTheirOwnContext(t *testing.T) {
+	type key struct{}
+	var started sync.WaitGroup
+	started.Add(4)
+	fromContext := mmath.CalculationInt64ContextFunc(
+		func(ctx context.Context) (int64, error) {
+			v, _ := ctx.Value(key{}).(int64)
+			return v, nil
+		},
+	)
+	waiting := mmath.CalculationInt64Func(
+		func() (int64, error) {
+			started.Done()
+			started.Wait()
+			return 0, nil
+		},
+	)
+	calculation := mmath.ToContextInt64(
+		mmath.NewSumInt64(waiting, mmath.NewProductInt64(fromContext, mmath.NewConstantInt64(2))),
+	)
+
+	results := make(chan [2]int64)
+	for i := int64(1); i <= 4; i++ {
+		go func(i int64) {
+			v, _ := calculation.CalculateInt64Context(context.WithValue(context.Background(), key{}, i))
+			results <- [2]int64{i, v}
+		}(i)
+	}
+
+	for i := 0; i < 4; i++ {
+		result := <-results
+		if result[1] != 2*result[0] {
+			t.Errorf("context value %d: expected %d, got %d", result[0], 2*result[0], result[1])
+		}
+	}
+
+	// Calculating again reuses the trees built for the calculations above.
+	started.Add(1)
+	v, _ := calculation.CalculateInt64Context(context.WithValue(context.Background(), key{}, int64(5)))
+	if v != 10 {
+		t.Errorf("expected 10, got %d", v)
+	}
+}
+
+func TestCalculationsRespectDeadlines(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	slow := mmath.CalculationBoolContextFunc(
+		func(ctx context.Context) (bool, error) {
+			select {
+			case <-ctx.Done():
+				return false, ctx.Err()
+			case <-time.After(time.Second):
+				return true, nil
+			}
+		},
+	)
+	calculation := mmath.ToContextBool(mmath.NewAnd(mmath.Strict, mmath.NewTrue(), mmath.NewNot(slow)))
+
+	_, err := calculation.CalculateBoolContext(ctx)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline to be exceeded, got %+v", err)
+	}
+}
+
+func TestContextAdapters(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	plain := mmath.CalculationInt64Func(
+		func() (int64, error) {
+			return 1, nil
+		},
+	)
+	if _, err := mmath.ToContextInt64(plain).CalculateInt64Context(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected cancellation, got %+v", err)
+	}
+	if _, err := mmath.FromContextInt64(mmath.ToContextInt64(plain), ctx).CalculateInt64(); err != nil {
+		t.Errorf("expected converting back and forth to return the original calculation, got error %+v", err)
+	}
+
+	p := mmath.NewVariableBool()
+	bound := mmath.FromContextBool(mmath.ToContextBool(mmath.NewNot(p)), ctx)
+	if _, err := bound.CalculateBool(); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected cancellation, got %+v", err)
+	}
+}
@@ -0,0 +1,140 @@
+package mmath
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// MaxDecimalScale is the maximum scale of a Decimal.
+const MaxDecimalScale = 18
+
+// Decimal is a fixed-point decimal number, e.g. an amount of money. It consists
+// of an int64 coefficient and a scale, which is the number of digits after the
+// decimal point. For example, 12.34 has a coefficient of 1234 and a scale of 2.
+//
+// The zero value is 0 with a scale of 0. Decimals are values and can be
+// copied freely.
+type Decimal struct {
+	coefficient int64
+	scale       int
+}
+
+// NewDecimal creates a decimal with the given coefficient and scale, i.e.
+// coefficient * 10**-scale. It panics if scale is negative or greater than
+// MaxDecimalScale.
+func NewDecimal(coefficient int64, scale int) Decimal {
+	mustBeValidDecimalScale(scale)
+	return Decimal{
+		coefficient: coefficient,
+		scale:       scale,
+	}
+}
+
+// ParseDecimal parses a decimal number like "-12.340". The scale of the
+// result is the number of digits after the decimal point. Exponents are not
+// supported.
+func ParseDecimal(s string) (Decimal, error) {
+	digits := strings.TrimLeft(s, "+-")
+	if len(s)-len(digits) > 1 {
+		return Decimal{}, fmt.Errorf("invalid decimal '%s'", s)
+	}
+	integerPart, fractionalPart := digits, ""
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		integerPart, fractionalPart = digits[:i], digits[i+1:]
+	}
+	if integerPart == "" || !isDigits(integerPart) || !isDigits(fractionalPart) {
+		return Decimal{}, fmt.Errorf("invalid decimal '%s'", s)
+	}
+	if len(fractionalPart) > MaxDecimalScale {
+		return Decimal{}, fmt.Errorf("invalid decimal '%s': scale exceeds %d", s, MaxDecimalScale)
+	}
+	coefficient, err := strconv.ParseInt(s[:len(s)-len(digits)]+integerPart+fractionalPart, 10, 64)
+	if err != nil {
+		return Decimal{}, newRangeError(s, "decimal")
+	}
+	return Decimal{
+		coefficient: coefficient,
+		scale:       len(fractionalPart),
+	}, nil
+}
+
+func isDigits(s string) bool {
+	for i := range s {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Coefficient returns the coefficient of d, e.g. 1234 for 12.34.
+func (d Decimal) Coefficient() int64 {
+	return d.coefficient
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// String formats d with exactly Scale() digits after the decimal point.
+func (d Decimal) String() string {
+	magnitude := uint64(d.coefficient)
+	sign := ""
+	if d.coefficient < 0 {
+		magnitude = uint64(-d.coefficient)
+		sign = "-"
+	}
+	digits := strconv.FormatUint(magnitude, 10)
+	if d.scale == 0 {
+		return sign + digits
+	}
+	if len(digits) <= d.scale {
+		digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-d.scale] + "." + digits[len(digits)-d.scale:]
+}
+
+// Rat returns d as an exact rational number.
+func (d Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(big.NewInt(d.coefficient), pow10BigInt(d.scale))
+}
+
+// Cmp compares d and other numerically, regardless of their scales. It returns
+// -1 if d < other, 0 if d == other and +1 if d > other.
+func (d Decimal) Cmp(other Decimal) int {
+	return d.Rat().Cmp(other.Rat())
+}
+
+// Rescale returns d with the given scale, rounding if necessary. If the result
+// does not fit into a Decimal, a *RangeError is returned. It panics if scale is
+// invalid.
+func (d Decimal) Rescale(scale int, mode RoundingMode) (Decimal, error) {
+	return decimalFromRat(d.Rat(), scale, mode)
+}
+
+// decimalFromRat rounds r to a decimal with the given scale.
+func decimalFromRat(r *big.Rat, scale int, mode RoundingMode) (Decimal, error) {
+	mustBeValidDecimalScale(scale)
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(pow10BigInt(scale)))
+	coefficient := roundRat(scaled, mode)
+	if !coefficient.IsInt64() {
+		return Decimal{}, newRangeError(r.RatString(), fmt.Sprintf("decimal with scale %d", scale))
+	}
+	return Decimal{
+		coefficient: coefficient.Int64(),
+		scale:       scale,
+	}, nil
+}
+
+func pow10BigInt(exponent int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+}
+
+func mustBeValidDecimalScale(scale int) {
+	if scale < 0 || scale > MaxDecimalScale {
+		panic(fmt.Sprintf("invalid decimal scale %d", scale))
+	}
+}
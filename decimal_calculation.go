@@ -0,0 +1,273 @@
+package mmath
+
+import (
+	"math/big"
+	"sync"
+)
+
+// CalculationDecimal represents a calculation that returns a Decimal.
+type CalculationDecimal interface {
+	// CalculateDecimal returns the Decimal value calculated by this calculator.
+	CalculateDecimal() (Decimal, error)
+}
+
+// CalculationDecimalFunc implements CalculationDecimal by wrapping a function.
+type CalculationDecimalFunc func() (Decimal, error)
+
+// CalculateDecimal calls f and returns its result.
+func (f CalculationDecimalFunc) CalculateDecimal() (Decimal, error) {
+	return f()
+}
+
+// NewConstantDecimal returns a calculation which always returns the same value
+// and no error.
+func NewConstantDecimal(c Decimal) CalculationDecimalFunc {
+	return func() (Decimal, error) {
+		return c, nil
+	}
+}
+
+// NewVariableDecimal creates a variable. In calculations, it returns the value
+// it was set to. Calculating the result of a variable never fails. The
+// variable is safe for concurrent use.
+func NewVariableDecimal() VariableDecimal {
+	return &variableDecimal{}
+}
+
+// VariableDecimal represents a variable value, which can be set from the
+// outside.
+type VariableDecimal interface {
+	CalculationDecimal
+
+	// Set sets the variable. Afterwards, calling CalculateDecimal() will return d.
+	Set(d Decimal)
+}
+
+type variableDecimal struct {
+	mutex sync.RWMutex
+	value Decimal
+}
+
+func (v *variableDecimal) CalculateDecimal() (Decimal, error) {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+	return v.value, nil
+}
+
+func (v *variableDecimal) Set(d Decimal) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.value = d
+}
+
+// NewSumDecimal returns a calculation which returns the sum of all calculations
+// passed to it. The scale of the result is the greatest scale of all summands,
+// so no rounding is necessary. If one or more calculations fail, an error
+// wrapping all those individual errors is returned. If the sum does not fit
+// into a Decimal, a *RangeError is returned.
+func NewSumDecimal(calculations ...CalculationDecimal) CalculationDecimalFunc {
+	return func() (Decimal, error) {
+		values, err := runCalculationsDecimal(calculations...)
+		if err != nil {
+			return Decimal{}, err
+		}
+		sum := new(big.Rat)
+		for i := range values {
+			sum.Add(sum, values[i].Rat())
+		}
+		return decimalFromRat(sum, maxDecimalScale(values), RoundTowardZero)
+	}
+}
+
+// NewDifferenceDecimal returns a calculation which subtracts the result of
+// subtrahend from the result of minuend. The scale of the result is the greater
+// scale of both operands. If one or both fail, an error combining those errors
+// is returned. If the difference does not fit into a Decimal, a *RangeError is
+// returned.
+func NewDifferenceDecimal(minuend, subtrahend CalculationDecimal) CalculationDecimalFunc {
+	return func() (Decimal, error) {
+		values, err := runCalculationsDecimal(minuend, subtrahend)
+		if err != nil {
+			return Decimal{}, err
+		}
+		difference := new(big.Rat).Sub(values[0].Rat(), values[1].Rat())
+		return decimalFromRat(difference, maxDecimalScale(values), RoundTowardZero)
+	}
+}
+
+// NewNegationDecimal returns a calculation which negates the result of another
+// calculation, keeping its scale. If that calculation fails, that error is
+// returned. If the negation does not fit into a Decimal, a *RangeError is
+// returned.
+func NewNegationDecimal(calculation CalculationDecimal) CalculationDecimalFunc {
+	return func() (Decimal, error) {
+		v, err := calculation.CalculateDecimal()
+		if err != nil {
+			return Decimal{}, err
+		}
+		return decimalFromRat(new(big.Rat).Neg(v.Rat()), v.scale, RoundTowardZero)
+	}
+}
+
+// NewProductDecimal returns a calculation which returns the product of all
+// calculations passed to it. The exact product is rounded once to the given
+// scale, using the given rounding mode. If one or more calculations fail, an
+// error wrapping all those individual errors is returned. If the rounded
+// product does not fit into a Decimal, a *RangeError is returned. It panics if
+// scale is invalid.
+func NewProductDecimal(scale int, mode RoundingMode, calculations ...CalculationDecimal) CalculationDecimalFunc {
+	mustBeValidDecimalScale(scale)
+	return func() (Decimal, error) {
+		values, err := runCalculationsDecimal(calculations...)
+		if err != nil {
+			return Decimal{}, err
+		}
+		product := big.NewRat(1, 1)
+		for i := range values {
+			product.Mul(product, values[i].Rat())
+		}
+		return decimalFromRat(product, scale, mode)
+	}
+}
+
+// NewQuotientDecimal returns a calculation which divides the result of dividend
+// by the result of divisor. The exact quotient is rounded to the given scale,
+// using the given rounding mode. If one or both fail, an error combining those
+// errors is returned. If the divisor is zero, a *DivisionByZeroError is
+// returned. If the rounded quotient does not fit into a Decimal, a *RangeError
+// is returned. It panics if scale is invalid.
+func NewQuotientDecimal(dividend, divisor CalculationDecimal, scale int, mode RoundingMode) CalculationDecimalFunc {
+	mustBeValidDecimalScale(scale)
+	return func() (Decimal, error) {
+		values, err := runCalculationsDecimal(dividend, divisor)
+		if err != nil {
+			return Decimal{}, err
+		}
+		if values[1].coefficient == 0 {
+			return Decimal{}, newDivisionByZeroError("quotient", values[0])
+		}
+		return decimalFromRat(new(big.Rat).Quo(values[0].Rat(), values[1].Rat()), scale, mode)
+	}
+}
+
+// NewRescaleDecimal returns a calculation which rescales the result of another
+// calculation to the given scale, using the given rounding mode. If that
+// calculation fails, its error is returned. If the result does not fit into a
+// Decimal, a *RangeError is returned. It panics if scale is invalid.
+func NewRescaleDecimal(calculation CalculationDecimal, scale int, mode RoundingMode) CalculationDecimalFunc {
+	mustBeValidDecimalScale(scale)
+	return func() (Decimal, error) {
+		v, err := calculation.CalculateDecimal()
+		if err != nil {
+			return Decimal{}, err
+		}
+		return v.Rescale(scale, mode)
+	}
+}
+
+func runCalculationsDecimal(calculations ...CalculationDecimal) ([]Decimal, error) {
+	var errs Errors
+	results := make([]Decimal, len(calculations))
+
+	for i := range calculations {
+		result, err := calculations[i].CalculateDecimal()
+		results[i] = result
+		if err != nil {
+			errs.add(i, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return results, nil
+}
+
+func maxDecimalScale(values []Decimal) int {
+	scale := 0
+	for i := range values {
+		if values[i].scale > scale {
+			scale = values[i].scale
+		}
+	}
+	return scale
+}
+
+// NewConditionalDecimal returns a calculation which returns the result of
+// ifTrue or ifFalse, depending on wether boolCalc returns true or false. If
+// boolCalc returns an error, that error is returned instead.
+func NewConditionalDecimal(boolCalc CalculationBool, ifTrue, ifFalse CalculationDecimal) CalculationDecimal {
+	return ToDecimal(Conditional(FromBool(boolCalc), FromDecimal(ifTrue), FromDecimal(ifFalse)))
+}
+
+// NewCoefficientToDecimal returns a calculation which interprets the result of
+// an int64 calculation as the coefficient of a decimal with the given scale,
+// e.g. an amount of cents with a scale of 2. If that calculation fails, its
+// error is returned. It panics if scale is invalid.
+func NewCoefficientToDecimal(calculation CalculationInt64, scale int) CalculationDecimalFunc {
+	mustBeValidDecimalScale(scale)
+	return func() (Decimal, error) {
+		v, err := calculation.CalculateInt64()
+		if err != nil {
+			return Decimal{}, err
+		}
+		return NewDecimal(v, scale), nil
+	}
+}
+
+// NewDecimalToCoefficient returns a calculation which rescales the result of a
+// decimal calculation to the given scale and returns its coefficient, e.g. an
+// amount of cents for a scale of 2. Errors are handled like in
+// NewRescaleDecimal.
+func NewDecimalToCoefficient(calculation CalculationDecimal, scale int, mode RoundingMode) CalculationInt64Func {
+	rescale := NewRescaleDecimal(calculation, scale, mode)
+	return func() (int64, error) {
+		v, err := rescale.CalculateDecimal()
+		if err != nil {
+			return 0, err
+		}
+		return v.coefficient, nil
+	}
+}
+
+// NewInt64ToDecimal returns a calculation which converts the result of an int64
+// calculation to a decimal with a scale of zero. If that calculation fails, its
+// error is returned.
+func NewInt64ToDecimal(calculation CalculationInt64) CalculationDecimalFunc {
+	return NewCoefficientToDecimal(calculation, 0)
+}
+
+// NewDecimalToInt64 returns a calculation which rounds the result of a decimal
+// calculation to an int64, using the given rounding mode. Errors are handled
+// like in NewRescaleDecimal.
+func NewDecimalToInt64(calculation CalculationDecimal, mode RoundingMode) CalculationInt64Func {
+	return NewDecimalToCoefficient(calculation, 0, mode)
+}
+
+// NewDecimalToRat returns a calculation which converts the result of a decimal
+// calculation to an exact rational number. If that calculation fails, its
+// error is returned.
+func NewDecimalToRat(calculation CalculationDecimal) CalculationRatFunc {
+	return func() (*big.Rat, error) {
+		v, err := calculation.CalculateDecimal()
+		if err != nil {
+			return nil, err
+		}
+		return v.Rat(), nil
+	}
+}
+
+// NewRatToDecimal returns a calculation which rounds the result of a rational
+// calculation to a decimal with the given scale, using the given rounding mode.
+// Errors are handled like in NewRescaleDecimal.
+func NewRatToDecimal(calculation CalculationRat, scale int, mode RoundingMode) CalculationDecimalFunc {
+	mustBeValidDecimalScale(scale)
+	return func() (Decimal, error) {
+		v, err := calculation.CalculateRat()
+		if err != nil {
+			return Decimal{}, err
+		}
+		return decimalFromRat(v, scale, mode)
+	}
+}
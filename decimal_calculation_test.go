@@ -0,0 +1,234 @@
+package mmath_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+)
+
+func TestDecimalCalculations(t *testing.T) {
+	t.Parallel()
+
+	c := func(s string) mmath.CalculationDecimal {
+		return mmath.NewConstantDecimal(mustParseDecimal(s))
+	}
+
+	testcases := map[string]testcaseDecimal{
+		"sum/no_summands": {
+			calculation:   mmath.NewSumDecimal(),
+			expectedValue: "0",
+		},
+		"sum/mixed_scales": {
+			calculation:   mmath.NewSumDecimal(c("1.5"), c("0.25"), c("-3")),
+			expectedValue: "-1.25",
+		},
+		"sum/overflow": {
+			calculation:       mmath.NewSumDecimal(mmath.NewConstantDecimal(mmath.NewDecimal(math.MaxInt64, 2)), c("0.01")),
+			expectedErrorFunc: errorIsRange("decimal with scale 2"),
+		},
+		"sum/errors": {
+			calculation: mmath.NewSumDecimal(
+				mmath.NewFailingCalculation(fmt.Errorf("foobar")),
+				mmath.NewFailingCalculation(fmt.Errorf("xyz")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("foobar"),
+				errorContainsString("xyz"),
+			),
+		},
+		"difference/value": {
+			calculation:   mmath.NewDifferenceDecimal(c("10"), c("0.01")),
+			expectedValue: "9.99",
+		},
+		"negation/value": {
+			calculation:   mmath.NewNegationDecimal(c("1.50")),
+			expectedValue: "-1.50",
+		},
+		"negation/overflow": {
+			calculation:       mmath.NewNegationDecimal(mmath.NewConstantDecimal(mmath.NewDecimal(math.MinInt64, 0))),
+			expectedErrorFunc: errorIsRange("decimal with scale 0"),
+		},
+		"product/rounded_once": {
+			calculation:   mmath.NewProductDecimal(2, mmath.RoundHalfEven, c("19.99"), c("0.075"), c("3")),
+			expectedValue: "4.50",
+		},
+		"product/bankers": {
+			calculation:   mmath.NewProductDecimal(2, mmath.RoundBankers, c("0.125"), c("1")),
+			expectedValue: "0.12",
+		},
+		"product/half_up": {
+			calculation:   mmath.NewProductDecimal(2, mmath.RoundHalfUp, c("0.125"), c("1")),
+			expectedValue: "0.13",
+		},
+		"product/errors": {
+			calculation: mmath.NewProductDecimal(
+				2,
+				mmath.RoundHalfEven,
+				mmath.NewFailingCalculation(fmt.Errorf("abc")),
+				mmath.NewFailingCalculation(fmt.Errorf("123")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("abc"),
+				errorContainsString("123"),
+			),
+		},
+		"quotient/value": {
+			calculation:   mmath.NewQuotientDecimal(c("100.00"), c("3"), 2, mmath.RoundHalfEven),
+			expectedValue: "33.33",
+		},
+		"quotient/by_zero": {
+			calculation:       mmath.NewQuotientDecimal(c("100.00"), c("0.00"), 2, mmath.RoundHalfEven),
+			expectedErrorFunc: errorIsDivisionByZero("quotient"),
+		},
+		"rescale/value": {
+			calculation:   mmath.NewRescaleDecimal(c("2.345"), 2, mmath.RoundHalfUp),
+			expectedValue: "2.35",
+		},
+		"conditional/true": {
+			calculation:   mmath.NewConditionalDecimal(mmath.NewTrue(), c("1.00"), c("2.00")),
+			expectedValue: "1.00",
+		},
+		"conditional/error": {
+			calculation:       mmath.NewConditionalDecimal(mmath.NewFailingCalculation(fmt.Errorf("hello")), c("1"), c("2")),
+			expectedErrorFunc: errorContainsString("hello"),
+		},
+		"fromCoefficient/value": {
+			calculation:   mmath.NewCoefficientToDecimal(mmath.NewConstantInt64(1234), 2),
+			expectedValue: "12.34",
+		},
+		"fromInt64/value": {
+			calculation:   mmath.NewInt64ToDecimal(mmath.NewConstantInt64(-7)),
+			expectedValue: "-7",
+		},
+		"fromRat/value": {
+			calculation:   mmath.NewRatToDecimal(mustRat("2/3"), 4, mmath.RoundHalfEven),
+			expectedValue: "0.6667",
+		},
+	}
+
+	runTestcasesDecimal(t, testcases)
+}
+
+func TestDecimalToInt64(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]testcaseInt64{
+		"coefficient": {
+			calculation:   mmath.NewDecimalToCoefficient(mmath.NewConstantDecimal(mustParseDecimal("12.345")), 2, mmath.RoundHalfEven),
+			expectedValue: 1234,
+		},
+		"int64": {
+			calculation:   mmath.NewDecimalToInt64(mmath.NewConstantDecimal(mustParseDecimal("-2.5")), mmath.RoundHalfUp),
+			expectedValue: -3,
+		},
+		"error": {
+			calculation:       mmath.NewDecimalToInt64(mmath.NewFailingCalculation(fmt.Errorf("decimal failed")), mmath.RoundHalfUp),
+			expectedErrorFunc: errorContainsString("decimal failed"),
+		},
+	}
+
+	runTestcasesInt64(t, testcases)
+}
+
+func TestDecimalComparison(t *testing.T) {
+	t.Parallel()
+
+	operators := map[string]struct {
+		create func(first, second mmath.CalculationDecimal) mmath.CalculationBoolFunc
+
+		// Expected results for comparing 1.49 with 1.5, 1.50 with 1.5 and 1.51
+		// with 1.5.
+		less, equal, greater bool
+	}{
+		"equals":         {create: mmath.NewDecimalEquals, less: false, equal: true, greater: false},
+		"notEquals":      {create: mmath.NewDecimalNotEquals, less: true, equal: false, greater: true},
+		"less":           {create: mmath.NewDecimalLess, less: true, equal: false, greater: false},
+		"lessOrEqual":    {create: mmath.NewDecimalLessOrEqual, less: true, equal: true, greater: false},
+		"greater":        {create: mmath.NewDecimalGreater, less: false, equal: false, greater: true},
+		"greaterOrEqual": {create: mmath.NewDecimalGreaterOrEqual, less: false, equal: true, greater: true},
+	}
+
+	c := func(s string) mmath.CalculationDecimal {
+		return mmath.NewConstantDecimal(mustParseDecimal(s))
+	}
+
+	testcases := make(map[string]testcaseBool)
+	for name, operator := range operators {
+		testcases[name+"/less"] = testcaseBool{
+			calculation:   operator.create(c("1.49"), c("1.5")),
+			expectedValue: operator.less,
+		}
+		testcases[name+"/equal"] = testcaseBool{
+			calculation:   operator.create(c("1.50"), c("1.5")),
+			expectedValue: operator.equal,
+		}
+		testcases[name+"/greater"] = testcaseBool{
+			calculation:   operator.create(c("1.51"), c("1.5")),
+			expectedValue: operator.greater,
+		}
+		testcases[name+"/errors"] = testcaseBool{
+			calculation: operator.create(
+				mmath.NewFailingCalculation(fmt.Errorf("broken")),
+				mmath.NewFailingCalculation(fmt.Errorf("meh")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("broken"),
+				errorContainsString("meh"),
+			),
+		}
+	}
+
+	runTestcasesBool(t, testcases)
+}
+
+func runTestcasesDecimal(t *testing.T, testcases map[string]testcaseDecimal) {
+	for name := range testcases {
+		testcaseDecimal := testcases[name]
+
+		t.Run(
+			name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				actualValue, actualErr := testcaseDecimal.calculation.CalculateDecimal()
+
+				if testcaseDecimal.expectedValue != "" && actualValue.String() != testcaseDecimal.expectedValue {
+					t.Errorf(
+						"expected calculation result value to be %s, but got %s",
+						testcaseDecimal.expectedValue,
+						actualValue,
+					)
+				}
+
+				if testcaseDecimal.expectedErrorFunc == nil && actualErr != nil {
+					t.Errorf("expected no error, but got %+v", actualErr)
+				}
+
+				if testcaseDecimal.expectedErrorFunc != nil {
+					if actualErr != nil {
+						testcaseDecimal.expectedErrorFunc(t, actualErr)
+					}
+					if actualErr == nil {
+						t.Errorf("expected non-nil error")
+					}
+				}
+			},
+		)
+	}
+}
+
+type testcaseDecimal struct {
+	// calculation is the calculation executed by the test. It's result is
+	// compared against the expected values.
+	calculation mmath.CalculationDecimal
+
+	// expectedValue is the formatted value the calculation should return,
+	// including the scale. If empty, the value is not checked.
+	expectedValue string
+
+	// expectedErrorFunc checks the error. This being nil is equivalent to
+	// checking wether the error should be nil.
+	expectedErrorFunc errorTest
+}
@@ -0,0 +1,81 @@
+package mmath
+
+// The following comparisons compare decimals numerically, regardless of their
+// scales, e.g. 1.5 and 1.50 are equal. If one or both calculations fail, an
+// error combining those errors is returned.
+
+// NewDecimalEquals returns wether the results of the first and second
+// calculations are equal.
+func NewDecimalEquals(first, second CalculationDecimal) CalculationBoolFunc {
+	return newDecimalComparison(
+		func(cmp int) bool {
+			return cmp == 0
+		},
+	)(first, second)
+}
+
+// NewDecimalNotEquals returns wether the results of the first and second
+// calculations differ.
+func NewDecimalNotEquals(first, second CalculationDecimal) CalculationBoolFunc {
+	return newDecimalComparison(
+		func(cmp int) bool {
+			return cmp != 0
+		},
+	)(first, second)
+}
+
+// NewDecimalLess returns wether the result of the first calculation is less
+// than the result of the second calculation.
+func NewDecimalLess(first, second CalculationDecimal) CalculationBoolFunc {
+	return newDecimalComparison(
+		func(cmp int) bool {
+			return cmp < 0
+		},
+	)(first, second)
+}
+
+// NewDecimalLessOrEqual returns wether the result of the first calculation is
+// less than or equal to the result of the second calculation.
+func NewDecimalLessOrEqual(first, second CalculationDecimal) CalculationBoolFunc {
+	return newDecimalComparison(
+		func(cmp int) bool {
+			return cmp <= 0
+		},
+	)(first, second)
+}
+
+// NewDecimalGreater returns wether the result of the first calculation is
+// greater than the result of the second calculation.
+func NewDecimalGreater(first, second CalculationDecimal) CalculationBoolFunc {
+	return newDecimalComparison(
+		func(cmp int) bool {
+			return cmp > 0
+		},
+	)(first, second)
+}
+
+// NewDecimalGreaterOrEqual returns wether the result of the first calculation
+// is greater than or equal to the result of the second calculation.
+func NewDecimalGreaterOrEqual(first, second CalculationDecimal) CalculationBoolFunc {
+	return newDecimalComparison(
+		func(cmp int) bool {
+			return cmp >= 0
+		},
+	)(first, second)
+}
+
+// newDecimalComparison creates a calculation constructor comparing the results
+// of two calculations. check receives the result of Decimal's Cmp method.
+func newDecimalComparison(
+	check func(cmp int) bool,
+) func(first, second CalculationDecimal) CalculationBoolFunc {
+	return func(first, second CalculationDecimal) CalculationBoolFunc {
+		return func() (bool, error) {
+			values, err := runCalculationsDecimal(first, second)
+			if err != nil {
+				return false, err
+			}
+			return check(values[0].Cmp(values[1])), nil
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package mmath_test
+
+import (
+	"github.com/GodsBoss/mmath"
+
+	"fmt"
+)
+
+func ExampleNewProductDecimal() {
+	price, _ := mmath.ParseDecimal("19.99")
+	taxRate, _ := mmath.ParseDecimal("0.075")
+
+	tax := mmath.NewProductDecimal(
+		2,
+		mmath.RoundHalfUp,
+		mmath.NewConstantDecimal(price),
+		mmath.NewConstantDecimal(taxRate),
+	)
+	total := mmath.NewSumDecimal(mmath.NewConstantDecimal(price), tax)
+
+	v, err := total.CalculateDecimal()
+
+	fmt.Printf("Total is %s.\n", v)
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+	}
+
+	// Output:
+	// Total is 21.49.
+}
+
+func ExampleNewCoefficientToDecimal() {
+	cents := mmath.NewVariableInt64()
+	amount := mmath.NewCoefficientToDecimal(cents, 2)
+
+	cents.Set(-705)
+
+	v, err := amount.CalculateDecimal()
+
+	fmt.Printf("Amount is %s.\n", v)
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+	}
+
+	// Output:
+	// Amount is -7.05.
+}
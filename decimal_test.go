@@ -0,0 +1,133 @@
+package mmath_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+)
+
+func TestParseDecimal(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		input               string
+		expectedCoefficient int64
+		expectedScale       int
+		expectedString      string
+		expectError         bool
+	}{
+		"integer":          {input: "42", expectedCoefficient: 42, expectedScale: 0, expectedString: "42"},
+		"fraction":         {input: "12.34", expectedCoefficient: 1234, expectedScale: 2, expectedString: "12.34"},
+		"trailingZeros":    {input: "1.500", expectedCoefficient: 1500, expectedScale: 3, expectedString: "1.500"},
+		"negative":         {input: "-0.05", expectedCoefficient: -5, expectedScale: 2, expectedString: "-0.05"},
+		"plus":             {input: "+3.1", expectedCoefficient: 31, expectedScale: 1, expectedString: "3.1"},
+		"trailingPoint":    {input: "7.", expectedCoefficient: 7, expectedScale: 0, expectedString: "7"},
+		"minimum":          {input: "-922337203.6854775808", expectedCoefficient: math.MinInt64, expectedScale: 10, expectedString: "-922337203.6854775808"},
+		"empty":            {input: "", expectError: true},
+		"onlySign":         {input: "-", expectError: true},
+		"missingInteger":   {input: ".5", expectError: true},
+		"twoSigns":         {input: "--5", expectError: true},
+		"twoPoints":        {input: "1.2.3", expectError: true},
+		"letters":          {input: "12a", expectError: true},
+		"exponent":         {input: "1e3", expectError: true},
+		"scaleTooLarge":    {input: "0.1234567890123456789", expectError: true},
+		"coefficientRange": {input: "9223372036854775808", expectError: true},
+	}
+
+	for name := range testcases {
+		testcase := testcases[name]
+
+		t.Run(
+			name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				d, err := mmath.ParseDecimal(testcase.input)
+				if testcase.expectError {
+					if err == nil {
+						t.Errorf("expected error, got decimal %s", d)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("expected no error, got %+v", err)
+				}
+				if d.Coefficient() != testcase.expectedCoefficient || d.Scale() != testcase.expectedScale {
+					t.Errorf(
+						"expected coefficient %d and scale %d, got %d and %d",
+						testcase.expectedCoefficient,
+						testcase.expectedScale,
+						d.Coefficient(),
+						d.Scale(),
+					)
+				}
+				if d.String() != testcase.expectedString {
+					t.Errorf("expected string '%s', got '%s'", testcase.expectedString, d.String())
+				}
+			},
+		)
+	}
+}
+
+func TestDecimalRescale(t *testing.T) {
+	t.Parallel()
+
+	modes := map[string]mmath.RoundingMode{
+		"towardZero":   mmath.RoundTowardZero,
+		"awayFromZero": mmath.RoundAwayFromZero,
+		"floor":        mmath.RoundFloor,
+		"ceiling":      mmath.RoundCeiling,
+		"halfUp":       mmath.RoundHalfUp,
+		"halfDown":     mmath.RoundHalfDown,
+		"halfEven":     mmath.RoundHalfEven,
+	}
+
+	// expected maps inputs to the expected results with a scale of 1 for every
+	// mode.
+	expected := map[string]map[string]string{
+		"0.25":  {"towardZero": "0.2", "awayFromZero": "0.3", "floor": "0.2", "ceiling": "0.3", "halfUp": "0.3", "halfDown": "0.2", "halfEven": "0.2"},
+		"0.35":  {"towardZero": "0.3", "awayFromZero": "0.4", "floor": "0.3", "ceiling": "0.4", "halfUp": "0.4", "halfDown": "0.3", "halfEven": "0.4"},
+		"-0.25": {"towardZero": "-0.2", "awayFromZero": "-0.3", "floor": "-0.3", "ceiling": "-0.2", "halfUp": "-0.3", "halfDown": "-0.2", "halfEven": "-0.2"},
+		"1.07":  {"towardZero": "1.0", "awayFromZero": "1.1", "floor": "1.0", "ceiling": "1.1", "halfUp": "1.1", "halfDown": "1.1", "halfEven": "1.1"},
+		"2":     {"towardZero": "2.0", "awayFromZero": "2.0", "floor": "2.0", "ceiling": "2.0", "halfUp": "2.0", "halfDown": "2.0", "halfEven": "2.0"},
+	}
+
+	for input, results := range expected {
+		for modeName, mode := range modes {
+			d, err := mustParseDecimal(input).Rescale(1, mode)
+			if err != nil {
+				t.Errorf("%s/%s: unexpected error %+v", modeName, input, err)
+				continue
+			}
+			if d.String() != results[modeName] {
+				t.Errorf("%s/%s: expected %s, got %s", modeName, input, results[modeName], d)
+			}
+		}
+	}
+
+	_, err := mmath.NewDecimal(math.MaxInt64, 0).Rescale(2, mmath.RoundHalfEven)
+	if err == nil {
+		t.Errorf("expected rescaling to fail because of the coefficient's range")
+	}
+}
+
+func TestNewDecimalInvalidScale(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic")
+		}
+	}()
+
+	mmath.NewDecimal(1, mmath.MaxDecimalScale+1)
+}
+
+func mustParseDecimal(s string) mmath.Decimal {
+	d, err := mmath.ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
@@ -0,0 +1,379 @@
+package mmath
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// DeduplicateInt64 returns a calculation which calculates the same results as
+// calculation, but structurally equal sub-calculations are replaced by a
+// single, shared one, turning the tree into a directed acyclic graph. While
+// calculating the result, every shared sub-calculation is calculated at most
+// once.
+//
+// Sub-calculations are structurally equal if they are of the same kind, have
+// the same params and structurally equal children. Constants are compared by
+// value. Variables, functions like those created via NewCreateBinaryInt64,
+// failing calculations, calculations which are no nodes and nodes of kinds
+// not created by this package are only equal to themselves, as they may
+// behave differently even if their structure is equal. Calculations which are
+// no nodes are only equal to themselves if they are pointers, all others, e.g.
+// a CalculationInt64Func or structs, are never equal, as Go may not be able to
+// compare them.
+//
+// The calculation is safe for concurrent use, but calculations are
+// serialized. Shared sub-calculations must not be calculated on their own
+// while the calculation is calculated.
+//
+// Rebuilding the calculation or shared sub-calculations via WithChildren keeps
+// them deduplicated, so transformations like NewParallelInt64 or
+// OptimizeInt64 can be applied afterwards. Sub-calculations are only shared as
+// long as transformations rebuild them once, not once per usage like
+// TraceInt64.
+func DeduplicateInt64(calculation CalculationInt64) CalculationInt64 {
+	d := newDeduplicator()
+	root := d.visit(NodeOf(calculation))
+	d.uses[root]++
+	node := d.build(root)
+	return &deduplicatedInt64Node{
+		Node:        node,
+		calculation: calculationOf(node).(CalculationInt64),
+		state:       d.state,
+	}
+}
+
+// DeduplicateBool works like DeduplicateInt64, but for bool calculations.
+func DeduplicateBool(calculation CalculationBool) CalculationBool {
+	d := newDeduplicator()
+	root := d.visit(NodeOf(calculation))
+	d.uses[root]++
+	node := d.build(root)
+	return &deduplicatedBoolNode{
+		Node:        node,
+		calculation: calculationOf(node).(CalculationBool),
+		state:       d.state,
+	}
+}
+
+type deduplicator struct {
+	// ids contains the id of every structurally distinct node, by key.
+	ids map[string]int
+
+	// visited contains the ids of already visited nodes.
+	visited transformed[int]
+
+	// opaqueIDs contains the ids of already visited calculations which are
+	// no nodes, by pointer.
+	opaqueIDs map[interface{}]int
+
+	// representatives contains one original node per id.
+	representatives []Node
+
+	// children contains the ids of the children of every node.
+	children [][]int
+
+	// uses contains the number of usages of every node.
+	uses []int
+
+	// built contains already built nodes, by id.
+	built map[int]Node
+
+	state *deduplicationState
+}
+
+func newDeduplicator() *deduplicator {
+	return &deduplicator{
+		ids:       make(map[string]int),
+		visited:   make(transformed[int]),
+		opaqueIDs: make(map[interface{}]int),
+		built:     make(map[int]Node),
+		state:     &deduplicationState{},
+	}
+}
+
+// visit returns the id of node. Structurally equal nodes get the same id.
+func (d *deduplicator) visit(node Node) int {
+	return d.visited.get(node, d.visitUncached)
+}
+
+func (d *deduplicator) visitUncached(node Node) int {
+	// Every call of NodeOf wraps calculations which are no nodes anew, so
+	// those are identified by the calculation itself. Only pointers are used,
+	// as values of comparable types like interfaces or structs may still
+	// contain incomparable values, e.g. functions.
+	calculation := calculationOf(node)
+	isOpaquePointer := node.Kind() == KindOpaque && reflect.ValueOf(calculation).Kind() == reflect.Ptr
+	if isOpaquePointer {
+		if id, ok := d.opaqueIDs[calculation]; ok {
+			return id
+		}
+	}
+
+	children := node.Children()
+	childIDs := make([]int, len(children))
+	for i := range children {
+		childIDs[i] = d.visit(children[i])
+	}
+
+	key, structural := structuralKey(node, childIDs)
+	if id, ok := d.ids[key]; ok && structural {
+		return id
+	}
+
+	id := len(d.representatives)
+	d.representatives = append(d.representatives, node)
+	d.children = append(d.children, childIDs)
+	d.uses = append(d.uses, 0)
+	for _, childID := range childIDs {
+		d.uses[childID]++
+	}
+	if structural {
+		d.ids[key] = id
+	}
+	if isOpaquePointer {
+		d.opaqueIDs[calculation] = id
+	}
+	return id
+}
+
+// structuralKey returns a key which is equal for structurally equal nodes,
+// given the ids of their children, and wether node can be compared
+// structurally at all.
+func structuralKey(node Node, childIDs []int) (string, bool) {
+	if node.Kind() != KindConstant && !pureKinds[node.Kind()] {
+		return "", false
+	}
+
+	parts := []string{string(node.Kind())}
+	for _, param := range node.Params() {
+		switch param.(type) {
+		case int64, bool, EvaluationStrategy:
+			parts = append(parts, fmt.Sprintf("%T:%v", param, param))
+		default:
+			return "", false
+		}
+	}
+	ids := make([]string, len(childIDs))
+	for i := range childIDs {
+		ids[i] = strconv.Itoa(childIDs[i])
+	}
+	parts = append(parts, "("+strings.Join(ids, ",")+")")
+	return strings.Join(parts, " "), true
+}
+
+// build returns the deduplicated node with the given id. Nodes used more than
+// once calculate their result only once per run.
+func (d *deduplicator) build(id int) Node {
+	if node, ok := d.built[id]; ok {
+		return node
+	}
+
+	node := d.representatives[id]
+	if len(d.children[id]) > 0 {
+		children := make([]Node, len(d.children[id]))
+		for i, childID := range d.children[id] {
+			children[i] = d.build(childID)
+		}
+		if rebuilt, err := node.WithChildren(children...); err == nil {
+			node = rebuilt
+		}
+	}
+
+	if d.uses[id] > 1 && node.Kind() != KindConstant && node.Kind() != KindVariable {
+		node = d.share(node)
+	}
+	d.built[id] = node
+	return node
+}
+
+func (d *deduplicator) share(node Node) Node {
+	return byResultType(
+		calculationOf(node),
+		func(calculation CalculationInt64) Node {
+			shared := &sharedInt64Node{
+				Node:        node,
+				calculation: calculation,
+				state:       d.state,
+			}
+			if node.Kind() == KindOpaque {
+				// Parents would unwrap the opaque node, bypassing shared.
+				return NodeOf(CalculationInt64Func(shared.CalculateInt64))
+			}
+			return shared
+		},
+		func(calculation CalculationBool) Node {
+			shared := &sharedBoolNode{
+				Node:        node,
+				calculation: calculation,
+				state:       d.state,
+			}
+			if node.Kind() == KindOpaque {
+				return NodeOf(CalculationBoolFunc(shared.CalculateBool))
+			}
+			return shared
+		},
+		node,
+	)
+}
+
+// deduplicationState is shared by all nodes of a deduplicated calculation.
+type deduplicationState struct {
+	// run is increased when the deduplicated calculation starts and when it
+	// stops being calculated, so it is odd while the calculation is running.
+	// Every run invalidates the results of shared nodes. It is accessed
+	// atomically, as shared nodes may be calculated on their own concurrently.
+	// It is the first field, so it is aligned for atomic access.
+	run uint64
+
+	mutex sync.Mutex
+}
+
+func (state *deduplicationState) start() {
+	state.mutex.Lock()
+	atomic.AddUint64(&state.run, 1)
+}
+
+func (state *deduplicationState) stop() {
+	atomic.AddUint64(&state.run, 1)
+	state.mutex.Unlock()
+}
+
+// current returns the current run and wether the calculation is running.
+func (state *deduplicationState) current() (uint64, bool) {
+	run := atomic.LoadUint64(&state.run)
+	return run, run%2 == 1
+}
+
+type deduplicatedInt64Node struct {
+	Node
+	calculation CalculationInt64
+	state       *deduplicationState
+}
+
+func (node *deduplicatedInt64Node) CalculateInt64() (int64, error) {
+	node.state.start()
+	defer node.state.stop()
+	return node.calculation.CalculateInt64()
+}
+
+func (node *deduplicatedInt64Node) WithChildren(children ...Node) (Node, error) {
+	rebuilt, err := node.Node.WithChildren(children...)
+	if err != nil {
+		return nil, err
+	}
+	return &deduplicatedInt64Node{
+		Node:        rebuilt,
+		calculation: calculationOf(rebuilt).(CalculationInt64),
+		state:       node.state,
+	}, nil
+}
+
+type deduplicatedBoolNode struct {
+	Node
+	calculation CalculationBool
+	state       *deduplicationState
+}
+
+func (node *deduplicatedBoolNode) CalculateBool() (bool, error) {
+	node.state.start()
+	defer node.state.stop()
+	return node.calculation.CalculateBool()
+}
+
+func (node *deduplicatedBoolNode) WithChildren(children ...Node) (Node, error) {
+	rebuilt, err := node.Node.WithChildren(children...)
+	if err != nil {
+		return nil, err
+	}
+	return &deduplicatedBoolNode{
+		Node:        rebuilt,
+		calculation: calculationOf(rebuilt).(CalculationBool),
+		state:       node.state,
+	}, nil
+}
+
+// sharedInt64Node is a node used more than once. It may be calculated
+// concurrently, e.g. by parallel calculations.
+type sharedInt64Node struct {
+	Node
+	calculation CalculationInt64
+	state       *deduplicationState
+	mutex       sync.Mutex
+	run         uint64
+	value       int64
+	err         error
+}
+
+func (node *sharedInt64Node) CalculateInt64() (int64, error) {
+	run, running := node.state.current()
+	if !running {
+		return node.calculation.CalculateInt64()
+	}
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+	if node.run != run {
+		node.value, node.err = node.calculation.CalculateInt64()
+		node.run = run
+	}
+	return node.value, node.err
+}
+
+// WithChildren returns the rebuilt node, shared again. Nodes without children
+// stay shared as they are.
+func (node *sharedInt64Node) WithChildren(children ...Node) (Node, error) {
+	if len(children) == 0 && len(node.Children()) == 0 {
+		return node, nil
+	}
+	rebuilt, err := node.Node.WithChildren(children...)
+	if err != nil {
+		return nil, err
+	}
+	return &sharedInt64Node{
+		Node:        rebuilt,
+		calculation: calculationOf(rebuilt).(CalculationInt64),
+		state:       node.state,
+	}, nil
+}
+
+type sharedBoolNode struct {
+	Node
+	calculation CalculationBool
+	state       *deduplicationState
+	mutex       sync.Mutex
+	run         uint64
+	value       bool
+	err         error
+}
+
+func (node *sharedBoolNode) CalculateBool() (bool, error) {
+	run, running := node.state.current()
+	if !running {
+		return node.calculation.CalculateBool()
+	}
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+	if node.run != run {
+		node.value, node.err = node.calculation.CalculateBool()
+		node.run = run
+	}
+	return node.value, node.err
+}
+
+func (node *sharedBoolNode) WithChildren(children ...Node) (Node, error) {
+	if len(children) == 0 && len(node.Children()) == 0 {
+		return node, nil
+	}
+	rebuilt, err := node.Node.WithChildren(children...)
+	if err != nil {
+		return nil, err
+	}
+	return &sharedBoolNode{
+		Node:        rebuilt,
+		calculation: calculationOf(rebuilt).(CalculationBool),
+		state:       node.state,
+	}, nil
+}
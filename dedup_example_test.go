@@ -0,0 +1,45 @@
+package mmath_test
+
+import (
+	"github.com/GodsBoss/mmath"
+
+	"fmt"
+)
+
+// priceLookup simulates an expensive calculation, e.g. a database query.
+type priceLookup struct {
+	price   int64
+	lookups int
+}
+
+func (lookup *priceLookup) CalculateInt64() (int64, error) {
+	lookup.lookups++
+	return lookup.price, nil
+}
+
+func ExampleDeduplicateInt64() {
+	basePrice := &priceLookup{price: 40}
+	quantity := mmath.NewVariableInt64()
+	quantity.Set(3)
+
+	// Both branches build the same sub-calculation independently.
+	subtotal := func() mmath.CalculationInt64 {
+		return mmath.NewProductInt64(basePrice, quantity)
+	}
+	total := mmath.NewConditionalInt64(
+		mmath.NewInt64Greater(subtotal(), mmath.NewConstantInt64(100)),
+		mmath.NewDifferenceInt64(subtotal(), mmath.NewConstantInt64(10)),
+		subtotal(),
+	)
+
+	v, _ := total.CalculateInt64()
+	fmt.Printf("Value is %d after %d lookups.\n", v, basePrice.lookups)
+
+	basePrice.lookups = 0
+	v, _ = mmath.DeduplicateInt64(total).CalculateInt64()
+	fmt.Printf("Value is %d after %d lookups.\n", v, basePrice.lookups)
+
+	// Output:
+	// Value is 110 after 2 lookups.
+	// Value is 110 after 1 lookups.
+}
@@ -0,0 +1,255 @@
+package mmath_test
+
+import (
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+)
+
+// countingLookup is a calculation counting how often it is calculated.
+type countingLookup struct {
+	value int64
+	calls int
+}
+
+func (lookup *countingLookup) CalculateInt64() (int64, error) {
+	lookup.calls++
+	return lookup.value, nil
+}
+
+// countNodes returns the number of distinct nodes in calculation.
+func countNodes(calculation interface{}) int {
+	seen := make(map[mmath.Node]bool)
+	mmath.Inspect(
+		mmath.NodeOf(calculation),
+		func(node mmath.Node) bool {
+			if node != nil {
+				seen[node] = true
+			}
+			return true
+		},
+	)
+	return len(seen)
+}
+
+func TestDeduplicateInt64CalculatesEqualSubCalculationsOnce(t *testing.T) {
+	lookup := &countingLookup{value: 10}
+	x := mmath.NewVariableInt64()
+	rule := func() mmath.CalculationInt64 {
+		return mmath.NewSumInt64(mmath.NewProductInt64(x, mmath.NewConstantInt64(2)), lookup)
+	}
+	calculation := mmath.NewConditionalInt64(
+		mmath.NewInt64Less(rule(), mmath.NewConstantInt64(100)),
+		rule(),
+		mmath.NewNegationInt64(rule()),
+	)
+	deduplicated := mmath.DeduplicateInt64(calculation)
+
+	for _, value := range []int64{3, 50} {
+		x.Set(value)
+		expected, _ := calculation.CalculateInt64()
+		lookup.calls = 0
+
+		v, err := deduplicated.CalculateInt64()
+
+		if v != expected || err != nil {
+			t.Errorf("x=%d: expected %d and no error, got %d and %+v", value, expected, v, err)
+		}
+		if lookup.calls != 1 {
+			t.Errorf("x=%d: expected lookup to be calculated once, got %d", value, lookup.calls)
+		}
+	}
+
+	if before, after := countNodes(calculation), countNodes(deduplicated); after >= before {
+		t.Errorf("expected less than %d nodes after deduplication, got %d", before, after)
+	}
+}
+
+func TestDeduplicatedCalculationsCanBeTransformed(t *testing.T) {
+	transformations := map[string]func(calculation mmath.CalculationInt64) mmath.CalculationInt64{
+		"parallel": func(calculation mmath.CalculationInt64) mmath.CalculationInt64 {
+			return mmath.NewParallelInt64(calculation, 4)
+		},
+		"optimize": mmath.OptimizeInt64,
+	}
+
+	for name, transform := range transformations {
+		lookup := &countingLookup{value: 10}
+		x := mmath.NewVariableInt64()
+		rule := func() mmath.CalculationInt64 {
+			return mmath.NewSumInt64(mmath.NewProductInt64(x, mmath.NewConstantInt64(2)), lookup)
+		}
+		calculation := mmath.NewConditionalInt64(
+			mmath.NewInt64Less(rule(), mmath.NewConstantInt64(100)),
+			rule(),
+			mmath.NewNegationInt64(rule()),
+		)
+		transformed := transform(mmath.DeduplicateInt64(calculation))
+
+		for _, value := range []int64{3, 50} {
+			x.Set(value)
+			expected, _ := calculation.CalculateInt64()
+			lookup.calls = 0
+
+			v, err := transformed.CalculateInt64()
+
+			if v != expected || err != nil {
+				t.Errorf("%s, x=%d: expected %d and no error, got %d and %+v", name, value, expected, v, err)
+			}
+			if lookup.calls != 1 {
+				t.Errorf("%s, x=%d: expected lookup to be calculated once, got %d", name, value, lookup.calls)
+			}
+		}
+	}
+}
+
+func TestDeduplicateInt64SharesSubCalculationsWithConstants(t *testing.T) {
+	x := mmath.NewVariableInt64()
+	deduplicated := mmath.DeduplicateInt64(
+		mmath.NewProductInt64(
+			mmath.NewSumInt64(x, mmath.NewConstantInt64(1)),
+			mmath.NewSumInt64(x, mmath.NewConstantInt64(1)),
+		),
+	)
+
+	children := mmath.NodeOf(deduplicated).Children()
+	if len(children) != 2 || children[0] != children[1] {
+		t.Errorf("expected both operands to be the same shared node, got %+v", children)
+	}
+	x.Set(2)
+	if v, err := deduplicated.CalculateInt64(); v != 9 || err != nil {
+		t.Errorf("expected 9 and no error, got %d and %+v", v, err)
+	}
+}
+
+func TestDeduplicateInt64DoesNotShareFunctionLeaves(t *testing.T) {
+	calls := 0
+	lookup := mmath.CalculationInt64Func(
+		func() (int64, error) {
+			calls++
+			return 10, nil
+		},
+	)
+	deduplicated := mmath.DeduplicateInt64(
+		mmath.NewSumInt64(mmath.NewNegationInt64(lookup), mmath.NewNegationInt64(lookup)),
+	)
+
+	if v, err := deduplicated.CalculateInt64(); v != -20 || err != nil {
+		t.Errorf("expected -20 and no error, got %d and %+v", v, err)
+	}
+	if calls != 2 {
+		t.Errorf("expected lookup to be calculated twice, got %d", calls)
+	}
+}
+
+// wrappedLookup is a comparable type containing an incomparable value.
+type wrappedLookup struct {
+	lookup mmath.CalculationInt64
+}
+
+func (wrapped wrappedLookup) CalculateInt64() (int64, error) {
+	return wrapped.lookup.CalculateInt64()
+}
+
+func TestDeduplicateInt64DoesNotCompareWrappedFunctions(t *testing.T) {
+	calls := 0
+	lookup := wrappedLookup{
+		lookup: mmath.CalculationInt64Func(
+			func() (int64, error) {
+				calls++
+				return 10, nil
+			},
+		),
+	}
+	deduplicated := mmath.DeduplicateInt64(mmath.NewSumInt64(lookup, lookup))
+
+	if v, err := deduplicated.CalculateInt64(); v != 20 || err != nil {
+		t.Errorf("expected 20 and no error, got %d and %+v", v, err)
+	}
+	if calls != 2 {
+		t.Errorf("expected lookup to be calculated twice, got %d", calls)
+	}
+}
+
+func TestDeduplicateInt64KeepsDistinctCalculations(t *testing.T) {
+	x := mmath.NewNamedVariableInt64("x")
+	otherX := mmath.NewNamedVariableInt64("x")
+	double := mmath.NewCreateBinaryInt64(
+		func(left, right int64) int64 {
+			return 2 * (left + right)
+		},
+	)
+	triple := mmath.NewCreateBinaryInt64(
+		func(left, right int64) int64 {
+			return 3 * (left + right)
+		},
+	)
+	x.Set(1)
+	otherX.Set(2)
+	one := mmath.NewConstantInt64(1)
+
+	testcases := map[string]struct {
+		calculation   mmath.CalculationInt64
+		expectedValue int64
+		expectedNodes int
+	}{
+		"variables with equal names": {
+			calculation:   mmath.NewSumInt64(mmath.NewNegationInt64(x), mmath.NewNegationInt64(otherX)),
+			expectedValue: -3,
+			expectedNodes: 5,
+		},
+		"functions": {
+			calculation:   mmath.NewSumInt64(double(x, one), triple(x, one)),
+			expectedValue: 10,
+			expectedNodes: 5,
+		},
+		"equal constants": {
+			calculation:   mmath.NewSumInt64(mmath.NewConstantInt64(7), mmath.NewConstantInt64(7)),
+			expectedValue: 14,
+			expectedNodes: 2,
+		},
+		"different params": {
+			calculation: mmath.NewConditionalInt64(
+				mmath.NewAnd(mmath.Strict, mmath.NewTrue()),
+				mmath.NewConditionalInt64(mmath.NewAnd(mmath.ShortCircuit, mmath.NewTrue()), x, one),
+				one,
+			),
+			expectedValue: 1,
+			expectedNodes: 7,
+		},
+	}
+
+	for name := range testcases {
+		testcase := testcases[name]
+		t.Run(
+			name,
+			func(t *testing.T) {
+				deduplicated := mmath.DeduplicateInt64(testcase.calculation)
+
+				if v, err := deduplicated.CalculateInt64(); v != testcase.expectedValue || err != nil {
+					t.Errorf("expected %d and no error, got %d and %+v", testcase.expectedValue, v, err)
+				}
+				if nodes := countNodes(deduplicated); nodes != testcase.expectedNodes {
+					t.Errorf("expected %d nodes, got %d", testcase.expectedNodes, nodes)
+				}
+			},
+		)
+	}
+}
+
+func TestDeduplicateBool(t *testing.T) {
+	x := mmath.NewVariableInt64()
+	inRange := func() mmath.CalculationBool {
+		return mmath.NewInt64Between(x, mmath.NewConstantInt64(0), mmath.NewConstantInt64(10))
+	}
+	calculation := mmath.NewXor(mmath.Strict, inRange(), mmath.NewNot(inRange()))
+	deduplicated := mmath.DeduplicateBool(calculation)
+
+	x.Set(5)
+	if b, err := deduplicated.CalculateBool(); !b || err != nil {
+		t.Errorf("expected true and no error, got %t and %+v", b, err)
+	}
+	if formula := mmath.FormatFormula(deduplicated); formula != mmath.FormatFormula(calculation) {
+		t.Errorf("expected formula '%s', got '%s'", mmath.FormatFormula(calculation), formula)
+	}
+}
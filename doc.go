@@ -0,0 +1,33 @@
+// Package mmath provides calculations which are built once and calculated
+// many times, e.g. because they depend on variables set from the outside.
+//
+// # Concurrency
+//
+// Variables created by this package, e.g. via NewVariableInt64 or
+// NewVariable, are safe for concurrent use: they may be set and calculated by
+// multiple goroutines simultaneously. A calculation reads every variable it
+// depends on separately, so a calculation running while another goroutine sets
+// several variables may see some of the new values, but not others.
+//
+// All other calculations created by this package, including constants,
+// operators like NewSumInt64 and conditionals, never change after creation.
+// They are safe for concurrent use as long as the calculations they are built
+// from are. Calculations wrapping functions, e.g. CalculationInt64Func or
+// NewReduceLeft, are as safe as the functions passed to them.
+//
+// MemoizedInt64, MemoizedBool, DerivedInt64 and DerivedBool are safe for
+// concurrent use, Batch is not. Subscribers of variables and derived
+// calculations are called by the goroutine which caused the change, so they
+// may be called concurrently.
+//
+// # Structure
+//
+// All calculations created by this package are nodes, see Node, so they can be
+// formatted, encoded, optimized and transformed in other ways. For that
+// reason, NewConstantInt64, NewSignumInt64, the constructors returned by
+// NewCreateBinaryInt64, NewConstantBool, NewNot and NewInt64Equals return
+// CalculationInt64 or CalculationBool instead of CalculationInt64Func or
+// CalculationBoolFunc, which cannot expose their structure. Code relying on
+// the function types can use the CalculateInt64 or CalculateBool method
+// instead, e.g. CalculationInt64Func(NewConstantInt64(5).CalculateInt64).
+package mmath
@@ -1,15 +1,223 @@
 package mmath
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 )
 
-type errors []error
+// Errors is returned by calculations combining the errors of several
+// operands, e.g. sums. Every error returned by such a calculation is an
+// *OperandError, which tells which operand failed. If an operand fails with
+// Errors itself, its errors are added instead, so errors are never nested.
+//
+// Errors supports errors.Is and errors.As, which find errors contained in it.
+type Errors []error
 
-func (errs errors) Error() string {
+func (errs Errors) Error() string {
 	errStrings := make([]string, len(errs))
 	for i := range errs {
 		errStrings[i] = errs[i].Error()
 	}
 	return "multiple errors: " + strings.Join(errStrings, "; ")
 }
+
+// Unwrap returns the errors contained in errs.
+func (errs Errors) Unwrap() []error {
+	return append([]error(nil), errs...)
+}
+
+// Is returns wether one of the errors contained in errs matches target. It is
+// needed for Go versions without support for Unwrap() []error.
+func (errs Errors) Is(target error) bool {
+	for i := range errs {
+		if errors.Is(errs[i], target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error contained in errs which matches target. It is
+// needed for Go versions without support for Unwrap() []error.
+func (errs Errors) As(target interface{}) bool {
+	for i := range errs {
+		if errors.As(errs[i], target) {
+			return true
+		}
+	}
+	return false
+}
+
+// CombineErrors combines the errors of operands like the calculations of this
+// package do, e.g. sums. It returns nil if all errs are nil, else Errors
+// containing the errors which are not nil, with their positions in errs as
+// paths. It is useful for custom calculations and used by code generated via
+// GenerateGoInt64.
+func CombineErrors(errs ...error) error {
+	var combined Errors
+	for i := range errs {
+		if errs[i] != nil {
+			combined.add(i, errs[i])
+		}
+	}
+	if len(combined) == 0 {
+		return nil
+	}
+	return combined
+}
+
+// add adds the error of the operand at position index.
+func (errs *Errors) add(index int, err error) {
+	nested, ok := err.(Errors)
+	if !ok {
+		*errs = append(*errs, &OperandError{Path: []int{index}, Err: err})
+		return
+	}
+	for i := range nested {
+		operandErr, ok := nested[i].(*OperandError)
+		if !ok {
+			operandErr = &OperandError{Err: nested[i]}
+		}
+		*errs = append(
+			*errs,
+			&OperandError{
+				Path: append([]int{index}, operandErr.Path...),
+				Err:  operandErr.Err,
+			},
+		)
+	}
+}
+
+// shift returns errs with the first position of every path increased by
+// offset. It is needed by calculations whose operands are not their first
+// children.
+func (errs Errors) shift(offset int) Errors {
+	shifted := make(Errors, len(errs))
+	for i := range errs {
+		operandErr, ok := errs[i].(*OperandError)
+		if !ok || len(operandErr.Path) == 0 {
+			shifted[i] = errs[i]
+			continue
+		}
+		path := append([]int(nil), operandErr.Path...)
+		path[0] += offset
+		shifted[i] = &OperandError{Path: path, Err: operandErr.Err}
+	}
+	return shifted
+}
+
+// OperandError is an error of an operand contained in Errors.
+type OperandError struct {
+	// Path contains the positions of the operands leading from the calculation
+	// returning Errors to the failed operand, e.g. [1 0] for the first operand
+	// of the second operand. Positions match the children of nodes, see Node.
+	// Calculations passing on the error of an operand as is, e.g. negations
+	// and conditionals, do not add a position.
+	Path []int
+
+	// Err is the error of the operand.
+	Err error
+}
+
+// Error returns the message of the error of the operand.
+func (err *OperandError) Error() string {
+	return err.Err.Error()
+}
+
+// Unwrap returns the error of the operand.
+func (err *OperandError) Unwrap() error {
+	return err.Err
+}
+
+// OverflowError is returned by checked calculations if the result of an
+// operation does not fit into an int64.
+type OverflowError struct {
+	// Operation is the name of the operation which overflowed, e.g. "sum".
+	Operation string
+
+	// Operands are the values the operation was applied to.
+	Operands []int64
+}
+
+func newOverflowError(operation string, operands ...int64) *OverflowError {
+	return &OverflowError{
+		Operation: operation,
+		Operands:  operands,
+	}
+}
+
+func (err *OverflowError) Error() string {
+	operandStrings := make([]string, len(err.Operands))
+	for i := range err.Operands {
+		operandStrings[i] = fmt.Sprintf("%d", err.Operands[i])
+	}
+	return fmt.Sprintf("int64 overflow in %s of %s", err.Operation, strings.Join(operandStrings, ", "))
+}
+
+// DivisionByZeroError is returned by calculations which would have to divide
+// by zero.
+type DivisionByZeroError struct {
+	// Operation is the name of the operation which failed, e.g. "quotient".
+	Operation string
+
+	// Dividend is the value which should have been divided by zero.
+	Dividend interface{}
+}
+
+func newDivisionByZeroError(operation string, dividend interface{}) *DivisionByZeroError {
+	return &DivisionByZeroError{
+		Operation: operation,
+		Dividend:  dividend,
+	}
+}
+
+func (err *DivisionByZeroError) Error() string {
+	return fmt.Sprintf("division by zero in %s of %v", err.Operation, err.Dividend)
+}
+
+// NonFiniteError is returned by float64 calculations if their result would be
+// NaN or infinite, or if such a value cannot be processed.
+type NonFiniteError struct {
+	// Operation is the name of the operation which failed, e.g. "sqrt".
+	Operation string
+
+	// Operands are the values the operation was applied to.
+	Operands []float64
+}
+
+func newNonFiniteError(operation string, operands ...float64) *NonFiniteError {
+	return &NonFiniteError{
+		Operation: operation,
+		Operands:  operands,
+	}
+}
+
+func (err *NonFiniteError) Error() string {
+	operandStrings := make([]string, len(err.Operands))
+	for i := range err.Operands {
+		operandStrings[i] = fmt.Sprintf("%g", err.Operands[i])
+	}
+	return fmt.Sprintf("non-finite result in %s of %s", err.Operation, strings.Join(operandStrings, ", "))
+}
+
+// RangeError is returned by conversions if the value to convert does not fit
+// into the target type.
+type RangeError struct {
+	// Value is the value which could not be converted.
+	Value interface{}
+
+	// Target is the name of the target type, e.g. "int64".
+	Target string
+}
+
+func newRangeError(value interface{}, target string) *RangeError {
+	return &RangeError{
+		Value:  value,
+		Target: target,
+	}
+}
+
+func (err *RangeError) Error() string {
+	return fmt.Sprintf("%v is out of range for %s", err.Value, err.Target)
+}
@@ -0,0 +1,33 @@
+package mmath_test
+
+import (
+	"github.com/GodsBoss/mmath"
+
+	"errors"
+	"fmt"
+)
+
+func ExampleOperandError() {
+	quantity := mmath.NewVariableInt64()
+	total := mmath.NewSumInt64(
+		mmath.NewConstantInt64(100),
+		mmath.NewQuotientInt64(mmath.NewConstantInt64(500), quantity),
+		mmath.NewFailingCalculation(errors.New("price unknown")),
+	)
+
+	_, err := total.CalculateInt64()
+
+	var errs mmath.Errors
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			var operandErr *mmath.OperandError
+			if errors.As(e, &operandErr) {
+				fmt.Printf("Operand %v failed: %v\n", operandErr.Path, operandErr.Err)
+			}
+		}
+	}
+
+	// Output:
+	// Operand [1] failed: division by zero in quotient of 500
+	// Operand [2] failed: price unknown
+}
@@ -0,0 +1,127 @@
+package mmath_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+)
+
+func TestErrorsContainOperandErrorsWithPaths(t *testing.T) {
+	errA := errors.New("a")
+	errB := errors.New("b")
+	errC := errors.New("c")
+	one := mmath.NewConstantInt64(1)
+	failing := func(err error) mmath.CalculationInt64 {
+		return mmath.NewFailingCalculation(err)
+	}
+
+	testcases := map[string]struct {
+		calculation   mmath.CalculationInt64
+		expectedPaths [][]int
+		expectedErrs  []error
+	}{
+		"sum": {
+			calculation:   mmath.NewSumInt64(one, failing(errA), one, failing(errB)),
+			expectedPaths: [][]int{{1}, {3}},
+			expectedErrs:  []error{errA, errB},
+		},
+		"nested": {
+			calculation: mmath.NewProductInt64(
+				failing(errA),
+				mmath.NewNegationInt64(mmath.NewDifferenceInt64(failing(errB), failing(errC))),
+			),
+			expectedPaths: [][]int{{0}, {1, 0}, {1, 1}},
+			expectedErrs:  []error{errA, errB, errC},
+		},
+		"reduction": {
+			calculation: mmath.NewReduceLeft(
+				func(current, next int64) (int64, error) {
+					return current + next, nil
+				},
+				one,
+				[]mmath.CalculationInt64{one, failing(errA)},
+			),
+			expectedPaths: [][]int{{2}},
+			expectedErrs:  []error{errA},
+		},
+	}
+
+	for name := range testcases {
+		testcase := testcases[name]
+		t.Run(
+			name,
+			func(t *testing.T) {
+				_, err := testcase.calculation.CalculateInt64()
+
+				var errs mmath.Errors
+				if !errors.As(err, &errs) {
+					t.Fatalf("expected Errors, got %+v", err)
+				}
+				if len(errs) != len(testcase.expectedErrs) {
+					t.Fatalf("expected %d errors, got %d", len(testcase.expectedErrs), len(errs))
+				}
+				for i := range errs {
+					operandErr, ok := errs[i].(*mmath.OperandError)
+					if !ok {
+						t.Errorf("expected error %d to be an *OperandError, got %T", i, errs[i])
+						continue
+					}
+					if !reflect.DeepEqual(operandErr.Path, testcase.expectedPaths[i]) {
+						t.Errorf("expected path %v for error %d, got %v", testcase.expectedPaths[i], i, operandErr.Path)
+					}
+					if operandErr.Err != testcase.expectedErrs[i] {
+						t.Errorf("expected error %d to be %+v, got %+v", i, testcase.expectedErrs[i], operandErr.Err)
+					}
+				}
+			},
+		)
+	}
+}
+
+func TestErrorsCanBeFoundViaIsAndAs(t *testing.T) {
+	errFailed := errors.New("failed")
+	x := mmath.NewVariableInt64()
+	calculation := mmath.NewInt64Between(
+		mmath.NewSumInt64(mmath.NewConstantInt64(1), mmath.NewQuotientInt64(mmath.NewConstantInt64(1), x)),
+		mmath.NewFailingCalculation(errFailed),
+		x,
+	)
+
+	_, err := calculation.CalculateBool()
+
+	if !errors.Is(err, errFailed) {
+		t.Errorf("expected error %+v to contain %+v", err, errFailed)
+	}
+	var divisionByZeroErr *mmath.DivisionByZeroError
+	if !errors.As(err, &divisionByZeroErr) {
+		t.Errorf("expected error %+v to contain a division by zero", err)
+	}
+	var errs mmath.Errors
+	if errors.As(err, &errs) && (!errs.Is(errFailed) || !errs.As(&divisionByZeroErr)) {
+		t.Errorf("expected Errors to find contained errors without support for Unwrap() []error")
+	}
+	expected := "multiple errors: division by zero in quotient of 1; failed"
+	if err.Error() != expected {
+		t.Errorf("expected flattened error message '%s', got '%s'", expected, err.Error())
+	}
+}
+
+func TestCombineErrors(t *testing.T) {
+	errA := errors.New("a")
+	errB := errors.New("b")
+
+	if err := mmath.CombineErrors(nil, nil); err != nil {
+		t.Errorf("expected no error, got %+v", err)
+	}
+
+	err := mmath.CombineErrors(nil, errA, mmath.CombineErrors(errB))
+	expected := mmath.Errors{
+		&mmath.OperandError{Path: []int{1}, Err: errA},
+		&mmath.OperandError{Path: []int{2, 0}, Err: errB},
+	}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("expected %#v, got %#v", expected, err)
+	}
+}
@@ -1,5 +1,9 @@
 package mmath
 
+import (
+	"math/big"
+)
+
 // FailingCalculation is a helper type useful for tests. It implements all
 // calculations, but returns errors for all of them.
 type FailingCalculation struct {
@@ -23,3 +27,43 @@ func (calc FailingCalculation) CalculateBool() (bool, error) {
 func (calc FailingCalculation) CalculateInt64() (int64, error) {
 	return 0, calc.Err
 }
+
+// CalculateFloat64 returns calc.Err.
+func (calc FailingCalculation) CalculateFloat64() (float64, error) {
+	return 0, calc.Err
+}
+
+// CalculateBigInt returns calc.Err.
+func (calc FailingCalculation) CalculateBigInt() (*big.Int, error) {
+	return nil, calc.Err
+}
+
+// CalculateRat returns calc.Err.
+func (calc FailingCalculation) CalculateRat() (*big.Rat, error) {
+	return nil, calc.Err
+}
+
+// CalculateDecimal returns calc.Err.
+func (calc FailingCalculation) CalculateDecimal() (Decimal, error) {
+	return Decimal{}, calc.Err
+}
+
+// Kind returns KindFailing.
+func (calc FailingCalculation) Kind() Kind {
+	return KindFailing
+}
+
+// Children returns no children.
+func (calc FailingCalculation) Children() []Node {
+	return nil
+}
+
+// Params returns calc.Err.
+func (calc FailingCalculation) Params() []interface{} {
+	return []interface{}{calc.Err}
+}
+
+// WithChildren returns calc if called without children, else an error.
+func (calc FailingCalculation) WithChildren(children ...Node) (Node, error) {
+	return withoutChildren(calc, children)
+}
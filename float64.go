@@ -0,0 +1,241 @@
+package mmath
+
+import (
+	"sync"
+)
+
+// CalculationFloat64 represents a calculation that returns a float64.
+type CalculationFloat64 interface {
+	// CalculateFloat64 returns the float64 value calculated by this calculator.
+	CalculateFloat64() (float64, error)
+}
+
+// CalculationFloat64Func implements CalculationFloat64 by wrapping a function.
+type CalculationFloat64Func func() (float64, error)
+
+// CalculateFloat64 calls f and returns its result.
+func (f CalculationFloat64Func) CalculateFloat64() (float64, error) {
+	return f()
+}
+
+// NewConstantFloat64 returns a calculation which always returns the same value
+// and no error.
+func NewConstantFloat64(c float64) CalculationFloat64Func {
+	return func() (float64, error) {
+		return c, nil
+	}
+}
+
+// NewVariableFloat64 creates a variable. In calculations, it returns the value
+// it was set to. Calculating the result of a variable never fails. The
+// variable is safe for concurrent use.
+func NewVariableFloat64() VariableFloat64 {
+	return &variableFloat64{}
+}
+
+// VariableFloat64 represents a variable value, which can be set from the
+// outside.
+type VariableFloat64 interface {
+	CalculationFloat64
+
+	// Set sets the variable. Afterwards, calling CalculateFloat64() will return f.
+	Set(f float64)
+}
+
+type variableFloat64 struct {
+	mutex sync.RWMutex
+	value float64
+}
+
+func (v *variableFloat64) CalculateFloat64() (float64, error) {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+	return v.value, nil
+}
+
+func (v *variableFloat64) Set(f float64) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.value = f
+}
+
+// NewSumFloat64 returns a calculation which returns the sum of all calculations
+// passed to it. If one or more calculations fail, an error wrapping all those
+// individual errors is returned.
+func NewSumFloat64(calculations ...CalculationFloat64) CalculationFloat64 {
+	return NewReduceLeftFloat64(
+		func(left, right float64) (float64, error) {
+			return left + right, nil
+		},
+		NewConstantFloat64(0),
+		calculations,
+	)
+}
+
+// NewProductFloat64 returns a calculation which returns the product of all
+// calculations passed to it. If one or more calculations fail, an error
+// wrapping all those individual errors is returned.
+func NewProductFloat64(calculations ...CalculationFloat64) CalculationFloat64 {
+	return NewReduceLeftFloat64(
+		func(left, right float64) (float64, error) {
+			return left * right, nil
+		},
+		NewConstantFloat64(1),
+		calculations,
+	)
+}
+
+// NewDifferenceFloat64 returns a calculation which subtracts the result of
+// subtrahend from the result of minuend. If one or both fail, an error
+// combining those errors is returned.
+func NewDifferenceFloat64(minuend, subtrahend CalculationFloat64) CalculationFloat64Func {
+	return NewCreateBinaryFloat64(
+		func(left, right float64) float64 {
+			return left - right
+		},
+	)(minuend, subtrahend)
+}
+
+// NewQuotientFloat64 returns a calculation which divides the result of dividend
+// by the result of divisor. If one or both fail, an error combining those
+// errors is returned. Instead of returning an infinite value or NaN, dividing
+// by zero returns a *DivisionByZeroError.
+func NewQuotientFloat64(dividend, divisor CalculationFloat64) CalculationFloat64Func {
+	return NewCreateFallibleBinaryFloat64(
+		func(left, right float64) (float64, error) {
+			if right == 0 {
+				return 0, newDivisionByZeroError("quotient", left)
+			}
+			return left / right, nil
+		},
+	)(dividend, divisor)
+}
+
+func runCalculationsFloat64(calculations ...CalculationFloat64) ([]float64, error) {
+	var errs Errors
+	results := make([]float64, len(calculations))
+
+	for i := range calculations {
+		result, err := calculations[i].CalculateFloat64()
+		results[i] = result
+		if err != nil {
+			errs.add(i, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return results, nil
+}
+
+// NewConditionalFloat64 returns a calculation which returns the result of
+// ifTrue or ifFalse, depending on wether boolCalc returns true or false. If
+// boolCalc returns an error, that error is returned instead.
+func NewConditionalFloat64(boolCalc CalculationBool, ifTrue, ifFalse CalculationFloat64) CalculationFloat64 {
+	return ToFloat64(Conditional(FromBool(boolCalc), FromFloat64(ifTrue), FromFloat64(ifFalse)))
+}
+
+// NewCreateBinaryFloat64 wraps a simple binary arithmetic function
+// (float64, float64) -> float64 and returns a calculation constructor
+// representing the same calculation.
+func NewCreateBinaryFloat64(
+	f func(left, right float64) float64,
+) func(left, right CalculationFloat64) CalculationFloat64Func {
+	return NewCreateFallibleBinaryFloat64(
+		func(left, right float64) (float64, error) {
+			return f(left, right), nil
+		},
+	)
+}
+
+// NewCreateFallibleBinaryFloat64 works like NewCreateBinaryFloat64, but wraps a
+// function which may fail. If both operands could be calculated, but f fails,
+// the error returned by f is returned.
+func NewCreateFallibleBinaryFloat64(
+	f func(left, right float64) (float64, error),
+) func(left, right CalculationFloat64) CalculationFloat64Func {
+	return func(left, right CalculationFloat64) CalculationFloat64Func {
+		return func() (float64, error) {
+			var errs Errors
+
+			leftValue, err := left.CalculateFloat64()
+			if err != nil {
+				errs.add(0, err)
+			}
+
+			rightValue, err := right.CalculateFloat64()
+			if err != nil {
+				errs.add(1, err)
+			}
+
+			if len(errs) > 0 {
+				return 0, errs
+			}
+
+			return f(leftValue, rightValue)
+		}
+	}
+}
+
+// NewCreateFallibleUnaryFloat64 wraps a unary function float64 -> (float64, error)
+// and returns a calculation constructor representing the same calculation. If
+// the operand fails, that error is returned, else the result of f.
+func NewCreateFallibleUnaryFloat64(
+	f func(value float64) (float64, error),
+) func(calculation CalculationFloat64) CalculationFloat64Func {
+	return func(calculation CalculationFloat64) CalculationFloat64Func {
+		return func() (float64, error) {
+			v, err := calculation.CalculateFloat64()
+			if err != nil {
+				return 0, err
+			}
+			return f(v)
+		}
+	}
+}
+
+// NewCreateFallibleNaryFloat64 wraps a function taking any number of float64
+// values and returns a calculation constructor representing the same
+// calculation. If one or more operands fail, an error combining those errors is
+// returned, else the result of f.
+func NewCreateFallibleNaryFloat64(
+	f func(values []float64) (float64, error),
+) func(calculations ...CalculationFloat64) CalculationFloat64Func {
+	return func(calculations ...CalculationFloat64) CalculationFloat64Func {
+		return func() (float64, error) {
+			values, err := runCalculationsFloat64(calculations...)
+			if err != nil {
+				return 0, err
+			}
+			return f(values)
+		}
+	}
+}
+
+// NewSignumFloat64 returns a calculation which returns the signum of another
+// calculation. If that other calculation fails, that error is returned instead.
+// The signum of NaN is NaN.
+func NewSignumFloat64(calculation CalculationFloat64) CalculationFloat64Func {
+	return NewCreateFallibleUnaryFloat64(
+		func(v float64) (float64, error) {
+			if v > 0 {
+				return 1, nil
+			}
+			if v < 0 {
+				return -1, nil
+			}
+			return v, nil
+		},
+	)(calculation)
+}
+
+// NewReduceLeftFloat64 works like NewReduceLeft, but for float64 values.
+func NewReduceLeftFloat64(
+	reduce func(current float64, next float64) (float64, error),
+	initialValue CalculationFloat64,
+	calculations []CalculationFloat64,
+) CalculationFloat64 {
+	return ToFloat64(ReduceLeft(reduce, FromFloat64(initialValue), fromSlice(calculations, FromFloat64)))
+}
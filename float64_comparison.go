@@ -0,0 +1,92 @@
+package mmath
+
+import (
+	"math"
+)
+
+// The following comparisons treat two float64 values as equal if their
+// absolute difference is at most epsilon. An epsilon of zero results in exact
+// comparisons. As usual, comparisons involving NaN are false, except for the
+// inequality. If one or both calculations fail, an error combining those errors
+// is returned.
+
+// NewFloat64Equals returns wether the results of the first and second
+// calculations are equal within epsilon.
+func NewFloat64Equals(first, second CalculationFloat64, epsilon float64) CalculationBoolFunc {
+	return newFloat64Comparison(
+		func(first, second float64) bool {
+			return equalsFloat64(first, second, epsilon)
+		},
+	)(first, second)
+}
+
+// NewFloat64NotEquals returns wether the results of the first and second
+// calculations differ by more than epsilon.
+func NewFloat64NotEquals(first, second CalculationFloat64, epsilon float64) CalculationBoolFunc {
+	return newFloat64Comparison(
+		func(first, second float64) bool {
+			return !equalsFloat64(first, second, epsilon)
+		},
+	)(first, second)
+}
+
+// NewFloat64Less returns wether the result of the first calculation is less
+// than the result of the second calculation and they are not equal within
+// epsilon.
+func NewFloat64Less(first, second CalculationFloat64, epsilon float64) CalculationBoolFunc {
+	return newFloat64Comparison(
+		func(first, second float64) bool {
+			return first < second && !equalsFloat64(first, second, epsilon)
+		},
+	)(first, second)
+}
+
+// NewFloat64LessOrEqual returns wether the result of the first calculation is
+// less than or equal within epsilon to the result of the second calculation.
+func NewFloat64LessOrEqual(first, second CalculationFloat64, epsilon float64) CalculationBoolFunc {
+	return newFloat64Comparison(
+		func(first, second float64) bool {
+			return first < second || equalsFloat64(first, second, epsilon)
+		},
+	)(first, second)
+}
+
+// NewFloat64Greater returns wether the result of the first calculation is
+// greater than the result of the second calculation and they are not equal
+// within epsilon.
+func NewFloat64Greater(first, second CalculationFloat64, epsilon float64) CalculationBoolFunc {
+	return newFloat64Comparison(
+		func(first, second float64) bool {
+			return first > second && !equalsFloat64(first, second, epsilon)
+		},
+	)(first, second)
+}
+
+// NewFloat64GreaterOrEqual returns wether the result of the first calculation
+// is greater than or equal within epsilon to the result of the second
+// calculation.
+func NewFloat64GreaterOrEqual(first, second CalculationFloat64, epsilon float64) CalculationBoolFunc {
+	return newFloat64Comparison(
+		func(first, second float64) bool {
+			return first > second || equalsFloat64(first, second, epsilon)
+		},
+	)(first, second)
+}
+
+func newFloat64Comparison(
+	compare func(first, second float64) bool,
+) func(first, second CalculationFloat64) CalculationBoolFunc {
+	return func(first, second CalculationFloat64) CalculationBoolFunc {
+		return func() (bool, error) {
+			values, err := runCalculationsFloat64(first, second)
+			if err != nil {
+				return false, err
+			}
+			return compare(values[0], values[1]), nil
+		}
+	}
+}
+
+func equalsFloat64(first, second, epsilon float64) bool {
+	return first == second || math.Abs(first-second) <= epsilon
+}
@@ -0,0 +1,76 @@
+package mmath_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+)
+
+func TestFloat64Comparison(t *testing.T) {
+	t.Parallel()
+
+	operators := map[string]struct {
+		create func(first, second mmath.CalculationFloat64, epsilon float64) mmath.CalculationBoolFunc
+
+		// Expected results for comparing 1 with 2, 2 with 2.05 and 3 with 2,
+		// using an epsilon of 0.1.
+		less, nearlyEqual, greater bool
+	}{
+		"equals":         {create: mmath.NewFloat64Equals, less: false, nearlyEqual: true, greater: false},
+		"notEquals":      {create: mmath.NewFloat64NotEquals, less: true, nearlyEqual: false, greater: true},
+		"less":           {create: mmath.NewFloat64Less, less: true, nearlyEqual: false, greater: false},
+		"lessOrEqual":    {create: mmath.NewFloat64LessOrEqual, less: true, nearlyEqual: true, greater: false},
+		"greater":        {create: mmath.NewFloat64Greater, less: false, nearlyEqual: false, greater: true},
+		"greaterOrEqual": {create: mmath.NewFloat64GreaterOrEqual, less: false, nearlyEqual: true, greater: true},
+	}
+
+	c := mmath.NewConstantFloat64
+
+	testcases := make(map[string]testcaseBool)
+	for name, operator := range operators {
+		testcases[name+"/less"] = testcaseBool{
+			calculation:   operator.create(c(1), c(2), 0.1),
+			expectedValue: operator.less,
+		}
+		testcases[name+"/nearlyEqual"] = testcaseBool{
+			calculation:   operator.create(c(2), c(2.05), 0.1),
+			expectedValue: operator.nearlyEqual,
+		}
+		testcases[name+"/greater"] = testcaseBool{
+			calculation:   operator.create(c(3), c(2), 0.1),
+			expectedValue: operator.greater,
+		}
+		testcases[name+"/errors"] = testcaseBool{
+			calculation: operator.create(
+				mmath.NewFailingCalculation(fmt.Errorf("broken")),
+				mmath.NewFailingCalculation(fmt.Errorf("meh")),
+				0,
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("broken"),
+				errorContainsString("meh"),
+			),
+		}
+	}
+
+	testcases["equals/exact"] = testcaseBool{
+		calculation:   mmath.NewFloat64Equals(c(2), c(2.05), 0),
+		expectedValue: false,
+	}
+	testcases["equals/infinity"] = testcaseBool{
+		calculation:   mmath.NewFloat64Equals(c(math.Inf(1)), c(math.Inf(1)), 0.1),
+		expectedValue: true,
+	}
+	testcases["equals/nan"] = testcaseBool{
+		calculation:   mmath.NewFloat64Equals(c(math.NaN()), c(math.NaN()), math.Inf(1)),
+		expectedValue: false,
+	}
+	testcases["notEquals/nan"] = testcaseBool{
+		calculation:   mmath.NewFloat64NotEquals(c(math.NaN()), c(math.NaN()), 0),
+		expectedValue: true,
+	}
+
+	runTestcasesBool(t, testcases)
+}
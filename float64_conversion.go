@@ -0,0 +1,48 @@
+package mmath
+
+import (
+	"math"
+)
+
+// NewInt64ToFloat64 returns a calculation which converts the result of an
+// int64 calculation to a float64. Large values may lose precision. If the
+// calculation fails, its error is returned.
+func NewInt64ToFloat64(calculation CalculationInt64) CalculationFloat64Func {
+	return func() (float64, error) {
+		v, err := calculation.CalculateInt64()
+		if err != nil {
+			return 0, err
+		}
+		return float64(v), nil
+	}
+}
+
+// NewFloat64ToInt64 returns a calculation which rounds the result of a float64
+// calculation to an int64, using the given rounding mode. If the calculation
+// fails, its error is returned. NaN and infinite values cause a
+// *NonFiniteError, values which are out of range for an int64 after rounding
+// cause a *RangeError.
+func NewFloat64ToInt64(calculation CalculationFloat64, mode RoundingMode) CalculationInt64Func {
+	return func() (int64, error) {
+		v, err := calculation.CalculateFloat64()
+		if err != nil {
+			return 0, err
+		}
+		return float64ToInt64(v, mode)
+	}
+}
+
+// float64ToInt64 rounds v to an int64.
+func float64ToInt64(v float64, mode RoundingMode) (int64, error) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, newNonFiniteError("conversion to int64", v)
+	}
+	rounded := roundFloat64(v, mode)
+
+	// -2**63 is exactly representable as a float64, but math.MaxInt64 is not,
+	// float64(math.MaxInt64) is 2**63.
+	if rounded < math.MinInt64 || rounded >= -math.MinInt64 {
+		return 0, newRangeError(v, "int64")
+	}
+	return int64(rounded), nil
+}
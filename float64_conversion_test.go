@@ -0,0 +1,73 @@
+package mmath_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+)
+
+func TestFloat64ToInt64(t *testing.T) {
+	t.Parallel()
+
+	c := mmath.NewConstantFloat64
+
+	testcases := map[string]testcaseInt64{
+		"towardZero": {
+			calculation:   mmath.NewFloat64ToInt64(c(-2.7), mmath.RoundTowardZero),
+			expectedValue: -2,
+		},
+		"halfEven": {
+			calculation:   mmath.NewFloat64ToInt64(c(2.5), mmath.RoundHalfEven),
+			expectedValue: 2,
+		},
+		"halfUp": {
+			calculation:   mmath.NewFloat64ToInt64(c(2.5), mmath.RoundHalfUp),
+			expectedValue: 3,
+		},
+		"minimum": {
+			calculation:   mmath.NewFloat64ToInt64(c(math.MinInt64), mmath.RoundTowardZero),
+			expectedValue: math.MinInt64,
+		},
+		"tooLarge": {
+			calculation:       mmath.NewFloat64ToInt64(c(math.MaxInt64), mmath.RoundTowardZero),
+			expectedErrorFunc: errorIsRange("int64"),
+		},
+		"tooSmall": {
+			calculation:       mmath.NewFloat64ToInt64(c(-1e19), mmath.RoundTowardZero),
+			expectedErrorFunc: errorIsRange("int64"),
+		},
+		"nan": {
+			calculation:       mmath.NewFloat64ToInt64(c(math.NaN()), mmath.RoundTowardZero),
+			expectedErrorFunc: errorIsNonFinite("conversion to int64"),
+		},
+		"infinity": {
+			calculation:       mmath.NewFloat64ToInt64(c(math.Inf(-1)), mmath.RoundTowardZero),
+			expectedErrorFunc: errorIsNonFinite("conversion to int64"),
+		},
+		"error": {
+			calculation:       mmath.NewFloat64ToInt64(mmath.NewFailingCalculation(fmt.Errorf("float failed")), mmath.RoundTowardZero),
+			expectedErrorFunc: errorContainsString("float failed"),
+		},
+	}
+
+	runTestcasesInt64(t, testcases)
+}
+
+func TestInt64ToFloat64(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]testcaseFloat64{
+		"value": {
+			calculation:   mmath.NewInt64ToFloat64(mmath.NewConstantInt64(-42)),
+			expectedValue: -42,
+		},
+		"error": {
+			calculation:       mmath.NewInt64ToFloat64(mmath.NewFailingCalculation(fmt.Errorf("int failed"))),
+			expectedErrorFunc: errorContainsString("int failed"),
+		},
+	}
+
+	runTestcasesFloat64(t, testcases)
+}
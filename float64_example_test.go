@@ -0,0 +1,73 @@
+package mmath_test
+
+import (
+	"github.com/GodsBoss/mmath"
+
+	"fmt"
+)
+
+func ExampleNewVariableFloat64() {
+	radius := mmath.NewVariableFloat64()
+	area := mmath.NewProductFloat64(
+		mmath.NewConstantFloat64(3.14),
+		radius,
+		radius,
+	)
+
+	radius.Set(2)
+
+	v, err := area.CalculateFloat64()
+
+	fmt.Printf("Value is %.2f.\n", v)
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+	}
+
+	// Output:
+	// Value is 12.56.
+}
+
+func ExampleNewSqrtFloat64() {
+	v, err := mmath.NewSqrtFloat64(mmath.NewConstantFloat64(-4)).CalculateFloat64()
+
+	fmt.Printf("Value is %g.\n", v)
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+	}
+
+	// Output:
+	// Value is 0.
+	// Error is: non-finite result in sqrt of -4
+}
+
+func ExampleNewFloat64ToInt64() {
+	for _, mode := range []mmath.RoundingMode{mmath.RoundTowardZero, mmath.RoundHalfUp, mmath.RoundHalfEven} {
+		v, err := mmath.NewFloat64ToInt64(mmath.NewConstantFloat64(-2.5), mode).CalculateInt64()
+
+		fmt.Printf("Rounding %s: %d\n", mode, v)
+		if err != nil {
+			fmt.Printf("Error is: %v\n", err)
+		}
+	}
+
+	// Output:
+	// Rounding toward zero: -2
+	// Rounding half up: -3
+	// Rounding half even: -2
+}
+
+func ExampleNewFloat64Equals() {
+	b, err := mmath.NewFloat64Equals(
+		mmath.NewSumFloat64(mmath.NewConstantFloat64(0.1), mmath.NewConstantFloat64(0.2)),
+		mmath.NewConstantFloat64(0.3),
+		1e-9,
+	).CalculateBool()
+
+	fmt.Printf("%t\n", b)
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+	}
+
+	// Output:
+	// true
+}
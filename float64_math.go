@@ -0,0 +1,136 @@
+package mmath
+
+import (
+	"math"
+)
+
+// The following calculations apply functions from the math package to the
+// result of another calculation. If that calculation fails, its error is
+// returned. If the function result is NaN or infinite, e.g. the square root of
+// a negative number or the logarithm of zero, a *NonFiniteError is returned.
+
+// NewSqrtFloat64 returns a calculation returning the square root.
+func NewSqrtFloat64(calculation CalculationFloat64) CalculationFloat64Func {
+	return newFloat64MathFunc("sqrt", math.Sqrt)(calculation)
+}
+
+// NewExpFloat64 returns a calculation returning e**x.
+func NewExpFloat64(calculation CalculationFloat64) CalculationFloat64Func {
+	return newFloat64MathFunc("exp", math.Exp)(calculation)
+}
+
+// NewLogFloat64 returns a calculation returning the natural logarithm.
+func NewLogFloat64(calculation CalculationFloat64) CalculationFloat64Func {
+	return newFloat64MathFunc("log", math.Log)(calculation)
+}
+
+// NewLog10Float64 returns a calculation returning the decimal logarithm.
+func NewLog10Float64(calculation CalculationFloat64) CalculationFloat64Func {
+	return newFloat64MathFunc("log10", math.Log10)(calculation)
+}
+
+// NewSinFloat64 returns a calculation returning the sine of a radian argument.
+func NewSinFloat64(calculation CalculationFloat64) CalculationFloat64Func {
+	return newFloat64MathFunc("sin", math.Sin)(calculation)
+}
+
+// NewCosFloat64 returns a calculation returning the cosine of a radian
+// argument.
+func NewCosFloat64(calculation CalculationFloat64) CalculationFloat64Func {
+	return newFloat64MathFunc("cos", math.Cos)(calculation)
+}
+
+// NewTanFloat64 returns a calculation returning the tangent of a radian
+// argument.
+func NewTanFloat64(calculation CalculationFloat64) CalculationFloat64Func {
+	return newFloat64MathFunc("tan", math.Tan)(calculation)
+}
+
+// NewAsinFloat64 returns a calculation returning the arcsine in radians.
+func NewAsinFloat64(calculation CalculationFloat64) CalculationFloat64Func {
+	return newFloat64MathFunc("asin", math.Asin)(calculation)
+}
+
+// NewAcosFloat64 returns a calculation returning the arccosine in radians.
+func NewAcosFloat64(calculation CalculationFloat64) CalculationFloat64Func {
+	return newFloat64MathFunc("acos", math.Acos)(calculation)
+}
+
+// NewAtanFloat64 returns a calculation returning the arctangent in radians.
+func NewAtanFloat64(calculation CalculationFloat64) CalculationFloat64Func {
+	return newFloat64MathFunc("atan", math.Atan)(calculation)
+}
+
+// NewAbsFloat64 returns a calculation returning the absolute value.
+func NewAbsFloat64(calculation CalculationFloat64) CalculationFloat64Func {
+	return newFloat64MathFunc("abs", math.Abs)(calculation)
+}
+
+// NewPowFloat64 returns a calculation returning base**exponent. If one or both
+// calculations fail, an error combining those errors is returned. If the result
+// is NaN or infinite, a *NonFiniteError is returned.
+func NewPowFloat64(base, exponent CalculationFloat64) CalculationFloat64Func {
+	return NewCreateFallibleBinaryFloat64(
+		func(left, right float64) (float64, error) {
+			return checkFiniteFloat64("pow", math.Pow(left, right), left, right)
+		},
+	)(base, exponent)
+}
+
+// NewRoundFloat64 returns a calculation which rounds the result of another
+// calculation to an integral value, using the given rounding mode. If that
+// calculation fails, its error is returned.
+func NewRoundFloat64(calculation CalculationFloat64, mode RoundingMode) CalculationFloat64Func {
+	return NewCreateFallibleUnaryFloat64(
+		func(v float64) (float64, error) {
+			return roundFloat64(v, mode), nil
+		},
+	)(calculation)
+}
+
+func newFloat64MathFunc(
+	operation string,
+	f func(float64) float64,
+) func(calculation CalculationFloat64) CalculationFloat64Func {
+	return NewCreateFallibleUnaryFloat64(
+		func(v float64) (float64, error) {
+			return checkFiniteFloat64(operation, f(v), v)
+		},
+	)
+}
+
+// checkFiniteFloat64 returns result if it is finite, else a *NonFiniteError.
+func checkFiniteFloat64(operation string, result float64, operands ...float64) (float64, error) {
+	if math.IsNaN(result) || math.IsInf(result, 0) {
+		return 0, newNonFiniteError(operation, operands...)
+	}
+	return result, nil
+}
+
+// roundFloat64 rounds v to an integral value. NaN and infinite values are
+// returned unchanged.
+func roundFloat64(v float64, mode RoundingMode) float64 {
+	switch mode {
+	case RoundAwayFromZero:
+		if v < 0 {
+			return math.Floor(v)
+		}
+		return math.Ceil(v)
+	case RoundFloor:
+		return math.Floor(v)
+	case RoundCeiling:
+		return math.Ceil(v)
+	case RoundHalfUp:
+		return math.Round(v)
+	case RoundHalfDown:
+		t := math.Trunc(v)
+		if math.Abs(v-t) > 0.5 {
+			return t + math.Copysign(1, v)
+		}
+		return t
+	case RoundHalfEven:
+		return math.RoundToEven(v)
+	default:
+		return math.Trunc(v)
+	}
+}
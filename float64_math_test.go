@@ -0,0 +1,94 @@
+package mmath_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+)
+
+func TestFloat64Math(t *testing.T) {
+	t.Parallel()
+
+	c := mmath.NewConstantFloat64
+
+	testcases := map[string]testcaseFloat64{
+		"sqrt/value":       {calculation: mmath.NewSqrtFloat64(c(16)), expectedValue: 4},
+		"sqrt/negative":    {calculation: mmath.NewSqrtFloat64(c(-1)), expectedErrorFunc: errorIsNonFinite("sqrt")},
+		"exp/value":        {calculation: mmath.NewExpFloat64(c(0)), expectedValue: 1},
+		"exp/overflow":     {calculation: mmath.NewExpFloat64(c(1000)), expectedErrorFunc: errorIsNonFinite("exp")},
+		"log/value":        {calculation: mmath.NewLogFloat64(c(1)), expectedValue: 0},
+		"log/zero":         {calculation: mmath.NewLogFloat64(c(0)), expectedErrorFunc: errorIsNonFinite("log")},
+		"log10/value":      {calculation: mmath.NewLog10Float64(c(1000)), expectedValue: 3},
+		"sin/value":        {calculation: mmath.NewSinFloat64(c(0)), expectedValue: 0},
+		"cos/value":        {calculation: mmath.NewCosFloat64(c(0)), expectedValue: 1},
+		"tan/value":        {calculation: mmath.NewTanFloat64(c(0)), expectedValue: 0},
+		"asin/value":       {calculation: mmath.NewAsinFloat64(c(1)), expectedValue: math.Pi / 2},
+		"asin/outOfDomain": {calculation: mmath.NewAsinFloat64(c(2)), expectedErrorFunc: errorIsNonFinite("asin")},
+		"acos/value":       {calculation: mmath.NewAcosFloat64(c(1)), expectedValue: 0},
+		"atan/value":       {calculation: mmath.NewAtanFloat64(c(0)), expectedValue: 0},
+		"abs/value":        {calculation: mmath.NewAbsFloat64(c(-2.5)), expectedValue: 2.5},
+		"abs/nan":          {calculation: mmath.NewAbsFloat64(c(math.NaN())), expectedErrorFunc: errorIsNonFinite("abs")},
+		"sqrt/error": {
+			calculation:       mmath.NewSqrtFloat64(mmath.NewFailingCalculation(fmt.Errorf("no root"))),
+			expectedErrorFunc: errorContainsString("no root"),
+		},
+		"pow/value": {
+			calculation:   mmath.NewPowFloat64(c(2), c(10)),
+			expectedValue: 1024,
+		},
+		"pow/nonFinite": {
+			calculation:       mmath.NewPowFloat64(c(0), c(-1)),
+			expectedErrorFunc: errorIsNonFinite("pow"),
+		},
+		"pow/errors": {
+			calculation: mmath.NewPowFloat64(
+				mmath.NewFailingCalculation(fmt.Errorf("base")),
+				mmath.NewFailingCalculation(fmt.Errorf("exponent")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("base"),
+				errorContainsString("exponent"),
+			),
+		},
+	}
+
+	runTestcasesFloat64(t, testcases)
+}
+
+func TestRoundFloat64(t *testing.T) {
+	t.Parallel()
+
+	modes := map[string]mmath.RoundingMode{
+		"towardZero":   mmath.RoundTowardZero,
+		"awayFromZero": mmath.RoundAwayFromZero,
+		"floor":        mmath.RoundFloor,
+		"ceiling":      mmath.RoundCeiling,
+		"halfUp":       mmath.RoundHalfUp,
+		"halfDown":     mmath.RoundHalfDown,
+		"halfEven":     mmath.RoundHalfEven,
+	}
+
+	// expected maps inputs to the expected results for every mode.
+	expected := map[float64]map[string]float64{
+		2.5:  {"towardZero": 2, "awayFromZero": 3, "floor": 2, "ceiling": 3, "halfUp": 3, "halfDown": 2, "halfEven": 2},
+		3.5:  {"towardZero": 3, "awayFromZero": 4, "floor": 3, "ceiling": 4, "halfUp": 4, "halfDown": 3, "halfEven": 4},
+		-2.5: {"towardZero": -2, "awayFromZero": -3, "floor": -3, "ceiling": -2, "halfUp": -3, "halfDown": -2, "halfEven": -2},
+		2.7:  {"towardZero": 2, "awayFromZero": 3, "floor": 2, "ceiling": 3, "halfUp": 3, "halfDown": 3, "halfEven": 3},
+		-2.2: {"towardZero": -2, "awayFromZero": -3, "floor": -3, "ceiling": -2, "halfUp": -2, "halfDown": -2, "halfEven": -2},
+		4:    {"towardZero": 4, "awayFromZero": 4, "floor": 4, "ceiling": 4, "halfUp": 4, "halfDown": 4, "halfEven": 4},
+	}
+
+	testcases := make(map[string]testcaseFloat64)
+	for input, results := range expected {
+		for modeName, mode := range modes {
+			testcases[fmt.Sprintf("%s/%g", modeName, input)] = testcaseFloat64{
+				calculation:   mmath.NewRoundFloat64(mmath.NewConstantFloat64(input), mode),
+				expectedValue: results[modeName],
+			}
+		}
+	}
+
+	runTestcasesFloat64(t, testcases)
+}
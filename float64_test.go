@@ -0,0 +1,216 @@
+package mmath_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+)
+
+func TestFloat64(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]testcaseFloat64{
+		"sum/no_summands": {
+			calculation:   mmath.NewSumFloat64(),
+			expectedValue: 0,
+		},
+		"sum/some_numbers": {
+			calculation: mmath.NewSumFloat64(
+				mmath.NewConstantFloat64(1.5),
+				mmath.NewConstantFloat64(-0.25),
+				mmath.NewConstantFloat64(2),
+			),
+			expectedValue: 3.25,
+		},
+		"sum/errors": {
+			calculation: mmath.NewSumFloat64(
+				mmath.NewFailingCalculation(fmt.Errorf("foobar")),
+				mmath.NewFailingCalculation(fmt.Errorf("xyz")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("foobar"),
+				errorContainsString("xyz"),
+			),
+		},
+		"product/no_factors": {
+			calculation:   mmath.NewProductFloat64(),
+			expectedValue: 1,
+		},
+		"product/some_numbers": {
+			calculation: mmath.NewProductFloat64(
+				mmath.NewConstantFloat64(0.5),
+				mmath.NewConstantFloat64(-3),
+				mmath.NewConstantFloat64(4),
+			),
+			expectedValue: -6,
+		},
+		"product/errors": {
+			calculation: mmath.NewProductFloat64(
+				mmath.NewFailingCalculation(fmt.Errorf("abc")),
+				mmath.NewFailingCalculation(fmt.Errorf("123")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("abc"),
+				errorContainsString("123"),
+			),
+		},
+		"difference/value": {
+			calculation: mmath.NewDifferenceFloat64(
+				mmath.NewConstantFloat64(1),
+				mmath.NewConstantFloat64(2.5),
+			),
+			expectedValue: -1.5,
+		},
+		"quotient/value": {
+			calculation: mmath.NewQuotientFloat64(
+				mmath.NewConstantFloat64(1),
+				mmath.NewConstantFloat64(4),
+			),
+			expectedValue: 0.25,
+		},
+		"quotient/by_zero": {
+			calculation: mmath.NewQuotientFloat64(
+				mmath.NewConstantFloat64(1),
+				mmath.NewConstantFloat64(0),
+			),
+			expectedErrorFunc: errorIsDivisionByZero("quotient"),
+		},
+		"quotient/errors": {
+			calculation: mmath.NewQuotientFloat64(
+				mmath.NewFailingCalculation(fmt.Errorf("dividend failed")),
+				mmath.NewFailingCalculation(fmt.Errorf("divisor failed")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("dividend failed"),
+				errorContainsString("divisor failed"),
+			),
+		},
+		"conditional/true": {
+			calculation: mmath.NewConditionalFloat64(
+				mmath.NewTrue(),
+				mmath.NewConstantFloat64(1.5),
+				mmath.NewConstantFloat64(2.5),
+			),
+			expectedValue: 1.5,
+		},
+		"conditional/false": {
+			calculation: mmath.NewConditionalFloat64(
+				mmath.NewFalse(),
+				mmath.NewConstantFloat64(1.5),
+				mmath.NewConstantFloat64(2.5),
+			),
+			expectedValue: 2.5,
+		},
+		"conditional/error": {
+			calculation: mmath.NewConditionalFloat64(
+				mmath.NewFailingCalculation(fmt.Errorf("hello")),
+				mmath.NewConstantFloat64(1),
+				mmath.NewConstantFloat64(2),
+			),
+			expectedErrorFunc: errorContainsString("hello"),
+		},
+		"signum/negative": {
+			calculation:   mmath.NewSignumFloat64(mmath.NewConstantFloat64(-0.1)),
+			expectedValue: -1,
+		},
+		"signum/nan": {
+			calculation:   mmath.NewSignumFloat64(mmath.NewConstantFloat64(math.NaN())),
+			expectedValue: math.NaN(),
+		},
+		"signum/error": {
+			calculation:       mmath.NewSignumFloat64(mmath.NewFailingCalculation(fmt.Errorf("xyz"))),
+			expectedErrorFunc: errorContainsString("xyz"),
+		},
+		"fallibleNary/errors": {
+			calculation: mmath.NewCreateFallibleNaryFloat64(
+				func(values []float64) (float64, error) {
+					return 0, nil
+				},
+			)(
+				mmath.NewFailingCalculation(fmt.Errorf("first")),
+				mmath.NewConstantFloat64(5),
+				mmath.NewFailingCalculation(fmt.Errorf("third")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("first"),
+				errorContainsString("third"),
+			),
+		},
+		"reduceLeft/initialValueError": {
+			calculation: mmath.NewReduceLeftFloat64(
+				func(_, _ float64) (float64, error) {
+					return 0, nil
+				},
+				mmath.NewFailingCalculation(fmt.Errorf("initial calculation failed")),
+				[]mmath.CalculationFloat64{},
+			),
+			expectedErrorFunc: errorContainsString("initial calculation failed"),
+		},
+		"reduceLeft/reduceError": {
+			calculation: mmath.NewReduceLeftFloat64(
+				func(_, _ float64) (float64, error) {
+					return 0, fmt.Errorf("reduce failure")
+				},
+				mmath.NewConstantFloat64(0),
+				[]mmath.CalculationFloat64{
+					mmath.NewConstantFloat64(0),
+				},
+			),
+			expectedErrorFunc: errorContainsString("reduce failure"),
+		},
+	}
+
+	runTestcasesFloat64(t, testcases)
+}
+
+func runTestcasesFloat64(t *testing.T, testcases map[string]testcaseFloat64) {
+	for name := range testcases {
+		testcaseFloat64 := testcases[name]
+
+		t.Run(
+			name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				actualValue, actualErr := testcaseFloat64.calculation.CalculateFloat64()
+
+				bothNaN := math.IsNaN(actualValue) && math.IsNaN(testcaseFloat64.expectedValue)
+				if actualValue != testcaseFloat64.expectedValue && !bothNaN {
+					t.Errorf(
+						"expected calculation result value to be %g, but got %g",
+						testcaseFloat64.expectedValue,
+						actualValue,
+					)
+				}
+
+				if testcaseFloat64.expectedErrorFunc == nil && actualErr != nil {
+					t.Errorf("expected no error, but got %+v", actualErr)
+				}
+
+				if testcaseFloat64.expectedErrorFunc != nil {
+					if actualErr != nil {
+						testcaseFloat64.expectedErrorFunc(t, actualErr)
+					}
+					if actualErr == nil {
+						t.Errorf("expected non-nil error")
+					}
+				}
+			},
+		)
+	}
+}
+
+type testcaseFloat64 struct {
+	// calculation is the calculation executed by the test. It's result is
+	// compared against the expected values.
+	calculation mmath.CalculationFloat64
+
+	// expectedValue is the value the calculation should return. NaN matches NaN.
+	expectedValue float64
+
+	// expectedErrorFunc checks the error. This being nil is equivalent to
+	// checking wether the error should be nil.
+	expectedErrorFunc errorTest
+}
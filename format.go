@@ -0,0 +1,277 @@
+package mmath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FormatFormula returns a human-readable infix formula for a calculation,
+// which must be a CalculationInt64 or a CalculationBool. Operators known to
+// package parser are printed with its syntax, e.g. "a + b * c" or
+// "if(a < b, a, b)", using only the parentheses needed to keep the structure
+// of the calculation. All other operators are printed like function calls,
+// e.g. "checkedSum(a, b)".
+//
+// Variables created via NewNamedVariableInt64 or NewNamedVariableBool are
+// printed with their name, other variables are numbered in order of their
+// first appearance, i.e. "var1", "var2" and so on. Calculations which do not
+// know their structure, e.g. a CalculationInt64Func, are printed via their
+// String method if they have one, else as "<unknown>".
+func FormatFormula(calculation interface{}) string {
+	text, _ := newFormatter(calculation).formula(calculation)
+	return text
+}
+
+// FormatTree returns an indented dump of a calculation, which must be a
+// CalculationInt64 or a CalculationBool. Every line contains one calculation,
+// operands are indented by two spaces below the calculation using them.
+// Variables are named like in FormatFormula.
+func FormatTree(calculation interface{}) string {
+	f := newFormatter(calculation)
+	lines := []string{}
+	f.tree(calculation, 0, &lines)
+	return strings.Join(lines, "\n")
+}
+
+const unknownCalculation = "<unknown>"
+
+const (
+	precedenceOr = iota + 1
+	precedenceAnd
+	precedenceComparison
+	precedenceAdditive
+	precedenceMultiplicative
+	precedenceUnary
+	precedencePrimary
+)
+
+type infixOperator struct {
+	symbol     string
+	precedence int
+}
+
+var infixOperators = map[Kind]infixOperator{
+	KindOr:                  {symbol: "||", precedence: precedenceOr},
+	KindAnd:                 {symbol: "&&", precedence: precedenceAnd},
+	KindEquals:              {symbol: "==", precedence: precedenceComparison},
+	KindNotEquals:           {symbol: "!=", precedence: precedenceComparison},
+	KindEquivalent:          {symbol: "==", precedence: precedenceComparison},
+	KindXor:                 {symbol: "!=", precedence: precedenceComparison},
+	KindLess:                {symbol: "<", precedence: precedenceComparison},
+	KindLessOrEqual:         {symbol: "<=", precedence: precedenceComparison},
+	KindGreater:             {symbol: ">", precedence: precedenceComparison},
+	KindGreaterOrEqual:      {symbol: ">=", precedence: precedenceComparison},
+	KindChainLess:           {symbol: "<", precedence: precedenceComparison},
+	KindChainLessOrEqual:    {symbol: "<=", precedence: precedenceComparison},
+	KindChainGreater:        {symbol: ">", precedence: precedenceComparison},
+	KindChainGreaterOrEqual: {symbol: ">=", precedence: precedenceComparison},
+	KindSum:                 {symbol: "+", precedence: precedenceAdditive},
+	KindDifference:          {symbol: "-", precedence: precedenceAdditive},
+	KindProduct:             {symbol: "*", precedence: precedenceMultiplicative},
+	KindQuotient:            {symbol: "/", precedence: precedenceMultiplicative},
+	KindRemainder:           {symbol: "%", precedence: precedenceMultiplicative},
+}
+
+// infixOperandCounts contains operators which are only printed infix for a
+// certain number of operands.
+var infixOperandCounts = map[Kind]int{
+	KindEquivalent: 2,
+	KindXor:        2,
+}
+
+var prefixOperators = map[Kind]string{
+	KindNegation: "-",
+	KindNot:      "!",
+}
+
+// functionNames contains function names deviating from the kind.
+var functionNames = map[Kind]string{
+	KindConditional: "if",
+	KindSignum:      "sign",
+	KindFailing:     "fail",
+}
+
+type formatter struct {
+	variableNames map[Node]string
+}
+
+// newFormatter creates a formatter which knows the names of all variables
+// contained in calculation.
+func newFormatter(calculation interface{}) *formatter {
+	f := &formatter{
+		variableNames: make(map[Node]string),
+	}
+	taken := make(map[string]bool)
+	unnamed := []Node{}
+	seen := make(map[Node]bool)
+
+	Inspect(
+		NodeOf(calculation),
+		func(node Node) bool {
+			if node == nil || node.Kind() != KindVariable {
+				return true
+			}
+			if seen[node] {
+				return false
+			}
+			seen[node] = true
+			if name := node.Params()[0].(string); name != "" {
+				f.variableNames[node] = name
+				taken[name] = true
+				return false
+			}
+			unnamed = append(unnamed, node)
+			return false
+		},
+	)
+
+	number := 0
+	for i := range unnamed {
+		name := ""
+		for name == "" || taken[name] {
+			number++
+			name = "var" + strconv.Itoa(number)
+		}
+		f.variableNames[unnamed[i]] = name
+	}
+
+	return f
+}
+
+// formula returns calculation as formula and the precedence of its outermost
+// operator.
+func (f *formatter) formula(calculation interface{}) (string, int) {
+	node := NodeOf(calculation)
+	children := node.Children()
+
+	switch node.Kind() {
+	case KindOpaque:
+		return unknownText(node.Params()[0]), precedencePrimary
+	case KindConstant:
+		return formatConstant(node.Params()[0]), precedencePrimary
+	case KindVariable:
+		return f.variableNames[node], precedencePrimary
+	case KindFailing:
+		return "fail(" + strconv.Quote(fmt.Sprint(node.Params()[0])) + ")", precedencePrimary
+	}
+
+	if symbol, ok := prefixOperators[node.Kind()]; ok {
+		operand, precedence := f.formula(children[0])
+		if precedence < precedenceUnary || isInt64Constant(children[0]) {
+			operand = "(" + operand + ")"
+		}
+		return symbol + operand, precedenceUnary
+	}
+
+	if operator, ok := infixOperators[node.Kind()]; ok && isInfix(node.Kind(), len(children)) {
+		operands := make([]string, len(children))
+		for i := range children {
+			operand, precedence := f.formula(children[i])
+			if needsParentheses(operator.precedence, precedence, i) {
+				operand = "(" + operand + ")"
+			}
+			operands[i] = operand
+		}
+		return strings.Join(operands, " "+operator.symbol+" "), operator.precedence
+	}
+
+	arguments := make([]string, len(children))
+	for i := range children {
+		arguments[i], _ = f.formula(children[i])
+	}
+	return functionName(node.Kind()) + "(" + strings.Join(arguments, ", ") + ")", precedencePrimary
+}
+
+func (f *formatter) tree(calculation interface{}, depth int, lines *[]string) {
+	node := NodeOf(calculation)
+	*lines = append(*lines, strings.Repeat("  ", depth)+f.label(node))
+
+	children := node.Children()
+	for i := range children {
+		f.tree(children[i], depth+1, lines)
+	}
+}
+
+// label returns the line describing node in a tree, without its children.
+func (f *formatter) label(node Node) string {
+	switch node.Kind() {
+	case KindOpaque:
+		return unknownText(node.Params()[0])
+	case KindConstant:
+		return formatConstant(node.Params()[0])
+	case KindVariable:
+		return f.variableNames[node]
+	case KindFailing:
+		return string(KindFailing) + " " + strconv.Quote(fmt.Sprint(node.Params()[0]))
+	}
+
+	label := string(node.Kind())
+	if params := node.Params(); len(params) > 0 {
+		texts := make([]string, len(params))
+		for i := range params {
+			texts[i] = fmt.Sprint(params[i])
+		}
+		label += " (" + strings.Join(texts, ", ") + ")"
+	}
+	return label
+}
+
+// isInfix returns wether a calculation with an infix operator is printed
+// infix. Operators with less than two operands are printed like functions.
+func isInfix(kind Kind, operandCount int) bool {
+	if count, ok := infixOperandCounts[kind]; ok {
+		return operandCount == count
+	}
+	return operandCount >= 2
+}
+
+// needsParentheses returns wether the operand at position index with the
+// given precedence must be put into parentheses. Equality and comparison
+// operators share one precedence and do not associate, all other binary
+// operators associate to the left.
+func needsParentheses(operatorPrecedence, operandPrecedence int, index int) bool {
+	if operandPrecedence != operatorPrecedence {
+		return operandPrecedence < operatorPrecedence
+	}
+	if operatorPrecedence == precedenceComparison {
+		return true
+	}
+	return index > 0
+}
+
+// isInt64Constant returns wether node is an int64 constant. Those are put
+// into parentheses as operands of prefix operators, so that neither -5 nor
+// --5 are read as a single literal.
+func isInt64Constant(node Node) bool {
+	if node.Kind() != KindConstant {
+		return false
+	}
+	_, ok := node.Params()[0].(int64)
+	return ok
+}
+
+func formatConstant(value interface{}) string {
+	switch v := value.(type) {
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func functionName(k Kind) string {
+	if name, ok := functionNames[k]; ok {
+		return name
+	}
+	return string(k)
+}
+
+func unknownText(calculation interface{}) string {
+	if stringer, ok := calculation.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return unknownCalculation
+}
@@ -0,0 +1,43 @@
+package mmath_test
+
+import (
+	"github.com/GodsBoss/mmath"
+
+	"fmt"
+)
+
+func ExampleFormatFormula() {
+	stock := mmath.NewNamedVariableInt64("stock")
+	price := mmath.NewNamedVariableInt64("price")
+
+	calc := mmath.NewConditionalInt64(
+		mmath.NewInt64Less(stock, mmath.NewConstantInt64(10)),
+		mmath.NewProductInt64(mmath.NewSumInt64(price, mmath.NewConstantInt64(5)), mmath.NewConstantInt64(2)),
+		price,
+	)
+
+	fmt.Println(mmath.FormatFormula(calc))
+
+	// Output:
+	// if(stock < 10, (price + 5) * 2, price)
+}
+
+func ExampleFormatTree() {
+	calc := mmath.NewConditionalInt64(
+		mmath.NewAnd(mmath.Strict, mmath.NewNamedVariableBool("active"), mmath.NewTrue()),
+		mmath.NewSumInt64(mmath.NewVariableInt64(), mmath.NewConstantInt64(1)),
+		mmath.NewConstantInt64(0),
+	)
+
+	fmt.Println(mmath.FormatTree(calc))
+
+	// Output:
+	// conditional
+	//   and (strict)
+	//     active
+	//     true
+	//   sum
+	//     var1
+	//     1
+	//   0
+}
@@ -0,0 +1,151 @@
+package mmath_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+)
+
+func TestFormatFormula(t *testing.T) {
+	t.Parallel()
+
+	a := mmath.NewNamedVariableInt64("a")
+	b := mmath.NewNamedVariableInt64("b")
+	c := mmath.NewNamedVariableInt64("c")
+	p := mmath.NewNamedVariableBool("p")
+	q := mmath.NewNamedVariableBool("q")
+
+	testcases := map[string]struct {
+		calculation interface{}
+		expected    string
+	}{
+		"constant": {
+			calculation: mmath.NewConstantInt64(-42),
+			expected:    "-42",
+		},
+		"boolConstant": {
+			calculation: mmath.NewFalse(),
+			expected:    "false",
+		},
+		"sum": {
+			calculation: mmath.NewSumInt64(a, b, mmath.NewConstantInt64(3)),
+			expected:    "a + b + 3",
+		},
+		"precedence": {
+			calculation: mmath.NewSumInt64(a, mmath.NewProductInt64(b, c)),
+			expected:    "a + b * c",
+		},
+		"lowerPrecedenceOperand": {
+			calculation: mmath.NewProductInt64(mmath.NewSumInt64(a, b), c),
+			expected:    "(a + b) * c",
+		},
+		"leftAssociative": {
+			calculation: mmath.NewDifferenceInt64(mmath.NewDifferenceInt64(a, b), c),
+			expected:    "a - b - c",
+		},
+		"rightOperand": {
+			calculation: mmath.NewDifferenceInt64(a, mmath.NewSumInt64(b, c)),
+			expected:    "a - (b + c)",
+		},
+		"division": {
+			calculation: mmath.NewRemainderInt64(mmath.NewQuotientInt64(a, b), mmath.NewQuotientInt64(b, c)),
+			expected:    "a / b % (b / c)",
+		},
+		"negation": {
+			calculation: mmath.NewNegationInt64(mmath.NewSumInt64(a, b)),
+			expected:    "-(a + b)",
+		},
+		"negatedConstant": {
+			calculation: mmath.NewNegationInt64(mmath.NewConstantInt64(5)),
+			expected:    "-(5)",
+		},
+		"negatedNegativeConstant": {
+			calculation: mmath.NewNegationInt64(mmath.NewConstantInt64(-5)),
+			expected:    "-(-5)",
+		},
+		"conditional": {
+			calculation: mmath.NewConditionalInt64(mmath.NewInt64Less(a, b), a, b),
+			expected:    "if(a < b, a, b)",
+		},
+		"functions": {
+			calculation: mmath.NewCheckedSumInt64(mmath.NewCheckedAbsInt64(a), mmath.NewSignumInt64(b)),
+			expected:    "checkedSum(abs(a), sign(b))",
+		},
+		"custom": {
+			calculation: mmath.NewCreateBinaryInt64(func(left, right int64) int64 { return left })(a, b),
+			expected:    "function(a, b)",
+		},
+		"unknown": {
+			calculation: mmath.NewSumInt64(a, mmath.CalculationInt64Func(func() (int64, error) { return 0, nil })),
+			expected:    "a + <unknown>",
+		},
+		"failing": {
+			calculation: mmath.NewSumInt64(a, mmath.NewFailingCalculation(errors.New("broken"))),
+			expected:    `a + fail("broken")`,
+		},
+		"logic": {
+			calculation: mmath.NewOr(mmath.ShortCircuit, mmath.NewAnd(mmath.Strict, p, q), mmath.NewNot(p)),
+			expected:    "p && q || !p",
+		},
+		"logicParentheses": {
+			calculation: mmath.NewAnd(mmath.ShortCircuit, mmath.NewOr(mmath.ShortCircuit, p, q), mmath.NewNot(mmath.NewOr(mmath.ShortCircuit, p))),
+			expected:    "(p || q) && !or(p)",
+		},
+		"equality": {
+			calculation: mmath.NewEquivalent(mmath.ShortCircuit, mmath.NewInt64Equals(a, b), mmath.NewXor(mmath.Strict, p, q)),
+			expected:    "(a == b) == (p != q)",
+		},
+		"xorWithThreeOperands": {
+			calculation: mmath.NewXor(mmath.Strict, p, q, p),
+			expected:    "xor(p, q, p)",
+		},
+		"chain": {
+			calculation: mmath.NewInt64ChainLessOrEqual(a, mmath.NewSumInt64(b, c), c),
+			expected:    "a <= b + c <= c",
+		},
+		"between": {
+			calculation: mmath.NewInt64Between(a, b, c),
+			expected:    "between(a, b, c)",
+		},
+	}
+
+	for name := range testcases {
+		testcase := testcases[name]
+
+		t.Run(
+			name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				formula := mmath.FormatFormula(testcase.calculation)
+				if formula != testcase.expected {
+					t.Errorf("expected formula '%s', got '%s'", testcase.expected, formula)
+				}
+			},
+		)
+	}
+}
+
+func TestFormatFormulaNumbersUnnamedVariables(t *testing.T) {
+	first := mmath.NewVariableInt64()
+	second := mmath.NewVariableInt64()
+	taken := mmath.NewNamedVariableInt64("var2")
+
+	calc := mmath.NewSumInt64(first, taken, second, first)
+
+	expected := "var1 + var2 + var3 + var1"
+	if formula := mmath.FormatFormula(calc); formula != expected {
+		t.Errorf("expected formula '%s', got '%s'", expected, formula)
+	}
+}
+
+func TestCalculationsImplementStringer(t *testing.T) {
+	calc := mmath.NewInt64Greater(mmath.NewNamedVariableInt64("x"), mmath.NewConstantInt64(0))
+
+	expected := "x > 0"
+	if s := fmt.Sprint(calc); s != expected {
+		t.Errorf("expected '%s', got '%s'", expected, s)
+	}
+}
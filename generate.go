@@ -0,0 +1,962 @@
+package mmath
+
+import (
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"go/types"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// GenerateGoInt64 returns the source code of a Go file in package pkg
+// containing a function called name, which calculates the same results as
+// calculation, including errors, without building calculations at runtime.
+//
+// Every variable becomes a parameter of the function, in order of the first
+// appearance of the variables in calculation. Parameters are named like
+// variables in FormatFormula, so names of named variables must be valid Go
+// identifiers. Errors of failing calculations, see NewFailingCalculation,
+// must be created via errors.New. They are created once again with the same
+// message and stored in package-level variables named after the function, so
+// they are equal to the original errors for reflect.DeepEqual, but not for
+// errors.Is. Other errors, e.g. of custom types or wrapping other errors, are
+// not supported, as they cannot be turned into source code.
+//
+// All int64 and bool calculations of this package are supported, except for
+// functions and reductions created via NewCreateBinaryInt64, NewReduceLeft
+// and similar constructors, as Go functions cannot be turned into source
+// code. Calculations which are no nodes or nodes of kinds not created by this
+// package are not supported either.
+func GenerateGoInt64(pkg, name string, calculation CalculationInt64) ([]byte, error) {
+	return generateGo(pkg, name, calculation, "int64")
+}
+
+// GenerateGoBool works like GenerateGoInt64, but for bool calculations.
+func GenerateGoBool(pkg, name string, calculation CalculationBool) ([]byte, error) {
+	return generateGo(pkg, name, calculation, "bool")
+}
+
+func generateGo(pkg, name string, calculation interface{}, typ string) ([]byte, error) {
+	for _, identifier := range []string{pkg, name} {
+		if !isGoIdentifier(identifier) {
+			return nil, fmt.Errorf("%q is no valid Go identifier", identifier)
+		}
+	}
+
+	root := NodeOf(calculation)
+	g := newGoGenerator(root, name)
+	if err := g.collectParameters(root); err != nil {
+		return nil, err
+	}
+	result, err := g.generate(root, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	source := &strings.Builder{}
+	fmt.Fprintf(source, "// Code generated by mmath.GenerateGo%s. DO NOT EDIT.\n\n", goTypeNames[typ])
+	fmt.Fprintf(source, "package %s\n\n", pkg)
+	g.writeImports(source)
+	g.writeFailures(source)
+
+	formula, _ := g.formatter.formula(root)
+	parameters := make([]string, len(g.parameters))
+	for i := range g.parameters {
+		parameters[i] = g.parameters[i].name + " " + g.parameters[i].typ
+	}
+	fmt.Fprintf(source, "// %s calculates %s.\n", name, formula)
+	fmt.Fprintf(source, "func %s(%s) (%s, error) {\n", name, strings.Join(parameters, ", "), typ)
+	for _, line := range g.lines {
+		fmt.Fprintln(source, line)
+	}
+	fmt.Fprintf(source, "\treturn %s, %s\n}\n", result.value, result.err)
+
+	return format.Source([]byte(source.String()))
+}
+
+func isGoIdentifier(name string) bool {
+	if name == "" || token.Lookup(name).IsKeyword() {
+		return false
+	}
+	for i, r := range name {
+		if !unicode.IsLetter(r) && r != '_' && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+var goTypeNames = map[string]string{
+	"int64": "Int64",
+	"bool":  "Bool",
+}
+
+// temporaryName matches the names of variables declared by generated code.
+var temporaryName = regexp.MustCompile(`^[ve][0-9]+$`)
+
+// goValue is the result of a generated calculation.
+type goValue struct {
+	// value is a Go expression for the value.
+	value string
+
+	// err is a Go expression for the error, "nil" if the calculation cannot
+	// fail.
+	err string
+
+	// constant is true if value is a constant expression. Go evaluates those
+	// at compile time, so overflows and divisions by zero would not compile.
+	constant bool
+
+	// failing is true if the calculation always fails.
+	failing bool
+}
+
+type goParameter struct {
+	name string
+	typ  string
+}
+
+type goFailure struct {
+	name string
+	err  error
+}
+
+type goGenerator struct {
+	formatter *formatter
+	name      string
+
+	parameters []goParameter
+	failures   []goFailure
+	imports    map[string]bool
+
+	// lines contains the body of the generated function, except for the
+	// return statement.
+	lines       []string
+	indentation int
+	temporaries int
+}
+
+func newGoGenerator(root Node, name string) *goGenerator {
+	return &goGenerator{
+		formatter: newFormatter(root),
+		name:      name,
+		imports:   make(map[string]bool),
+	}
+}
+
+// collectParameters collects all variables contained in root, in order of
+// their first appearance.
+func (g *goGenerator) collectParameters(root Node) error {
+	var err error
+	seen := make(map[Node]bool)
+	Inspect(
+		root,
+		func(node Node) bool {
+			if err != nil || node == nil || node.Kind() != KindVariable {
+				return err == nil
+			}
+			if seen[node] {
+				return false
+			}
+			seen[node] = true
+
+			name := g.formatter.variableNames[node]
+			if !isGoIdentifier(name) || temporaryName.MatchString(name) || g.isReserved(name) {
+				err = fmt.Errorf("variable name %q cannot be used as Go parameter", name)
+				return false
+			}
+			typ := "int64"
+			if _, ok := calculationOf(node).(CalculationBool); ok {
+				typ = "bool"
+			}
+			g.parameters = append(g.parameters, goParameter{name: name, typ: typ})
+			return false
+		},
+	)
+	return err
+}
+
+// isReserved returns wether name is used by generated code besides
+// temporaries.
+func (g *goGenerator) isReserved(name string) bool {
+	switch name {
+	case "errors", "math", "mmath", g.name:
+		return true
+	}
+	if types.Universe.Lookup(name) != nil {
+		return true
+	}
+	return strings.HasPrefix(name, g.failurePrefix())
+}
+
+func (g *goGenerator) failurePrefix() string {
+	r, size := utf8.DecodeRuneInString(g.name)
+	return string(unicode.ToLower(r)) + g.name[size:] + "Failure"
+}
+
+func (g *goGenerator) writeImports(source *strings.Builder) {
+	standard := []string{}
+	for _, path := range []string{"errors", "math"} {
+		if g.imports[path] {
+			standard = append(standard, strconv.Quote(path))
+		}
+	}
+	if len(standard) == 0 && !g.imports["mmath"] {
+		return
+	}
+	source.WriteString("import (\n")
+	for _, path := range standard {
+		source.WriteString("\t" + path + "\n")
+	}
+	if g.imports["mmath"] {
+		if len(standard) > 0 {
+			source.WriteString("\n")
+		}
+		source.WriteString("\t\"github.com/GodsBoss/mmath\"\n")
+	}
+	source.WriteString(")\n\n")
+}
+
+func (g *goGenerator) writeFailures(source *strings.Builder) {
+	if len(g.failures) == 0 {
+		return
+	}
+	source.WriteString("var (\n")
+	for _, failure := range g.failures {
+		fmt.Fprintf(source, "\t%s = errors.New(%s)\n", failure.name, strconv.Quote(failure.err.Error()))
+	}
+	source.WriteString(")\n\n")
+}
+
+// line adds a line to the body of the generated function.
+func (g *goGenerator) line(format string, args ...interface{}) {
+	g.lines = append(g.lines, strings.Repeat("\t", g.indentation+1)+fmt.Sprintf(format, args...))
+}
+
+// open adds a line opening a block.
+func (g *goGenerator) open(format string, args ...interface{}) {
+	g.line(format, args...)
+	g.indentation++
+}
+
+// middle adds a line closing a block and opening the next one, e.g. "} else {".
+func (g *goGenerator) middle(format string, args ...interface{}) {
+	g.indentation--
+	g.open(format, args...)
+}
+
+func (g *goGenerator) close() {
+	g.indentation--
+	g.line("}")
+}
+
+func (g *goGenerator) temporary(prefix string) string {
+	g.temporaries++
+	return prefix + strconv.Itoa(g.temporaries)
+}
+
+// bind returns an expression for the value of result which can be used several
+// times. If variable is true, it is never a constant expression.
+func (g *goGenerator) bind(result goValue, typ string, variable bool) string {
+	if result.constant && !variable || !result.constant && isGoIdentifier(result.value) {
+		return result.value
+	}
+	name := g.temporary("v")
+	if result.constant {
+		g.line("%s := %s(%s)", name, typ, strings.TrimSuffix(strings.TrimPrefix(result.value, "("), ")"))
+	} else {
+		g.line("%s := %s", name, result.value)
+	}
+	return name
+}
+
+// errorsNewType is the type of errors created via errors.New.
+var errorsNewType = reflect.TypeOf(errors.New(""))
+
+// failure returns the package-level variable containing the error of node, a
+// failing calculation.
+func (g *goGenerator) failure(node Node) (string, error) {
+	err, _ := node.Params()[0].(error)
+	if err == nil {
+		return "nil", nil
+	}
+	if reflect.TypeOf(err) != errorsNewType {
+		return "", fmt.Errorf("cannot generate Go code for error of type %T of %s", err, g.formatter.label(node))
+	}
+	for _, failure := range g.failures {
+		if failure.err == err {
+			return failure.name, nil
+		}
+	}
+	g.imports["errors"] = true
+	name := g.failurePrefix() + strconv.Itoa(len(g.failures)+1)
+	g.failures = append(g.failures, goFailure{name: name, err: err})
+	return name, nil
+}
+
+func (g *goGenerator) generate(node Node, typ string) (goValue, error) {
+	switch node.Kind() {
+	case KindVariable:
+		return goValue{value: g.formatter.variableNames[node], err: "nil"}, nil
+	case KindFailing:
+		zero := "0"
+		if typ == "bool" {
+			zero = "false"
+		}
+		failure, err := g.failure(node)
+		if err != nil {
+			return goValue{}, err
+		}
+		return goValue{value: zero, err: failure, constant: true, failing: failure != "nil"}, nil
+	case KindConstant:
+		switch value := node.Params()[0].(type) {
+		case int64:
+			if typ == "int64" {
+				return goValue{value: formatGoInt64(value), err: "nil", constant: true}, nil
+			}
+		case bool:
+			if typ == "bool" {
+				return goValue{value: strconv.FormatBool(value), err: "nil", constant: true}, nil
+			}
+		}
+	}
+
+	if typ == "int64" {
+		if generate, ok := goInt64Generators[node.Kind()]; ok {
+			return generate(g, node, node.Children())
+		}
+	} else {
+		if generate, ok := goBoolGenerators[node.Kind()]; ok {
+			return generate(g, node, node.Children())
+		}
+	}
+	return goValue{}, fmt.Errorf("cannot generate Go code for %s", g.formatter.label(node))
+}
+
+func formatGoInt64(value int64) string {
+	if value < 0 {
+		return "(" + strconv.FormatInt(value, 10) + ")"
+	}
+	return strconv.FormatInt(value, 10)
+}
+
+type goCodeGenerator func(g *goGenerator, node Node, children []Node) (goValue, error)
+
+var goInt64Generators map[Kind]goCodeGenerator
+
+var goBoolGenerators map[Kind]goCodeGenerator
+
+func init() {
+	goInt64Generators = map[Kind]goCodeGenerator{
+		KindSum:                goArithmetic("+", "0"),
+		KindProduct:            goArithmetic("*", "1"),
+		KindDifference:         goArithmetic("-", ""),
+		KindNegation:           (*goGenerator).negation,
+		KindSignum:             (*goGenerator).signum,
+		KindConditional:        (*goGenerator).conditional,
+		KindCheckedSum:         goCheckedReduction("sum", goCheckedAdd),
+		KindCheckedProduct:     goCheckedReduction("product", goCheckedMultiply),
+		KindCheckedDifference:  (*goGenerator).checkedDifference,
+		KindCheckedNegation:    goCheckedUnary("negation", goNegate),
+		KindAbs:                goCheckedUnary("absolute value", goAbs),
+		KindQuotient:           goDivision("quotient", true, goQuotient),
+		KindRemainder:          goDivision("remainder", false, goRemainder),
+		KindEuclideanQuotient:  goDivision("euclidean quotient", true, goEuclideanQuotient),
+		KindEuclideanRemainder: goDivision("euclidean remainder", false, goEuclideanRemainder),
+		KindFlooredQuotient:    goDivision("floored quotient", true, goFlooredQuotient),
+		KindFlooredModulo:      goDivision("floored modulo", false, goFlooredModulo),
+	}
+	goBoolGenerators = map[Kind]goCodeGenerator{
+		KindNot:                 (*goGenerator).not,
+		KindAnd:                 (*goGenerator).logicalOperator,
+		KindOr:                  (*goGenerator).logicalOperator,
+		KindXor:                 (*goGenerator).logicalOperator,
+		KindImplies:             (*goGenerator).logicalOperator,
+		KindEquivalent:          (*goGenerator).logicalOperator,
+		KindEquals:              goComparison("=="),
+		KindNotEquals:           goComparison("!="),
+		KindLess:                goComparison("<"),
+		KindLessOrEqual:         goComparison("<="),
+		KindGreater:             goComparison(">"),
+		KindGreaterOrEqual:      goComparison(">="),
+		KindChainLess:           goComparison("<"),
+		KindChainLessOrEqual:    goComparison("<="),
+		KindChainGreater:        goComparison(">"),
+		KindChainGreaterOrEqual: goComparison(">="),
+		KindBetween:             goRange("<="),
+		KindInRange:             goRange("<"),
+	}
+}
+
+// operands generates all children, which are of type typ.
+func (g *goGenerator) operands(children []Node, typ string) ([]goValue, error) {
+	operands := make([]goValue, len(children))
+	for i := range children {
+		var err error
+		if operands[i], err = g.generate(children[i], typ); err != nil {
+			return nil, err
+		}
+	}
+	return operands, nil
+}
+
+// combine returns the combined error of operands, like runCalculationsInt64.
+func (g *goGenerator) combine(operands []goValue) string {
+	errs := make([]string, len(operands))
+	fallible := false
+	for i := range operands {
+		errs[i] = operands[i].err
+		fallible = fallible || errs[i] != "nil"
+	}
+	if !fallible {
+		return "nil"
+	}
+	g.imports["mmath"] = true
+	name := g.temporary("e")
+	g.line("%s := mmath.CombineErrors(%s)", name, strings.Join(errs, ", "))
+	return name
+}
+
+// result returns the result of a calculation whose value is expr unless err
+// is not nil.
+func (g *goGenerator) result(typ, expr, err string, constant bool) goValue {
+	if err == "nil" {
+		return goValue{value: expr, err: "nil", constant: constant}
+	}
+	return g.compute(typ, err, false, func(value, _ string) {
+		g.line("%s = %s", value, expr)
+	})
+}
+
+// compute declares the result of a calculation and calls body with the names
+// of its value and error if err, the error of its operands, is nil. If
+// fallible is true, body may set the error.
+func (g *goGenerator) compute(typ, err string, fallible bool, body func(value, err string)) goValue {
+	value := g.temporary("v")
+	g.line("var %s %s", value, typ)
+	switch {
+	case err != "nil":
+		g.open("if %s == nil {", err)
+		body(value, err)
+		g.close()
+	case fallible:
+		err = g.temporary("e")
+		g.line("var %s error", err)
+		body(value, err)
+	default:
+		body(value, err)
+	}
+	return goValue{value: value, err: err}
+}
+
+// failingOperand returns the first operand which always fails, if any. Calculations
+// passing on the errors of their operands fail with it, too.
+func failingOperand(operands ...goValue) (goValue, bool) {
+	for i := range operands {
+		if operands[i].failing {
+			return operands[i], true
+		}
+	}
+	return goValue{}, false
+}
+
+// nonConstant makes sure that not all operands are constant expressions, so
+// Go does not evaluate them at compile time.
+func (g *goGenerator) nonConstant(operands []goValue, typ string) {
+	for i := range operands {
+		if !operands[i].constant {
+			return
+		}
+	}
+	if len(operands) > 0 {
+		operands[0] = goValue{value: g.bind(operands[0], typ, true), err: operands[0].err}
+	}
+}
+
+func goArithmetic(operator, neutral string) goCodeGenerator {
+	return func(g *goGenerator, node Node, children []Node) (goValue, error) {
+		operands, err := g.operands(children, "int64")
+		if err != nil {
+			return goValue{}, err
+		}
+		if len(operands) == 0 {
+			return goValue{value: neutral, err: "nil", constant: true}, nil
+		}
+		g.nonConstant(operands, "int64")
+		values := make([]string, len(operands))
+		for i := range operands {
+			values[i] = operands[i].value
+		}
+		expr := "(" + strings.Join(values, " "+operator+" ") + ")"
+		return g.result("int64", expr, g.combine(operands), false), nil
+	}
+}
+
+func (g *goGenerator) negation(node Node, children []Node) (goValue, error) {
+	operand, err := g.generate(children[0], "int64")
+	if err != nil {
+		return goValue{}, err
+	}
+	if failure, ok := failingOperand(operand); ok {
+		return failure, nil
+	}
+	value := operand.value
+	if operand.constant {
+		value = g.bind(operand, "int64", true)
+	}
+	return g.result("int64", "(-"+value+")", operand.err, false), nil
+}
+
+func (g *goGenerator) signum(node Node, children []Node) (goValue, error) {
+	operand, err := g.generate(children[0], "int64")
+	if err != nil {
+		return goValue{}, err
+	}
+	if failure, ok := failingOperand(operand); ok {
+		return failure, nil
+	}
+	value := g.bind(operand, "int64", false)
+	return g.compute("int64", operand.err, false, func(result, _ string) {
+		g.open("switch {")
+		g.line("case %s > 0:", value)
+		g.line("\t%s = 1", result)
+		g.line("case %s < 0:", value)
+		g.line("\t%s = -1", result)
+		g.close()
+	}), nil
+}
+
+func (g *goGenerator) conditional(node Node, children []Node) (goValue, error) {
+	condition, err := g.generate(children[0], "bool")
+	if err != nil {
+		return goValue{}, err
+	}
+	if failure, ok := failingOperand(condition); ok {
+		return goValue{value: "0", err: failure.err, constant: true, failing: true}, nil
+	}
+
+	value := g.temporary("v")
+	g.line("var %s int64", value)
+	errName := g.temporary("e")
+	declaration := len(g.lines)
+	g.line("var %s error", errName)
+	assigned := false
+
+	if condition.err != "nil" {
+		g.open("if %s != nil {", condition.err)
+		g.line("%s = %s", errName, condition.err)
+		g.middle("} else if %s {", condition.value)
+		assigned = true
+	} else {
+		g.open("if %s {", condition.value)
+	}
+	for i, branch := range children[1:] {
+		if i == 1 {
+			g.middle("} else {")
+		}
+		result, err := g.generate(branch, "int64")
+		if err != nil {
+			return goValue{}, err
+		}
+		if result.err == "nil" {
+			g.line("%s = %s", value, result.value)
+			continue
+		}
+		g.line("%s, %s = %s, %s", value, errName, result.value, result.err)
+		assigned = true
+	}
+	g.close()
+
+	if !assigned {
+		g.removeLine(declaration)
+		errName = "nil"
+	}
+	return goValue{value: value, err: errName}, nil
+}
+
+func (g *goGenerator) removeLine(index int) {
+	g.lines = append(g.lines[:index], g.lines[index+1:]...)
+}
+
+// goCheckedReduction reduces all operands from left to right via step, which
+// generates code adding or multiplying value and operand, setting err on
+// overflow.
+func goCheckedReduction(operation string, step func(g *goGenerator, value, operand, err string)) goCodeGenerator {
+	return func(g *goGenerator, node Node, children []Node) (goValue, error) {
+		operands, err := g.operands(children, "int64")
+		if err != nil {
+			return goValue{}, err
+		}
+		if len(operands) == 0 {
+			return goValue{value: "0", err: "nil", constant: true}, nil
+		}
+		// Constant operands would turn checks into constant expressions, e.g.
+		// dividing by zero.
+		values := make([]string, len(operands))
+		for i := range operands {
+			values[i] = g.bind(operands[i], "int64", true)
+		}
+		g.imports["mmath"] = true
+		return g.compute("int64", g.combine(operands), true, func(value, err string) {
+			g.line("%s = %s", value, values[0])
+			for i := 1; i < len(values); i++ {
+				if i > 1 {
+					g.open("if %s == nil {", err)
+				}
+				step(g, value, values[i], err)
+				if i > 1 {
+					g.close()
+				}
+			}
+		}), nil
+	}
+}
+
+func goCheckedAdd(g *goGenerator, value, operand, err string) {
+	sum := g.temporary("v")
+	g.open(
+		"if %[1]s := %[2]s + %[3]s; (%[3]s > 0 && %[1]s < %[2]s) || (%[3]s < 0 && %[1]s > %[2]s) {",
+		sum, value, operand,
+	)
+	g.line("%s, %s = 0, %s", value, err, goOverflow("sum", value, operand))
+	g.middle("} else {")
+	g.line("%s = %s", value, sum)
+	g.close()
+}
+
+func goCheckedMultiply(g *goGenerator, value, operand, err string) {
+	g.imports["math"] = true
+	g.open("if %s == 0 || %s == 0 {", value, operand)
+	g.line("%s = 0", value)
+	g.middle(
+		"} else if (%[1]s == -1 && %[2]s == math.MinInt64) || (%[2]s == -1 && %[1]s == math.MinInt64) || %[1]s*%[2]s/%[2]s != %[1]s {",
+		value, operand,
+	)
+	g.line("%s, %s = 0, %s", value, err, goOverflow("product", value, operand))
+	g.middle("} else {")
+	g.line("%s *= %s", value, operand)
+	g.close()
+}
+
+func (g *goGenerator) checkedDifference(node Node, children []Node) (goValue, error) {
+	operands, err := g.operands(children, "int64")
+	if err != nil {
+		return goValue{}, err
+	}
+	minuend := g.bind(operands[0], "int64", true)
+	subtrahend := g.bind(operands[1], "int64", false)
+	g.imports["mmath"] = true
+	return g.compute("int64", g.combine(operands), true, func(value, errName string) {
+		difference := g.temporary("v")
+		g.open(
+			"if %[1]s := %[2]s - %[3]s; (%[3]s > 0 && %[1]s > %[2]s) || (%[3]s < 0 && %[1]s < %[2]s) {",
+			difference, minuend, subtrahend,
+		)
+		g.line("%s = %s", errName, goOverflow("difference", minuend, subtrahend))
+		g.middle("} else {")
+		g.line("%s = %s", value, difference)
+		g.close()
+	}), nil
+}
+
+// goCheckedUnary fails on math.MinInt64 and else calculates the result via
+// calculate, which gets the names of the result and the operand.
+func goCheckedUnary(operation string, calculate func(g *goGenerator, result, value string)) goCodeGenerator {
+	return func(g *goGenerator, node Node, children []Node) (goValue, error) {
+		operand, err := g.generate(children[0], "int64")
+		if err != nil {
+			return goValue{}, err
+		}
+		if failure, ok := failingOperand(operand); ok {
+			return failure, nil
+		}
+		value := g.bind(operand, "int64", true)
+		g.imports["math"] = true
+		g.imports["mmath"] = true
+		return g.compute("int64", operand.err, true, func(result, errName string) {
+			g.open("if %s == math.MinInt64 {", value)
+			g.line("%s = %s", errName, goOverflow(operation, value))
+			g.middle("} else {")
+			calculate(g, result, value)
+			g.close()
+		}), nil
+	}
+}
+
+func goNegate(g *goGenerator, result, value string) {
+	g.line("%s = -%s", result, value)
+}
+
+func goAbs(g *goGenerator, result, value string) {
+	g.line("%s = %s", result, value)
+	g.open("if %s < 0 {", value)
+	g.line("%s = -%s", result, value)
+	g.close()
+}
+
+// goDivision divides after checking for errors like checkDivisionInt64 if
+// overflows are possible, else only for a zero divisor. divide gets the names
+// of the result, the dividend and the divisor.
+func goDivision(
+	operation string,
+	overflows bool,
+	divide func(g *goGenerator, result, dividend, divisor string),
+) goCodeGenerator {
+	return func(g *goGenerator, node Node, children []Node) (goValue, error) {
+		operands, err := g.operands(children, "int64")
+		if err != nil {
+			return goValue{}, err
+		}
+		dividend := g.bind(operands[0], "int64", true)
+		divisor := g.bind(operands[1], "int64", true)
+		g.imports["mmath"] = true
+		return g.compute("int64", g.combine(operands), true, func(value, errName string) {
+			g.open("if %s == 0 {", divisor)
+			g.line(
+				"%s = &mmath.DivisionByZeroError{Operation: %s, Dividend: %s}",
+				errName, strconv.Quote(operation), dividend,
+			)
+			if overflows {
+				g.imports["math"] = true
+				g.middle("} else if %s == math.MinInt64 && %s == -1 {", dividend, divisor)
+				g.line("%s = %s", errName, goOverflow(operation, dividend, divisor))
+			}
+			g.middle("} else {")
+			divide(g, value, dividend, divisor)
+			g.close()
+		}), nil
+	}
+}
+
+func goQuotient(g *goGenerator, result, dividend, divisor string) {
+	g.line("%s = %s / %s", result, dividend, divisor)
+}
+
+func goRemainder(g *goGenerator, result, dividend, divisor string) {
+	g.line("%s = %s %% %s", result, dividend, divisor)
+}
+
+// goEuclideanQuotient works like divideEuclideanInt64.
+func goEuclideanQuotient(g *goGenerator, result, dividend, divisor string) {
+	g.line("%s = %s / %s", result, dividend, divisor)
+	g.open("if %s%%%s < 0 {", dividend, divisor)
+	g.open("if %s > 0 {", divisor)
+	g.line("%s--", result)
+	g.middle("} else {")
+	g.line("%s++", result)
+	g.close()
+	g.close()
+}
+
+func goEuclideanRemainder(g *goGenerator, result, dividend, divisor string) {
+	g.line("%s = %s %% %s", result, dividend, divisor)
+	g.open("if %s < 0 {", result)
+	g.open("if %s > 0 {", divisor)
+	g.line("%s += %s", result, divisor)
+	g.middle("} else {")
+	g.line("%s -= %s", result, divisor)
+	g.close()
+	g.close()
+}
+
+// goFlooredQuotient works like divideFlooredInt64.
+func goFlooredQuotient(g *goGenerator, result, dividend, divisor string) {
+	remainder := g.temporary("v")
+	g.line("%s = %s / %s", result, dividend, divisor)
+	g.open(
+		"if %[1]s := %[2]s %% %[3]s; %[1]s != 0 && (%[1]s < 0) != (%[3]s < 0) {",
+		remainder, dividend, divisor,
+	)
+	g.line("%s--", result)
+	g.close()
+}
+
+func goFlooredModulo(g *goGenerator, result, dividend, divisor string) {
+	g.line("%s = %s %% %s", result, dividend, divisor)
+	g.open("if %[1]s != 0 && (%[1]s < 0) != (%[2]s < 0) {", result, divisor)
+	g.line("%s += %s", result, divisor)
+	g.close()
+}
+
+func goOverflow(operation string, operands ...string) string {
+	return fmt.Sprintf(
+		"&mmath.OverflowError{Operation: %s, Operands: []int64{%s}}",
+		strconv.Quote(operation),
+		strings.Join(operands, ", "),
+	)
+}
+
+func (g *goGenerator) not(node Node, children []Node) (goValue, error) {
+	operand, err := g.generate(children[0], "bool")
+	if err != nil {
+		return goValue{}, err
+	}
+	if failure, ok := failingOperand(operand); ok {
+		return failure, nil
+	}
+	return g.result("bool", "!"+operand.value, operand.err, operand.constant), nil
+}
+
+// goComparison compares all neighbouring operands via operator.
+func goComparison(operator string) goCodeGenerator {
+	return func(g *goGenerator, node Node, children []Node) (goValue, error) {
+		operands, err := g.operands(children, "int64")
+		if err != nil {
+			return goValue{}, err
+		}
+		values := make([]string, len(operands))
+		for i := range operands {
+			values[i] = g.bind(operands[i], "int64", false)
+		}
+		comparisons := []string{}
+		for i := 1; i < len(values); i++ {
+			comparisons = append(comparisons, values[i-1]+" "+operator+" "+values[i])
+		}
+		expr := "true"
+		if len(comparisons) > 0 {
+			expr = "(" + strings.Join(comparisons, " && ") + ")"
+		}
+		return g.result("bool", expr, g.combine(operands), false), nil
+	}
+}
+
+// goRange checks wether the first operand lies between the second and the
+// third one, using operator for the upper bound.
+func goRange(operator string) goCodeGenerator {
+	return func(g *goGenerator, node Node, children []Node) (goValue, error) {
+		operands, err := g.operands(children, "int64")
+		if err != nil {
+			return goValue{}, err
+		}
+		value := g.bind(operands[0], "int64", false)
+		expr := fmt.Sprintf("(%s <= %s && %s %s %s)", operands[1].value, value, value, operator, operands[2].value)
+		return g.result("bool", expr, g.combine(operands), false), nil
+	}
+}
+
+func (g *goGenerator) logicalOperator(node Node, children []Node) (goValue, error) {
+	if params := node.Params(); len(params) == 1 && params[0] == Strict {
+		operands, err := g.operands(children, "bool")
+		if err != nil {
+			return goValue{}, err
+		}
+		values := make([]string, len(operands))
+		for i := range operands {
+			values[i] = g.bind(operands[i], "bool", false)
+		}
+		return g.result("bool", goCombineLogical(node.Kind(), values), g.combine(operands), false), nil
+	}
+	return g.shortCircuit(node, children)
+}
+
+// shortCircuit generates a logical operator using ShortCircuit. Every operand
+// is calculated in a block only entered if the operands before it did not
+// fail and did not decide the result.
+func (g *goGenerator) shortCircuit(node Node, children []Node) (goValue, error) {
+	value := g.temporary("v")
+	g.line("var %s bool", value)
+	errName := g.temporary("e")
+	declaration := len(g.lines)
+	g.line("var %s error", errName)
+	assigned := false
+
+	values := []string{}
+	blocks := 0
+	for i := range children {
+		operand, err := g.generate(children[i], "bool")
+		if err != nil {
+			return goValue{}, err
+		}
+		values = append(values, g.bind(operand, "bool", false))
+
+		opened := false
+		if operand.err != "nil" {
+			g.open("if %s != nil {", operand.err)
+			g.line("%s = %s", errName, operand.err)
+			opened, assigned = true, true
+		}
+		if condition, result, ok := goDecideLogical(node.Kind(), values, len(children)); ok {
+			if opened {
+				g.middle("} else if %s {", condition)
+			} else {
+				g.open("if %s {", condition)
+			}
+			g.line("%s = %t", value, result)
+			opened = true
+		}
+		if opened {
+			g.middle("} else {")
+			blocks++
+		}
+	}
+	g.line("%s = %s", value, goCombineLogical(node.Kind(), values))
+	for ; blocks > 0; blocks-- {
+		g.close()
+	}
+
+	if !assigned {
+		g.removeLine(declaration)
+		errName = "nil"
+	}
+	return goValue{value: value, err: errName}, nil
+}
+
+// goDecideLogical returns the condition under which the operands so far
+// decide the result of a logical operator with n operands, like the decide
+// functions in bool_logic.go.
+func goDecideLogical(kind Kind, values []string, n int) (string, bool, bool) {
+	last := values[len(values)-1]
+	switch kind {
+	case KindAnd:
+		return "!" + last, false, true
+	case KindOr:
+		return last, true, true
+	case KindImplies:
+		if len(values) < n {
+			return "!" + last, true, true
+		}
+	case KindEquivalent:
+		if len(values) > 1 {
+			return last + " != " + values[0], false, true
+		}
+	}
+	return "", false, false
+}
+
+// goCombineLogical returns an expression combining all operands of a logical
+// operator.
+func goCombineLogical(kind Kind, values []string) string {
+	if len(values) == 0 {
+		return strconv.FormatBool(kind == KindAnd || kind == KindImplies || kind == KindEquivalent)
+	}
+	switch kind {
+	case KindAnd:
+		return "(" + strings.Join(values, " && ") + ")"
+	case KindOr:
+		return "(" + strings.Join(values, " || ") + ")"
+	case KindXor:
+		return "(" + strings.Join(values, " != ") + ")"
+	case KindImplies:
+		terms := []string{}
+		for _, premise := range values[:len(values)-1] {
+			terms = append(terms, "!"+premise)
+		}
+		return "(" + strings.Join(append(terms, values[len(values)-1]), " || ") + ")"
+	case KindEquivalent:
+		terms := []string{}
+		for _, value := range values[1:] {
+			terms = append(terms, values[0]+" == "+value)
+		}
+		if len(terms) == 0 {
+			return "true"
+		}
+		return "(" + strings.Join(terms, " && ") + ")"
+	}
+	return ""
+}
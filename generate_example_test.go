@@ -0,0 +1,47 @@
+package mmath_test
+
+import (
+	"github.com/GodsBoss/mmath"
+
+	"fmt"
+)
+
+func ExampleGenerateGoInt64() {
+	price := mmath.NewNamedVariableInt64("price")
+	quantity := mmath.NewNamedVariableInt64("quantity")
+
+	total := mmath.NewCheckedProductInt64(price, quantity)
+
+	source, err := mmath.GenerateGoInt64("pricing", "Total", total)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Print(string(source))
+
+	// Output:
+	// // Code generated by mmath.GenerateGoInt64. DO NOT EDIT.
+	//
+	// package pricing
+	//
+	// import (
+	// 	"math"
+	//
+	// 	"github.com/GodsBoss/mmath"
+	// )
+	//
+	// // Total calculates checkedProduct(price, quantity).
+	// func Total(price int64, quantity int64) (int64, error) {
+	// 	var v1 int64
+	// 	var e2 error
+	// 	v1 = price
+	// 	if v1 == 0 || quantity == 0 {
+	// 		v1 = 0
+	// 	} else if (v1 == -1 && quantity == math.MinInt64) || (quantity == -1 && v1 == math.MinInt64) || v1*quantity/quantity != v1 {
+	// 		v1, e2 = 0, &mmath.OverflowError{Operation: "product", Operands: []int64{v1, quantity}}
+	// 	} else {
+	// 		v1 *= quantity
+	// 	}
+	// 	return v1, e2
+	// }
+}
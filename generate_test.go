@@ -0,0 +1,111 @@
+package mmath_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+)
+
+// customError is an error which cannot be generated.
+type customError struct{}
+
+func (customError) Error() string {
+	return "custom"
+}
+
+func TestGenerateGoInt64RejectsUnsupportedCalculations(t *testing.T) {
+	double := mmath.NewCreateBinaryInt64(func(left, right int64) int64 {
+		return 2 * (left + right)
+	})
+
+	testcases := map[string]struct {
+		pkg         string
+		name        string
+		calculation mmath.CalculationInt64
+	}{
+		"function": {
+			pkg:         "rules",
+			name:        "Total",
+			calculation: mmath.NewSumInt64(double(mmath.NewConstantInt64(1), mmath.NewConstantInt64(2))),
+		},
+		"no node": {
+			pkg:  "rules",
+			name: "Total",
+			calculation: mmath.CalculationInt64Func(func() (int64, error) {
+				return 1, nil
+			}),
+		},
+		"custom error": {
+			pkg:         "rules",
+			name:        "Total",
+			calculation: mmath.NewFailingCalculation(customError{}),
+		},
+		"wrapped error": {
+			pkg:         "rules",
+			name:        "Total",
+			calculation: mmath.NewFailingCalculation(fmt.Errorf("no price: %w", errors.New("offline"))),
+		},
+		"invalid package": {
+			pkg:         "1rules",
+			name:        "Total",
+			calculation: mmath.NewConstantInt64(1),
+		},
+		"keyword as name": {
+			pkg:         "rules",
+			name:        "func",
+			calculation: mmath.NewConstantInt64(1),
+		},
+		"invalid variable name": {
+			pkg:         "rules",
+			name:        "Total",
+			calculation: mmath.NewNamedVariableInt64("unit price"),
+		},
+		"predeclared variable name": {
+			pkg:         "rules",
+			name:        "Total",
+			calculation: mmath.NewNamedVariableInt64("int64"),
+		},
+		"variable named like import": {
+			pkg:         "rules",
+			name:        "Total",
+			calculation: mmath.NewNamedVariableInt64("math"),
+		},
+		"variable named like temporary": {
+			pkg:         "rules",
+			name:        "Total",
+			calculation: mmath.NewNamedVariableInt64("v1"),
+		},
+	}
+
+	for name := range testcases {
+		testcase := testcases[name]
+		t.Run(name, func(t *testing.T) {
+			source, err := mmath.GenerateGoInt64(testcase.pkg, testcase.name, testcase.calculation)
+
+			if err == nil {
+				t.Errorf("expected an error, got source\n%s", source)
+			}
+		})
+	}
+}
+
+func TestGenerateGoBoolNamesUnnamedVariablesLikeFormatFormula(t *testing.T) {
+	calculation := mmath.NewAnd(
+		mmath.ShortCircuit,
+		mmath.NewVariableBool(),
+		mmath.NewNamedVariableBool("var1"),
+	)
+
+	source, err := mmath.GenerateGoBool("rules", "Check", calculation)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %+v", err)
+	}
+	expected := "func Check(var2 bool, var1 bool) (bool, error) {"
+	if !strings.Contains(string(source), expected) {
+		t.Errorf("expected source to contain %q, got\n%s", expected, source)
+	}
+}
@@ -0,0 +1,204 @@
+package mmath
+
+import (
+	"sync"
+)
+
+// Calculation represents a calculation that returns a value of type T. It is
+// the generic counterpart of the type-specific interfaces like
+// CalculationInt64. Use the adapters like FromInt64 and ToInt64 to convert
+// between them.
+type Calculation[T any] interface {
+	// Calculate returns the value calculated by this calculator.
+	Calculate() (T, error)
+}
+
+// Func implements Calculation by wrapping a function.
+type Func[T any] func() (T, error)
+
+// Calculate calls f and returns its result.
+func (f Func[T]) Calculate() (T, error) {
+	return f()
+}
+
+// Constant returns a calculation which always returns the same value and no
+// error.
+func Constant[T any](c T) Func[T] {
+	return func() (T, error) {
+		return c, nil
+	}
+}
+
+// Failing returns a calculation which always returns err. It is the generic
+// counterpart of FailingCalculation.
+func Failing[T any](err error) Func[T] {
+	return func() (T, error) {
+		var zero T
+		return zero, err
+	}
+}
+
+// NewVariable creates a variable. In calculations, it returns the value it was
+// set to, initially the zero value of T. Calculating the result of a variable
+// never fails. The variable is safe for concurrent use, but values of T are
+// not copied, so e.g. the elements of a slice must not be modified while
+// other goroutines use the variable.
+func NewVariable[T any]() Variable[T] {
+	return &variable[T]{}
+}
+
+// Variable represents a variable value, which can be set from the outside.
+type Variable[T any] interface {
+	Calculation[T]
+
+	// Set sets the variable. Afterwards, calling Calculate() will return value.
+	Set(value T)
+}
+
+type variable[T any] struct {
+	mutex sync.RWMutex
+	value T
+}
+
+func (v *variable[T]) Calculate() (T, error) {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+	return v.value, nil
+}
+
+func (v *variable[T]) Set(value T) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.value = value
+}
+
+// Conditional returns a calculation which returns the result of ifTrue or
+// ifFalse, depending on wether condition returns true or false. If condition
+// returns an error, that error is returned instead.
+func Conditional[T any](condition Calculation[bool], ifTrue, ifFalse Calculation[T]) Calculation[T] {
+	return conditional[T]{
+		condition: condition,
+		ifTrue:    ifTrue,
+		ifFalse:   ifFalse,
+	}
+}
+
+type conditional[T any] struct {
+	condition Calculation[bool]
+	ifTrue    Calculation[T]
+	ifFalse   Calculation[T]
+}
+
+func (cond conditional[T]) Calculate() (T, error) {
+	b, err := cond.condition.Calculate()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	if b {
+		return cond.ifTrue.Calculate()
+	}
+	return cond.ifFalse.Calculate()
+}
+
+// ReduceLeft works like NewReduceLeft, but for any type.
+func ReduceLeft[T any](
+	reduce func(current T, next T) (T, error),
+	initialValue Calculation[T],
+	calculations []Calculation[T],
+) Calculation[T] {
+	return reduceLeftGeneric[T]{
+		reduce:       reduce,
+		initialValue: initialValue,
+		calculations: calculations,
+	}
+}
+
+type reduceLeftGeneric[T any] struct {
+	reduce       func(current T, next T) (T, error)
+	initialValue Calculation[T]
+	calculations []Calculation[T]
+}
+
+func (rl reduceLeftGeneric[T]) Calculate() (T, error) {
+	var zero T
+
+	initialValue, err := rl.initialValue.Calculate()
+	if err != nil {
+		return zero, err
+	}
+
+	values, err := runCalculations(rl.calculations...)
+	if err != nil {
+		return zero, err
+	}
+
+	result := initialValue
+	for i := range values {
+		var err error
+		result, err = rl.reduce(result, values[i])
+		if err != nil {
+			return zero, err
+		}
+	}
+
+	return result, nil
+}
+
+// Map returns a calculation which applies f to the result of calculation. If
+// calculation fails, its error is returned, else the result of f.
+func Map[T, U any](calculation Calculation[T], f func(value T) (U, error)) Func[U] {
+	return func() (U, error) {
+		v, err := calculation.Calculate()
+		if err != nil {
+			var zero U
+			return zero, err
+		}
+		return f(v)
+	}
+}
+
+// Zip returns a calculation which combines the results of two calculations via
+// f. If one or both fail, an error combining those errors is returned, else the
+// result of f.
+func Zip[T, U, V any](first Calculation[T], second Calculation[U], f func(first T, second U) (V, error)) Func[V] {
+	return func() (V, error) {
+		var errs Errors
+
+		firstValue, err := first.Calculate()
+		if err != nil {
+			errs.add(0, err)
+		}
+
+		secondValue, err := second.Calculate()
+		if err != nil {
+			errs.add(1, err)
+		}
+
+		if len(errs) > 0 {
+			var zero V
+			return zero, errs
+		}
+
+		return f(firstValue, secondValue)
+	}
+}
+
+func runCalculations[T any](calculations ...Calculation[T]) ([]T, error) {
+	var errs Errors
+	results := make([]T, len(calculations))
+
+	for i := range calculations {
+		result, err := calculations[i].Calculate()
+		results[i] = result
+		if err != nil {
+			errs.add(i, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return results, nil
+}
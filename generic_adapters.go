@@ -0,0 +1,210 @@
+package mmath
+
+import (
+	"math/big"
+)
+
+// The adapters in this file convert between the type-specific calculation
+// interfaces and the generic Calculation. Converting back and forth returns
+// the original calculation instead of wrapping it twice.
+
+// FromInt64 converts a CalculationInt64 to a Calculation[int64].
+func FromInt64(calculation CalculationInt64) Calculation[int64] {
+	if adapter, ok := calculation.(int64FromGeneric); ok {
+		return adapter.calculation
+	}
+	return int64ToGeneric{calculation: calculation}
+}
+
+// ToInt64 converts a Calculation[int64] to a CalculationInt64.
+func ToInt64(calculation Calculation[int64]) CalculationInt64 {
+	if adapter, ok := calculation.(int64ToGeneric); ok {
+		return adapter.calculation
+	}
+	return int64FromGeneric{calculation: calculation}
+}
+
+type int64ToGeneric struct {
+	calculation CalculationInt64
+}
+
+func (adapter int64ToGeneric) Calculate() (int64, error) {
+	return adapter.calculation.CalculateInt64()
+}
+
+type int64FromGeneric struct {
+	calculation Calculation[int64]
+}
+
+func (adapter int64FromGeneric) CalculateInt64() (int64, error) {
+	return adapter.calculation.Calculate()
+}
+
+// FromBool converts a CalculationBool to a Calculation[bool].
+func FromBool(calculation CalculationBool) Calculation[bool] {
+	if adapter, ok := calculation.(boolFromGeneric); ok {
+		return adapter.calculation
+	}
+	return boolToGeneric{calculation: calculation}
+}
+
+// ToBool converts a Calculation[bool] to a CalculationBool.
+func ToBool(calculation Calculation[bool]) CalculationBool {
+	if adapter, ok := calculation.(boolToGeneric); ok {
+		return adapter.calculation
+	}
+	return boolFromGeneric{calculation: calculation}
+}
+
+type boolToGeneric struct {
+	calculation CalculationBool
+}
+
+func (adapter boolToGeneric) Calculate() (bool, error) {
+	return adapter.calculation.CalculateBool()
+}
+
+type boolFromGeneric struct {
+	calculation Calculation[bool]
+}
+
+func (adapter boolFromGeneric) CalculateBool() (bool, error) {
+	return adapter.calculation.Calculate()
+}
+
+// FromFloat64 converts a CalculationFloat64 to a Calculation[float64].
+func FromFloat64(calculation CalculationFloat64) Calculation[float64] {
+	if adapter, ok := calculation.(float64FromGeneric); ok {
+		return adapter.calculation
+	}
+	return float64ToGeneric{calculation: calculation}
+}
+
+// ToFloat64 converts a Calculation[float64] to a CalculationFloat64.
+func ToFloat64(calculation Calculation[float64]) CalculationFloat64 {
+	if adapter, ok := calculation.(float64ToGeneric); ok {
+		return adapter.calculation
+	}
+	return float64FromGeneric{calculation: calculation}
+}
+
+type float64ToGeneric struct {
+	calculation CalculationFloat64
+}
+
+func (adapter float64ToGeneric) Calculate() (float64, error) {
+	return adapter.calculation.CalculateFloat64()
+}
+
+type float64FromGeneric struct {
+	calculation Calculation[float64]
+}
+
+func (adapter float64FromGeneric) CalculateFloat64() (float64, error) {
+	return adapter.calculation.Calculate()
+}
+
+// FromBigInt converts a CalculationBigInt to a Calculation[*big.Int].
+func FromBigInt(calculation CalculationBigInt) Calculation[*big.Int] {
+	if adapter, ok := calculation.(bigIntFromGeneric); ok {
+		return adapter.calculation
+	}
+	return bigIntToGeneric{calculation: calculation}
+}
+
+// ToBigInt converts a Calculation[*big.Int] to a CalculationBigInt.
+func ToBigInt(calculation Calculation[*big.Int]) CalculationBigInt {
+	if adapter, ok := calculation.(bigIntToGeneric); ok {
+		return adapter.calculation
+	}
+	return bigIntFromGeneric{calculation: calculation}
+}
+
+type bigIntToGeneric struct {
+	calculation CalculationBigInt
+}
+
+func (adapter bigIntToGeneric) Calculate() (*big.Int, error) {
+	return adapter.calculation.CalculateBigInt()
+}
+
+type bigIntFromGeneric struct {
+	calculation Calculation[*big.Int]
+}
+
+func (adapter bigIntFromGeneric) CalculateBigInt() (*big.Int, error) {
+	return adapter.calculation.Calculate()
+}
+
+// FromRat converts a CalculationRat to a Calculation[*big.Rat].
+func FromRat(calculation CalculationRat) Calculation[*big.Rat] {
+	if adapter, ok := calculation.(ratFromGeneric); ok {
+		return adapter.calculation
+	}
+	return ratToGeneric{calculation: calculation}
+}
+
+// ToRat converts a Calculation[*big.Rat] to a CalculationRat.
+func ToRat(calculation Calculation[*big.Rat]) CalculationRat {
+	if adapter, ok := calculation.(ratToGeneric); ok {
+		return adapter.calculation
+	}
+	return ratFromGeneric{calculation: calculation}
+}
+
+type ratToGeneric struct {
+	calculation CalculationRat
+}
+
+func (adapter ratToGeneric) Calculate() (*big.Rat, error) {
+	return adapter.calculation.CalculateRat()
+}
+
+type ratFromGeneric struct {
+	calculation Calculation[*big.Rat]
+}
+
+func (adapter ratFromGeneric) CalculateRat() (*big.Rat, error) {
+	return adapter.calculation.Calculate()
+}
+
+// FromDecimal converts a CalculationDecimal to a Calculation[Decimal].
+func FromDecimal(calculation CalculationDecimal) Calculation[Decimal] {
+	if adapter, ok := calculation.(decimalFromGeneric); ok {
+		return adapter.calculation
+	}
+	return decimalToGeneric{calculation: calculation}
+}
+
+// ToDecimal converts a Calculation[Decimal] to a CalculationDecimal.
+func ToDecimal(calculation Calculation[Decimal]) CalculationDecimal {
+	if adapter, ok := calculation.(decimalToGeneric); ok {
+		return adapter.calculation
+	}
+	return decimalFromGeneric{calculation: calculation}
+}
+
+type decimalToGeneric struct {
+	calculation CalculationDecimal
+}
+
+func (adapter decimalToGeneric) Calculate() (Decimal, error) {
+	return adapter.calculation.CalculateDecimal()
+}
+
+type decimalFromGeneric struct {
+	calculation Calculation[Decimal]
+}
+
+func (adapter decimalFromGeneric) CalculateDecimal() (Decimal, error) {
+	return adapter.calculation.Calculate()
+}
+
+// fromSlice converts a slice of type-specific calculations to generic ones.
+func fromSlice[C any, T any](calculations []C, from func(C) Calculation[T]) []Calculation[T] {
+	result := make([]Calculation[T], len(calculations))
+	for i := range calculations {
+		result[i] = from(calculations[i])
+	}
+	return result
+}
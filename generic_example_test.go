@@ -0,0 +1,53 @@
+package mmath_test
+
+import (
+	"github.com/GodsBoss/mmath"
+
+	"fmt"
+	"strings"
+)
+
+func ExampleZip() {
+	name := mmath.NewVariable[string]()
+	count := mmath.NewVariableInt64()
+
+	greeting := mmath.Zip(
+		mmath.Calculation[string](name),
+		mmath.FromInt64(count),
+		func(name string, count int64) (string, error) {
+			return "Hello, " + name + strings.Repeat("!", int(count)), nil
+		},
+	)
+
+	name.Set("World")
+	count.Set(3)
+
+	s, err := greeting.Calculate()
+
+	fmt.Println(s)
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+	}
+
+	// Output:
+	// Hello, World!!!
+}
+
+func ExampleToInt64() {
+	doubled := mmath.Map(
+		mmath.FromInt64(mmath.NewConstantInt64(21)),
+		func(i int64) (int64, error) {
+			return i * 2, nil
+		},
+	)
+
+	v, err := mmath.NewSumInt64(mmath.ToInt64(doubled), mmath.NewConstantInt64(1)).CalculateInt64()
+
+	fmt.Printf("Value is %d.\n", v)
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+	}
+
+	// Output:
+	// Value is 43.
+}
@@ -0,0 +1,166 @@
+package mmath_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+)
+
+func TestGeneric(t *testing.T) {
+	t.Parallel()
+
+	variable := mmath.NewVariable[string]()
+	variable.Set("foo")
+
+	testcases := map[string]testcaseGeneric[string]{
+		"constant": {
+			calculation:   mmath.Constant("hello"),
+			expectedValue: "hello",
+		},
+		"failing": {
+			calculation:       mmath.Failing[string](fmt.Errorf("failure")),
+			expectedErrorFunc: errorContainsString("failure"),
+		},
+		"variable": {
+			calculation:   variable,
+			expectedValue: "foo",
+		},
+		"conditional/true": {
+			calculation:   mmath.Conditional[string](mmath.Constant(true), mmath.Constant("yes"), mmath.Constant("no")),
+			expectedValue: "yes",
+		},
+		"conditional/false": {
+			calculation:   mmath.Conditional[string](mmath.Constant(false), mmath.Constant("yes"), mmath.Constant("no")),
+			expectedValue: "no",
+		},
+		"conditional/error": {
+			calculation:       mmath.Conditional[string](mmath.Failing[bool](fmt.Errorf("hello")), mmath.Constant("yes"), mmath.Constant("no")),
+			expectedErrorFunc: errorContainsString("hello"),
+		},
+		"reduceLeft/value": {
+			calculation: mmath.ReduceLeft(
+				func(current, next string) (string, error) {
+					return current + next, nil
+				},
+				mmath.Calculation[string](mmath.Constant(">")),
+				[]mmath.Calculation[string]{mmath.Constant("a"), mmath.Constant("b")},
+			),
+			expectedValue: ">ab",
+		},
+		"reduceLeft/errors": {
+			calculation: mmath.ReduceLeft(
+				func(current, next string) (string, error) {
+					return current + next, nil
+				},
+				mmath.Calculation[string](mmath.Constant(">")),
+				[]mmath.Calculation[string]{mmath.Failing[string](fmt.Errorf("nobody")), mmath.Failing[string](fmt.Errorf("knows"))},
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("nobody"),
+				errorContainsString("knows"),
+			),
+		},
+		"map/value": {
+			calculation: mmath.Map[int64](mmath.FromInt64(mmath.NewConstantInt64(42)), func(i int64) (string, error) {
+				return strconv.FormatInt(i, 10), nil
+			}),
+			expectedValue: "42",
+		},
+		"map/error": {
+			calculation: mmath.Map[int64](mmath.Failing[int64](fmt.Errorf("no int")), func(i int64) (string, error) {
+				return "", nil
+			}),
+			expectedErrorFunc: errorContainsString("no int"),
+		},
+		"zip/value": {
+			calculation: mmath.Zip[string, int64](mmath.Constant("x"), mmath.Constant[int64](3), func(s string, n int64) (string, error) {
+				return fmt.Sprintf("%s%d", s, n), nil
+			}),
+			expectedValue: "x3",
+		},
+		"zip/errors": {
+			calculation: mmath.Zip[string, int64](mmath.Failing[string](fmt.Errorf("first")), mmath.Failing[int64](fmt.Errorf("second")), func(s string, n int64) (string, error) {
+				return "", nil
+			}),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("first"),
+				errorContainsString("second"),
+			),
+		},
+	}
+
+	runTestcasesGeneric(t, testcases)
+}
+
+func TestGenericAdapters(t *testing.T) {
+	t.Parallel()
+
+	variableInt64 := mmath.NewVariableInt64()
+	if mmath.ToInt64(mmath.FromInt64(variableInt64)) != variableInt64 {
+		t.Errorf("expected round trip to return the original calculation")
+	}
+
+	variableBool := mmath.NewVariable[bool]()
+	if mmath.FromBool(mmath.ToBool(variableBool)) != variableBool {
+		t.Errorf("expected round trip to return the original calculation")
+	}
+
+	sum := mmath.NewSumInt64(mmath.NewConstantInt64(1), mmath.NewConstantInt64(2))
+	value, err := mmath.ToInt64(mmath.Map(mmath.FromInt64(sum), func(i int64) (int64, error) {
+		return i * 10, nil
+	})).CalculateInt64()
+	if value != 30 || err != nil {
+		t.Errorf("expected 30 and no error, got %d and %+v", value, err)
+	}
+}
+
+func runTestcasesGeneric[T comparable](t *testing.T, testcases map[string]testcaseGeneric[T]) {
+	for name := range testcases {
+		testcase := testcases[name]
+
+		t.Run(
+			name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				actualValue, actualErr := testcase.calculation.Calculate()
+
+				if actualValue != testcase.expectedValue {
+					t.Errorf(
+						"expected calculation result value to be %v, but got %v",
+						testcase.expectedValue,
+						actualValue,
+					)
+				}
+
+				if testcase.expectedErrorFunc == nil && actualErr != nil {
+					t.Errorf("expected no error, but got %+v", actualErr)
+				}
+
+				if testcase.expectedErrorFunc != nil {
+					if actualErr != nil {
+						testcase.expectedErrorFunc(t, actualErr)
+					}
+					if actualErr == nil {
+						t.Errorf("expected non-nil error")
+					}
+				}
+			},
+		)
+	}
+}
+
+type testcaseGeneric[T comparable] struct {
+	// calculation is the calculation executed by the test. It's result is
+	// compared against the expected values.
+	calculation mmath.Calculation[T]
+
+	// expectedValue is the value the calculation should return.
+	expectedValue T
+
+	// expectedErrorFunc checks the error. This being nil is equivalent to
+	// checking wether the error should be nil.
+	expectedErrorFunc errorTest
+}
@@ -1,3 +1,3 @@
 module github.com/GodsBoss/mmath
 
-go 1.15
+go 1.18
@@ -1,5 +1,10 @@
 package mmath
 
+import (
+	"fmt"
+	"sync"
+)
+
 // CalculationInt64 represents a calculation that returns an int64.
 type CalculationInt64 interface {
 	// CalculateInt64 returns the int64 value calculated by this calculator.
@@ -16,18 +21,40 @@ func (f CalculationInt64Func) CalculateInt64() (int64, error) {
 
 // NewConstantInt64 returns a calculation which always returns the same value and
 // no error.
-func NewConstantInt64(c int64) CalculationInt64Func {
-	return func() (int64, error) {
-		return c, nil
-	}
+func NewConstantInt64(c int64) CalculationInt64 {
+	return newInt64Node(
+		KindConstant,
+		nil,
+		[]interface{}{c},
+		nil,
+		func() (int64, error) {
+			return c, nil
+		},
+	)
 }
 
 // NewVariableInt64 creates a variable. In calculations, it returns the value
-// it was set to. Calculating the result of a variable never fails.
+// it was set to. Calculating the result of a variable never fails. The
+// variable is safe for concurrent use.
 func NewVariableInt64() VariableInt64 {
 	return &variableInt64{}
 }
 
+// NewObservableVariableInt64 works like NewVariableInt64, but returns the
+// variable as ObservableVariableInt64, e.g. for subscribing to it or setting it
+// via Batch.
+func NewObservableVariableInt64() ObservableVariableInt64 {
+	return &variableInt64{}
+}
+
+// NewNamedVariableInt64 works like NewObservableVariableInt64, but the variable
+// has a name, which is used when formatting calculations containing it.
+func NewNamedVariableInt64(name string) ObservableVariableInt64 {
+	return &variableInt64{
+		name: name,
+	}
+}
+
 // VariableInt64 represents a variable value, which can be set from the outside.
 type VariableInt64 interface {
 	CalculationInt64
@@ -36,53 +63,180 @@ type VariableInt64 interface {
 	Set(i int64)
 }
 
+// ObservableVariableInt64 is a variable notifying subscribers about changes of
+// its value.
+type ObservableVariableInt64 interface {
+	VariableInt64
+
+	// Subscribe registers f, which is called with the new value every time the
+	// value of the variable changes, either via Set or via Batch. Setting the
+	// variable to its current value does not call f. Calling unsubscribe
+	// unregisters f.
+	Subscribe(f func(value int64)) (unsubscribe func())
+}
+
 type variableInt64 struct {
-	value int64
+	mutex         sync.RWMutex
+	value         int64
+	name          string
+	version       uint64
+	subscriptions subscriptions
 }
 
 func (v *variableInt64) CalculateInt64() (int64, error) {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
 	return v.value, nil
 }
 
 func (v *variableInt64) Set(i int64) {
+	if v.set(i) {
+		notify(v.subscriptions.list())
+	}
+}
+
+// set sets the variable without notifying subscribers and returns wether the
+// value changed.
+func (v *variableInt64) set(i int64) bool {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	changed := v.value != i
 	v.value = i
+	v.version++
+	return changed
+}
+
+func (v *variableInt64) Subscribe(f func(value int64)) func() {
+	return v.subscriptions.add(
+		nil,
+		func() {
+			value, _ := v.CalculateInt64()
+			f(value)
+		},
+	)
+}
+
+func (v *variableInt64) subscribe(owner interface{}, notify func()) func() {
+	return v.subscriptions.add(owner, notify)
+}
+
+func (v *variableInt64) isVolatile() bool {
+	return false
+}
+
+func (v *variableInt64) snapshot() func() bool {
+	old, _ := v.CalculateInt64()
+	return func() bool {
+		current, _ := v.CalculateInt64()
+		return current != old
+	}
+}
+
+func (v *variableInt64) notifications() []*subscription {
+	return v.subscriptions.list()
+}
+
+func (v *variableInt64) currentVersion() uint64 {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+	return v.version
+}
+
+func (v *variableInt64) Kind() Kind {
+	return KindVariable
+}
+
+func (v *variableInt64) Children() []Node {
+	return nil
+}
+
+func (v *variableInt64) Params() []interface{} {
+	return []interface{}{v.name}
+}
+
+func (v *variableInt64) WithChildren(children ...Node) (Node, error) {
+	return withoutChildren(v, children)
+}
+
+// String returns the name of the variable, see FormatFormula.
+func (v *variableInt64) String() string {
+	return FormatFormula(v)
 }
 
 // NewSumInt64 returns a calculation which returns the sum of all calculations
 // passed to it. If one or more calculations fail, an error wrapping all those
 // individual errors is returned.
 func NewSumInt64(calculations ...CalculationInt64) CalculationInt64 {
-	return NewReduceLeft(
+	rl := newReduceLeft(
 		func(left, right int64) (int64, error) {
 			return left + right, nil
 		},
 		NewConstantInt64(0),
 		calculations,
 	)
+	return newInt64Node(
+		KindSum,
+		int64Children(calculations...),
+		nil,
+		rebuildNary(KindSum, NewSumInt64),
+		rl.CalculateInt64,
+	).withOperation(rl)
 }
 
 // NewProductInt64 returns a calculation which returns the product of all
 // calculations passed to it. If one or more calculations fail, an error
 // wrapping all those individual errors is returned.
 func NewProductInt64(calculations ...CalculationInt64) CalculationInt64 {
-	return NewReduceLeft(
+	rl := newReduceLeft(
 		func(left, right int64) (int64, error) {
 			return left * right, nil
 		},
 		NewConstantInt64(1),
 		calculations,
 	)
+	return newInt64Node(
+		KindProduct,
+		int64Children(calculations...),
+		nil,
+		rebuildNary(KindProduct, NewProductInt64),
+		rl.CalculateInt64,
+	).withOperation(rl)
+}
+
+// NewDifferenceInt64 returns a calculation which subtracts the result of
+// subtrahend from the result of minuend. Like NewSumInt64, it wraps around on
+// overflow. If one or both fail, an error combining those errors is returned.
+func NewDifferenceInt64(minuend, subtrahend CalculationInt64) CalculationInt64 {
+	return createFallibleBinaryInt64(
+		KindDifference,
+		func(left, right int64) (int64, error) {
+			return left - right, nil
+		},
+	)(minuend, subtrahend)
+}
+
+// NewNegationInt64 returns a calculation which negates the result of another
+// calculation. Like NewSumInt64, it wraps around on overflow, so the negation
+// of math.MinInt64 is math.MinInt64. If that calculation fails, that error is
+// returned.
+func NewNegationInt64(calculation CalculationInt64) CalculationInt64 {
+	return createFallibleUnaryInt64(
+		KindNegation,
+		func(value int64) (int64, error) {
+			return -value, nil
+		},
+	)(calculation)
 }
 
 func runCalculationsInt64(calculations ...CalculationInt64) ([]int64, error) {
-	var errs errors
+	var errs Errors
 	results := make([]int64, len(calculations))
 
 	for i := range calculations {
 		result, err := calculations[i].CalculateInt64()
 		results[i] = result
 		if err != nil {
-			errs = append(errs, err)
+			errs.add(i, err)
 		}
 	}
 
@@ -97,11 +251,30 @@ func runCalculationsInt64(calculations ...CalculationInt64) ([]int64, error) {
 // or ifFalse, depending on wether boolCalc returns true or false. If boolCalc
 // returns an error, that error is returned instead.
 func NewConditionalInt64(boolCalc CalculationBool, ifTrue, ifFalse CalculationInt64) CalculationInt64 {
-	return conditionalInt64{
-		boolCalc: boolCalc,
-		ifTrue:   ifTrue,
-		ifFalse:  ifFalse,
+	return newInt64Node(
+		KindConditional,
+		[]interface{}{boolCalc, ifTrue, ifFalse},
+		nil,
+		rebuildConditionalInt64,
+		conditionalInt64{
+			boolCalc: boolCalc,
+			ifTrue:   ifTrue,
+			ifFalse:  ifFalse,
+		}.CalculateInt64,
+	)
+}
+
+func rebuildConditionalInt64(children []interface{}) (interface{}, error) {
+	if len(children) != 3 {
+		return nil, fmt.Errorf("%s expects 3 children, got %d", KindConditional, len(children))
 	}
+	boolCalc, ok := children[0].(CalculationBool)
+	if !ok {
+		return nil, fmt.Errorf("child 0 of %s has invalid type %T", KindConditional, children[0])
+	}
+	return rebuildBinary(KindConditional, func(ifTrue, ifFalse CalculationInt64) CalculationInt64 {
+		return NewConditionalInt64(boolCalc, ifTrue, ifFalse)
+	})(children[1:])
 }
 
 type conditionalInt64 struct {
@@ -125,47 +298,113 @@ func (cond conditionalInt64) CalculateInt64() (int64, error) {
 // returns a calculation constructor representing the same calculation.
 func NewCreateBinaryInt64(
 	f func(left, right int64) int64,
-) func(left, right CalculationInt64) CalculationInt64Func {
-	return func(left, right CalculationInt64) CalculationInt64Func {
-		return func() (int64, error) {
-			var errs errors
+) func(left, right CalculationInt64) CalculationInt64 {
+	return NewCreateFallibleBinaryInt64(
+		func(left, right int64) (int64, error) {
+			return f(left, right), nil
+		},
+	)
+}
+
+// NewCreateFallibleBinaryInt64 works like NewCreateBinaryInt64, but wraps a
+// function which may fail. If both operands could be calculated, but f fails,
+// the error returned by f is returned.
+func NewCreateFallibleBinaryInt64(
+	f func(left, right int64) (int64, error),
+) func(left, right CalculationInt64) CalculationInt64 {
+	return createFallibleBinaryInt64(KindFunction, f)
+}
+
+func createFallibleBinaryInt64(
+	kind Kind,
+	f func(left, right int64) (int64, error),
+) func(left, right CalculationInt64) CalculationInt64 {
+	var create func(left, right CalculationInt64) CalculationInt64
+	create = func(left, right CalculationInt64) CalculationInt64 {
+		return newInt64Node(kind, int64Children(left, right), nil, rebuildBinary(kind, create), func() (int64, error) {
+			var errs Errors
 
 			leftValue, err := left.CalculateInt64()
 			if err != nil {
-				errs = append(errs, err)
+				errs.add(0, err)
 			}
 
 			rightValue, err := right.CalculateInt64()
 			if err != nil {
-				errs = append(errs, err)
+				errs.add(1, err)
 			}
 
 			if len(errs) > 0 {
 				return 0, errs
 			}
 
-			return f(leftValue, rightValue), nil
-		}
+			return f(leftValue, rightValue)
+		}).withOperation(f)
 	}
+	return create
 }
 
-// NewSignumInt64 returns a calculation which returns the signum of another
-// calculation. If that other calculation fails, that error is returned instead.
-func NewSignumInt64(calculation CalculationInt64) CalculationInt64Func {
-	return func() (int64, error) {
-		v, err := calculation.CalculateInt64()
-		if err != nil {
-			return 0, err
-		}
+// NewCreateFallibleUnaryInt64 wraps a unary function int64 -> (int64, error) and
+// returns a calculation constructor representing the same calculation. If the
+// operand fails, that error is returned, else the result of f.
+func NewCreateFallibleUnaryInt64(
+	f func(value int64) (int64, error),
+) func(calculation CalculationInt64) CalculationInt64 {
+	return createFallibleUnaryInt64(KindFunction, f)
+}
 
-		if v > 0 {
-			return 1, nil
-		}
-		if v < 0 {
-			return -1, nil
-		}
-		return 0, nil
+func createFallibleUnaryInt64(
+	kind Kind,
+	f func(value int64) (int64, error),
+) func(calculation CalculationInt64) CalculationInt64 {
+	var create func(calculation CalculationInt64) CalculationInt64
+	create = func(calculation CalculationInt64) CalculationInt64 {
+		return newInt64Node(kind, int64Children(calculation), nil, rebuildUnary(kind, create), func() (int64, error) {
+			v, err := calculation.CalculateInt64()
+			if err != nil {
+				return 0, err
+			}
+			return f(v)
+		}).withOperation(f)
 	}
+	return create
+}
+
+// NewCreateFallibleNaryInt64 wraps a function taking any number of int64 values
+// and returns a calculation constructor representing the same calculation. If
+// one or more operands fail, an error combining those errors is returned, else
+// the result of f.
+func NewCreateFallibleNaryInt64(
+	f func(values []int64) (int64, error),
+) func(calculations ...CalculationInt64) CalculationInt64 {
+	var create func(calculations ...CalculationInt64) CalculationInt64
+	create = func(calculations ...CalculationInt64) CalculationInt64 {
+		return newInt64Node(KindFunction, int64Children(calculations...), nil, rebuildNary(KindFunction, create), func() (int64, error) {
+			values, err := runCalculationsInt64(calculations...)
+			if err != nil {
+				return 0, err
+			}
+			return f(values)
+		}).withOperation(f)
+	}
+	return create
+}
+
+// NewSignumInt64 returns a calculation which returns the signum of another
+// calculation. If that other calculation fails, that error is returned instead.
+func NewSignumInt64(calculation CalculationInt64) CalculationInt64 {
+	return createFallibleUnaryInt64(
+		KindSignum,
+		func(v int64) (int64, error) {
+			if v > 0 {
+				return 1, nil
+			}
+			if v < 0 {
+				return -1, nil
+			}
+			return 0, nil
+		},
+	)(calculation)
 }
 
 // NewReduceLeft takes a function which reduces two values to one (or an error),
@@ -181,6 +420,33 @@ func NewReduceLeft(
 	initialValue CalculationInt64,
 	calculations []CalculationInt64,
 ) CalculationInt64 {
+	rl := reduceLeft{
+		reduce:       reduce,
+		initialValue: initialValue,
+		calculations: calculations,
+		firstIndex:   1,
+	}
+	return newInt64Node(
+		KindReduceLeft,
+		int64Children(append([]CalculationInt64{initialValue}, calculations...)...),
+		nil,
+		func(children []interface{}) (interface{}, error) {
+			if len(children) == 0 {
+				return nil, fmt.Errorf("%s expects at least 1 child, got 0", KindReduceLeft)
+			}
+			return rebuildOperands(KindReduceLeft, -1, func(operands []CalculationInt64) interface{} {
+				return NewReduceLeft(reduce, operands[0], operands[1:])
+			})(children)
+		},
+		rl.CalculateInt64,
+	).withOperation(rl)
+}
+
+func newReduceLeft(
+	reduce func(current int64, next int64) (int64, error),
+	initialValue CalculationInt64,
+	calculations []CalculationInt64,
+) reduceLeft {
 	return reduceLeft{
 		reduce:       reduce,
 		initialValue: initialValue,
@@ -192,6 +458,10 @@ type reduceLeft struct {
 	reduce       func(current int64, next int64) (int64, error)
 	initialValue CalculationInt64
 	calculations []CalculationInt64
+
+	// firstIndex is the position of the first calculation among the children
+	// of the node, used for errors.
+	firstIndex int
 }
 
 func (rl reduceLeft) CalculateInt64() (int64, error) {
@@ -202,9 +472,14 @@ func (rl reduceLeft) CalculateInt64() (int64, error) {
 
 	values, err := runCalculationsInt64(rl.calculations...)
 	if err != nil {
-		return 0, err
+		return 0, err.(Errors).shift(rl.firstIndex)
 	}
 
+	return rl.fold(initialValue, values)
+}
+
+// fold reduces values, starting with initialValue.
+func (rl reduceLeft) fold(initialValue int64, values []int64) (int64, error) {
 	result := initialValue
 	for i := range values {
 		var err error
@@ -213,6 +488,5 @@ func (rl reduceLeft) CalculateInt64() (int64, error) {
 			return 0, err
 		}
 	}
-
 	return result, nil
 }
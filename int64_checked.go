@@ -0,0 +1,101 @@
+package mmath
+
+import (
+	"math"
+)
+
+// NewCheckedSumInt64 works like NewSumInt64, but instead of wrapping around
+// on overflow, an *OverflowError is returned.
+func NewCheckedSumInt64(calculations ...CalculationInt64) CalculationInt64 {
+	rl := newReduceLeft(addInt64Checked, NewConstantInt64(0), calculations)
+	return newInt64Node(
+		KindCheckedSum,
+		int64Children(calculations...),
+		nil,
+		rebuildNary(KindCheckedSum, NewCheckedSumInt64),
+		rl.CalculateInt64,
+	).withOperation(rl)
+}
+
+// NewCheckedProductInt64 works like NewProductInt64, but instead of wrapping
+// around on overflow, an *OverflowError is returned.
+func NewCheckedProductInt64(calculations ...CalculationInt64) CalculationInt64 {
+	rl := newReduceLeft(multiplyInt64Checked, NewConstantInt64(1), calculations)
+	return newInt64Node(
+		KindCheckedProduct,
+		int64Children(calculations...),
+		nil,
+		rebuildNary(KindCheckedProduct, NewCheckedProductInt64),
+		rl.CalculateInt64,
+	).withOperation(rl)
+}
+
+// NewCheckedDifferenceInt64 returns a calculation which subtracts the result of
+// subtrahend from the result of minuend. If one or both fail, an error combining
+// those errors is returned. If the difference overflows, an *OverflowError is
+// returned.
+func NewCheckedDifferenceInt64(minuend, subtrahend CalculationInt64) CalculationInt64 {
+	return createFallibleBinaryInt64(KindCheckedDifference, subtractInt64Checked)(minuend, subtrahend)
+}
+
+// NewCheckedNegationInt64 returns a calculation which negates the result of
+// another calculation. If that calculation fails, that error is returned. As
+// math.MinInt64 cannot be negated, an *OverflowError is returned for it.
+func NewCheckedNegationInt64(calculation CalculationInt64) CalculationInt64 {
+	return createFallibleUnaryInt64(KindCheckedNegation, negateInt64Checked)(calculation)
+}
+
+// NewCheckedAbsInt64 returns a calculation which returns the absolute value of
+// another calculation. If that calculation fails, that error is returned. As
+// the absolute value of math.MinInt64 is not representable, an *OverflowError
+// is returned for it.
+func NewCheckedAbsInt64(calculation CalculationInt64) CalculationInt64 {
+	return createFallibleUnaryInt64(KindAbs, absInt64Checked)(calculation)
+}
+
+func addInt64Checked(left, right int64) (int64, error) {
+	result := left + right
+	if (right > 0 && result < left) || (right < 0 && result > left) {
+		return 0, newOverflowError("sum", left, right)
+	}
+	return result, nil
+}
+
+func subtractInt64Checked(left, right int64) (int64, error) {
+	result := left - right
+	if (right > 0 && result > left) || (right < 0 && result < left) {
+		return 0, newOverflowError("difference", left, right)
+	}
+	return result, nil
+}
+
+func multiplyInt64Checked(left, right int64) (int64, error) {
+	if left == 0 || right == 0 {
+		return 0, nil
+	}
+	if (left == -1 && right == math.MinInt64) || (right == -1 && left == math.MinInt64) {
+		return 0, newOverflowError("product", left, right)
+	}
+	result := left * right
+	if result/right != left {
+		return 0, newOverflowError("product", left, right)
+	}
+	return result, nil
+}
+
+func absInt64Checked(v int64) (int64, error) {
+	if v == math.MinInt64 {
+		return 0, newOverflowError("absolute value", v)
+	}
+	if v < 0 {
+		return -v, nil
+	}
+	return v, nil
+}
+
+func negateInt64Checked(v int64) (int64, error) {
+	if v == math.MinInt64 {
+		return 0, newOverflowError("negation", v)
+	}
+	return -v, nil
+}
@@ -0,0 +1,33 @@
+package mmath_test
+
+import (
+	"github.com/GodsBoss/mmath"
+
+	"errors"
+	"fmt"
+	"math"
+)
+
+func ExampleNewCheckedSumInt64() {
+	sum := mmath.NewCheckedSumInt64(
+		mmath.NewConstantInt64(math.MaxInt64),
+		mmath.NewConstantInt64(1),
+	)
+
+	v, err := sum.CalculateInt64()
+
+	fmt.Printf("Value is %d.\n", v)
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+	}
+
+	var overflowErr *mmath.OverflowError
+	if errors.As(err, &overflowErr) {
+		fmt.Printf("Operation was %s.\n", overflowErr.Operation)
+	}
+
+	// Output:
+	// Value is 0.
+	// Error is: int64 overflow in sum of 9223372036854775807, 1
+	// Operation was sum.
+}
@@ -0,0 +1,160 @@
+package mmath_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+)
+
+func TestCheckedInt64(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]testcaseInt64{
+		"sum/no_summands": {
+			calculation:   mmath.NewCheckedSumInt64(),
+			expectedValue: 0,
+		},
+		"sum/some_numbers": {
+			calculation: mmath.NewCheckedSumInt64(
+				mmath.NewConstantInt64(10),
+				mmath.NewConstantInt64(-5),
+				mmath.NewConstantInt64(18),
+			),
+			expectedValue: 23,
+		},
+		"sum/overflow": {
+			calculation: mmath.NewCheckedSumInt64(
+				mmath.NewConstantInt64(math.MaxInt64),
+				mmath.NewConstantInt64(1),
+			),
+			expectedErrorFunc: errorAnd(
+				errorIsOverflow("sum"),
+				errorContainsString("9223372036854775807, 1"),
+			),
+		},
+		"sum/underflow": {
+			calculation: mmath.NewCheckedSumInt64(
+				mmath.NewConstantInt64(math.MinInt64),
+				mmath.NewConstantInt64(-1),
+			),
+			expectedErrorFunc: errorIsOverflow("sum"),
+		},
+		"sum/errors": {
+			calculation: mmath.NewCheckedSumInt64(
+				mmath.NewFailingCalculation(fmt.Errorf("foobar")),
+				mmath.NewFailingCalculation(fmt.Errorf("xyz")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("foobar"),
+				errorContainsString("xyz"),
+			),
+		},
+		"product/no_factors": {
+			calculation:   mmath.NewCheckedProductInt64(),
+			expectedValue: 1,
+		},
+		"product/some_numbers": {
+			calculation: mmath.NewCheckedProductInt64(
+				mmath.NewConstantInt64(3),
+				mmath.NewConstantInt64(-2),
+				mmath.NewConstantInt64(-7),
+			),
+			expectedValue: 42,
+		},
+		"product/zero": {
+			calculation: mmath.NewCheckedProductInt64(
+				mmath.NewConstantInt64(math.MinInt64),
+				mmath.NewConstantInt64(0),
+			),
+			expectedValue: 0,
+		},
+		"product/overflow": {
+			calculation: mmath.NewCheckedProductInt64(
+				mmath.NewConstantInt64(math.MaxInt64/2+1),
+				mmath.NewConstantInt64(2),
+			),
+			expectedErrorFunc: errorIsOverflow("product"),
+		},
+		"product/min_times_minus_one": {
+			calculation: mmath.NewCheckedProductInt64(
+				mmath.NewConstantInt64(math.MinInt64),
+				mmath.NewConstantInt64(-1),
+			),
+			expectedErrorFunc: errorIsOverflow("product"),
+		},
+		"product/minus_one_times_min": {
+			calculation: mmath.NewCheckedProductInt64(
+				mmath.NewConstantInt64(-1),
+				mmath.NewConstantInt64(math.MinInt64),
+			),
+			expectedErrorFunc: errorIsOverflow("product"),
+		},
+		"difference/some_numbers": {
+			calculation: mmath.NewCheckedDifferenceInt64(
+				mmath.NewConstantInt64(10),
+				mmath.NewConstantInt64(25),
+			),
+			expectedValue: -15,
+		},
+		"difference/overflow": {
+			calculation: mmath.NewCheckedDifferenceInt64(
+				mmath.NewConstantInt64(math.MaxInt64),
+				mmath.NewConstantInt64(-1),
+			),
+			expectedErrorFunc: errorIsOverflow("difference"),
+		},
+		"difference/underflow": {
+			calculation: mmath.NewCheckedDifferenceInt64(
+				mmath.NewConstantInt64(math.MinInt64),
+				mmath.NewConstantInt64(1),
+			),
+			expectedErrorFunc: errorIsOverflow("difference"),
+		},
+		"difference/errors": {
+			calculation: mmath.NewCheckedDifferenceInt64(
+				mmath.NewFailingCalculation(fmt.Errorf("left")),
+				mmath.NewFailingCalculation(fmt.Errorf("right")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("left"),
+				errorContainsString("right"),
+			),
+		},
+		"negation/positive": {
+			calculation:   mmath.NewCheckedNegationInt64(mmath.NewConstantInt64(5)),
+			expectedValue: -5,
+		},
+		"negation/max": {
+			calculation:   mmath.NewCheckedNegationInt64(mmath.NewConstantInt64(math.MaxInt64)),
+			expectedValue: -math.MaxInt64,
+		},
+		"negation/overflow": {
+			calculation:       mmath.NewCheckedNegationInt64(mmath.NewConstantInt64(math.MinInt64)),
+			expectedErrorFunc: errorIsOverflow("negation"),
+		},
+		"negation/error": {
+			calculation:       mmath.NewCheckedNegationInt64(mmath.NewFailingCalculation(fmt.Errorf("nope"))),
+			expectedErrorFunc: errorContainsString("nope"),
+		},
+		"abs/negative": {
+			calculation:   mmath.NewCheckedAbsInt64(mmath.NewConstantInt64(-12)),
+			expectedValue: 12,
+		},
+		"abs/positive": {
+			calculation:   mmath.NewCheckedAbsInt64(mmath.NewConstantInt64(12)),
+			expectedValue: 12,
+		},
+		"abs/overflow": {
+			calculation:       mmath.NewCheckedAbsInt64(mmath.NewConstantInt64(math.MinInt64)),
+			expectedErrorFunc: errorIsOverflow("absolute value"),
+		},
+		"abs/error": {
+			calculation:       mmath.NewCheckedAbsInt64(mmath.NewFailingCalculation(fmt.Errorf("nope"))),
+			expectedErrorFunc: errorContainsString("nope"),
+		},
+	}
+
+	runTestcasesInt64(t, testcases)
+}
@@ -0,0 +1,185 @@
+package mmath
+
+// NewInt64NotEquals returns wether the results of the first and second
+// calculations differ. Errors are handled like in NewInt64Equals.
+func NewInt64NotEquals(first, second CalculationInt64) CalculationBool {
+	return newInt64Comparison(KindNotEquals, notEqualsInt64)(first, second)
+}
+
+// NewInt64Less returns wether the result of the first calculation is less than
+// the result of the second calculation. Errors are handled like in
+// NewInt64Equals.
+func NewInt64Less(first, second CalculationInt64) CalculationBool {
+	return newInt64Comparison(KindLess, lessInt64)(first, second)
+}
+
+// NewInt64LessOrEqual returns wether the result of the first calculation is
+// less than or equal to the result of the second calculation. Errors are
+// handled like in NewInt64Equals.
+func NewInt64LessOrEqual(first, second CalculationInt64) CalculationBool {
+	return newInt64Comparison(KindLessOrEqual, lessOrEqualInt64)(first, second)
+}
+
+// NewInt64Greater returns wether the result of the first calculation is greater
+// than the result of the second calculation. Errors are handled like in
+// NewInt64Equals.
+func NewInt64Greater(first, second CalculationInt64) CalculationBool {
+	return newInt64Comparison(KindGreater, greaterInt64)(first, second)
+}
+
+// NewInt64GreaterOrEqual returns wether the result of the first calculation is
+// greater than or equal to the result of the second calculation. Errors are
+// handled like in NewInt64Equals.
+func NewInt64GreaterOrEqual(first, second CalculationInt64) CalculationBool {
+	return newInt64Comparison(KindGreaterOrEqual, greaterOrEqualInt64)(first, second)
+}
+
+// NewInt64ChainLess returns wether the results of the calculations are strictly
+// increasing, i.e. a < b < c for three calculations. With less than two
+// calculations, it is true. All calculations are evaluated, if one or more of
+// them fail, an error combining those errors is returned.
+func NewInt64ChainLess(calculations ...CalculationInt64) CalculationBool {
+	return newInt64ComparisonChain(KindChainLess, lessInt64)(calculations...)
+}
+
+// NewInt64ChainLessOrEqual works like NewInt64ChainLess, but checks for
+// a <= b <= c instead.
+func NewInt64ChainLessOrEqual(calculations ...CalculationInt64) CalculationBool {
+	return newInt64ComparisonChain(KindChainLessOrEqual, lessOrEqualInt64)(calculations...)
+}
+
+// NewInt64ChainGreater works like NewInt64ChainLess, but checks for a > b > c
+// instead.
+func NewInt64ChainGreater(calculations ...CalculationInt64) CalculationBool {
+	return newInt64ComparisonChain(KindChainGreater, greaterInt64)(calculations...)
+}
+
+// NewInt64ChainGreaterOrEqual works like NewInt64ChainLess, but checks for
+// a >= b >= c instead.
+func NewInt64ChainGreaterOrEqual(calculations ...CalculationInt64) CalculationBool {
+	return newInt64ComparisonChain(KindChainGreaterOrEqual, greaterOrEqualInt64)(calculations...)
+}
+
+// NewInt64Between returns wether the result of value lies between the results
+// of lower and upper, both inclusive. If lower is greater than upper, it is
+// always false. All calculations are evaluated in the order value, lower,
+// upper, if one or more of them fail, an error combining those errors is
+// returned.
+func NewInt64Between(value, lower, upper CalculationInt64) CalculationBool {
+	return newBoolNode(
+		KindBetween,
+		int64Children(value, lower, upper),
+		nil,
+		rebuildOperands(KindBetween, 3, func(operands []CalculationInt64) interface{} {
+			return NewInt64Between(operands[0], operands[1], operands[2])
+		}),
+		func() (bool, error) {
+			values, err := runCalculationsInt64(value, lower, upper)
+			if err != nil {
+				return false, err
+			}
+			return betweenInt64(values), nil
+		},
+	).withOperation(betweenInt64)
+}
+
+// NewInt64InRange works like NewInt64Between, but upper is exclusive, i.e. it
+// checks for lower <= value < upper.
+func NewInt64InRange(value, lower, upper CalculationInt64) CalculationBool {
+	return newBoolNode(
+		KindInRange,
+		int64Children(value, lower, upper),
+		nil,
+		rebuildOperands(KindInRange, 3, func(operands []CalculationInt64) interface{} {
+			return NewInt64InRange(operands[0], operands[1], operands[2])
+		}),
+		func() (bool, error) {
+			values, err := runCalculationsInt64(value, lower, upper)
+			if err != nil {
+				return false, err
+			}
+			return inRangeInt64(values), nil
+		},
+	).withOperation(inRangeInt64)
+}
+
+// betweenInt64 returns wether values[0] lies between values[1] and values[2],
+// both inclusive.
+func betweenInt64(values []int64) bool {
+	return values[1] <= values[0] && values[0] <= values[2]
+}
+
+// inRangeInt64 works like betweenInt64, but values[2] is exclusive.
+func inRangeInt64(values []int64) bool {
+	return values[1] <= values[0] && values[0] < values[2]
+}
+
+// newInt64Comparison creates a calculation constructor for comparing the
+// results of two int64 calculations. If one or both fail, an error combining
+// those errors is returned.
+func newInt64Comparison(
+	kind Kind,
+	compare func(first, second int64) bool,
+) func(first, second CalculationInt64) CalculationBool {
+	var create func(first, second CalculationInt64) CalculationBool
+	create = func(first, second CalculationInt64) CalculationBool {
+		return newBoolNode(kind, int64Children(first, second), nil, rebuildBinary(kind, create), func() (bool, error) {
+			values, err := runCalculationsInt64(first, second)
+			if err != nil {
+				return false, err
+			}
+			return compare(values[0], values[1]), nil
+		}).withOperation(compare)
+	}
+	return create
+}
+
+// newInt64ComparisonChain creates a calculation constructor which checks that
+// compare holds for every pair of neighbouring calculation results.
+func newInt64ComparisonChain(
+	kind Kind,
+	compare func(first, second int64) bool,
+) func(calculations ...CalculationInt64) CalculationBool {
+	var create func(calculations ...CalculationInt64) CalculationBool
+	create = func(calculations ...CalculationInt64) CalculationBool {
+		return newBoolNode(kind, int64Children(calculations...), nil, rebuildNary(kind, create), func() (bool, error) {
+			values, err := runCalculationsInt64(calculations...)
+			if err != nil {
+				return false, err
+			}
+			return holdsForNeighbours(compare, values), nil
+		}).withOperation(compare)
+	}
+	return create
+}
+
+// holdsForNeighbours returns wether compare holds for every pair of
+// neighbouring values.
+func holdsForNeighbours(compare func(first, second int64) bool, values []int64) bool {
+	for i := 1; i < len(values); i++ {
+		if !compare(values[i-1], values[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func notEqualsInt64(first, second int64) bool {
+	return first != second
+}
+
+func lessInt64(first, second int64) bool {
+	return first < second
+}
+
+func lessOrEqualInt64(first, second int64) bool {
+	return first <= second
+}
+
+func greaterInt64(first, second int64) bool {
+	return first > second
+}
+
+func greaterOrEqualInt64(first, second int64) bool {
+	return first >= second
+}
@@ -0,0 +1,202 @@
+package mmath_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+)
+
+func TestInt64ComparisonOperators(t *testing.T) {
+	t.Parallel()
+
+	operators := map[string]struct {
+		create func(first, second mmath.CalculationInt64) mmath.CalculationBool
+
+		// Expected results for comparing 1 with 2, 2 with 2 and 3 with 2.
+		less, equal, greater bool
+	}{
+		"equals":         {create: mmath.NewInt64Equals, less: false, equal: true, greater: false},
+		"notEquals":      {create: mmath.NewInt64NotEquals, less: true, equal: false, greater: true},
+		"less":           {create: mmath.NewInt64Less, less: true, equal: false, greater: false},
+		"lessOrEqual":    {create: mmath.NewInt64LessOrEqual, less: true, equal: true, greater: false},
+		"greater":        {create: mmath.NewInt64Greater, less: false, equal: false, greater: true},
+		"greaterOrEqual": {create: mmath.NewInt64GreaterOrEqual, less: false, equal: true, greater: true},
+	}
+
+	testcases := make(map[string]testcaseBool)
+	for name, operator := range operators {
+		testcases[name+"/less"] = testcaseBool{
+			calculation:   operator.create(mmath.NewConstantInt64(1), mmath.NewConstantInt64(2)),
+			expectedValue: operator.less,
+		}
+		testcases[name+"/equal"] = testcaseBool{
+			calculation:   operator.create(mmath.NewConstantInt64(2), mmath.NewConstantInt64(2)),
+			expectedValue: operator.equal,
+		}
+		testcases[name+"/greater"] = testcaseBool{
+			calculation:   operator.create(mmath.NewConstantInt64(3), mmath.NewConstantInt64(2)),
+			expectedValue: operator.greater,
+		}
+		testcases[name+"/errors"] = testcaseBool{
+			calculation: operator.create(
+				mmath.NewFailingCalculation(fmt.Errorf("broken")),
+				mmath.NewFailingCalculation(fmt.Errorf("meh")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("broken"),
+				errorContainsString("meh"),
+			),
+		}
+	}
+
+	runTestcasesBool(t, testcases)
+}
+
+func TestInt64ComparisonChains(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]testcaseBool{
+		"less/empty": {
+			calculation:   mmath.NewInt64ChainLess(),
+			expectedValue: true,
+		},
+		"less/single": {
+			calculation:   mmath.NewInt64ChainLess(int64Operands(5)...),
+			expectedValue: true,
+		},
+		"less/increasing": {
+			calculation:   mmath.NewInt64ChainLess(int64Operands(1, 2, 3)...),
+			expectedValue: true,
+		},
+		"less/equal": {
+			calculation:   mmath.NewInt64ChainLess(int64Operands(1, 2, 2)...),
+			expectedValue: false,
+		},
+		"less/notTransitive": {
+			calculation:   mmath.NewInt64ChainLess(int64Operands(1, 3, 2)...),
+			expectedValue: false,
+		},
+		"lessOrEqual/equal": {
+			calculation:   mmath.NewInt64ChainLessOrEqual(int64Operands(1, 2, 2)...),
+			expectedValue: true,
+		},
+		"lessOrEqual/decreasing": {
+			calculation:   mmath.NewInt64ChainLessOrEqual(int64Operands(2, 1)...),
+			expectedValue: false,
+		},
+		"greater/decreasing": {
+			calculation:   mmath.NewInt64ChainGreater(int64Operands(3, 2, 1)...),
+			expectedValue: true,
+		},
+		"greater/equal": {
+			calculation:   mmath.NewInt64ChainGreater(int64Operands(3, 3, 1)...),
+			expectedValue: false,
+		},
+		"greaterOrEqual/equal": {
+			calculation:   mmath.NewInt64ChainGreaterOrEqual(int64Operands(3, 3, 1)...),
+			expectedValue: true,
+		},
+		"greaterOrEqual/increasing": {
+			calculation:   mmath.NewInt64ChainGreaterOrEqual(int64Operands(3, 4)...),
+			expectedValue: false,
+		},
+		"less/errors": {
+			calculation: mmath.NewInt64ChainLess(
+				mmath.NewConstantInt64(3),
+				mmath.NewFailingCalculation(fmt.Errorf("foo")),
+				mmath.NewConstantInt64(1),
+				mmath.NewFailingCalculation(fmt.Errorf("bar")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("foo"),
+				errorContainsString("bar"),
+			),
+		},
+	}
+
+	runTestcasesBool(t, testcases)
+}
+
+func TestInt64Ranges(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]testcaseBool{
+		"between/below":  {calculation: mmath.NewInt64Between(mmath.NewConstantInt64(0), mmath.NewConstantInt64(1), mmath.NewConstantInt64(3))},
+		"between/lower":  {calculation: mmath.NewInt64Between(mmath.NewConstantInt64(1), mmath.NewConstantInt64(1), mmath.NewConstantInt64(3)), expectedValue: true},
+		"between/inside": {calculation: mmath.NewInt64Between(mmath.NewConstantInt64(2), mmath.NewConstantInt64(1), mmath.NewConstantInt64(3)), expectedValue: true},
+		"between/upper":  {calculation: mmath.NewInt64Between(mmath.NewConstantInt64(3), mmath.NewConstantInt64(1), mmath.NewConstantInt64(3)), expectedValue: true},
+		"between/above":  {calculation: mmath.NewInt64Between(mmath.NewConstantInt64(4), mmath.NewConstantInt64(1), mmath.NewConstantInt64(3))},
+		"between/empty":  {calculation: mmath.NewInt64Between(mmath.NewConstantInt64(2), mmath.NewConstantInt64(3), mmath.NewConstantInt64(1))},
+		"inRange/below":  {calculation: mmath.NewInt64InRange(mmath.NewConstantInt64(0), mmath.NewConstantInt64(1), mmath.NewConstantInt64(3))},
+		"inRange/lower":  {calculation: mmath.NewInt64InRange(mmath.NewConstantInt64(1), mmath.NewConstantInt64(1), mmath.NewConstantInt64(3)), expectedValue: true},
+		"inRange/inside": {calculation: mmath.NewInt64InRange(mmath.NewConstantInt64(2), mmath.NewConstantInt64(1), mmath.NewConstantInt64(3)), expectedValue: true},
+		"inRange/upper":  {calculation: mmath.NewInt64InRange(mmath.NewConstantInt64(3), mmath.NewConstantInt64(1), mmath.NewConstantInt64(3))},
+		"inRange/empty":  {calculation: mmath.NewInt64InRange(mmath.NewConstantInt64(1), mmath.NewConstantInt64(1), mmath.NewConstantInt64(1))},
+		"between/errors": {
+			calculation: mmath.NewInt64Between(
+				mmath.NewFailingCalculation(fmt.Errorf("value")),
+				mmath.NewFailingCalculation(fmt.Errorf("lower")),
+				mmath.NewFailingCalculation(fmt.Errorf("upper")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("value"),
+				errorContainsString("lower"),
+				errorContainsString("upper"),
+			),
+		},
+		"inRange/errors": {
+			calculation: mmath.NewInt64InRange(
+				mmath.NewFailingCalculation(fmt.Errorf("value")),
+				mmath.NewFailingCalculation(fmt.Errorf("lower")),
+				mmath.NewFailingCalculation(fmt.Errorf("upper")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("value"),
+				errorContainsString("lower"),
+				errorContainsString("upper"),
+			),
+		},
+	}
+
+	runTestcasesBool(t, testcases)
+}
+
+func TestInt64RangesEvaluateLikeEachOther(t *testing.T) {
+	t.Parallel()
+
+	constructors := map[string]func(value, lower, upper mmath.CalculationInt64) mmath.CalculationBool{
+		"between": mmath.NewInt64Between,
+		"inRange": mmath.NewInt64InRange,
+	}
+
+	errs := make(map[string]error)
+	for name, create := range constructors {
+		order := ""
+		operand := func(name string) mmath.CalculationInt64 {
+			return mmath.CalculationInt64Func(func() (int64, error) {
+				order += name
+				return 0, fmt.Errorf(name)
+			})
+		}
+
+		_, errs[name] = create(operand("v"), operand("l"), operand("u")).CalculateBool()
+
+		if order != "vlu" {
+			t.Errorf("%s: expected operands to be evaluated in order value, lower, upper, got %s", name, order)
+		}
+	}
+
+	if !reflect.DeepEqual(errs["between"], errs["inRange"]) {
+		t.Errorf("expected equal errors, got %+v and %+v", errs["between"], errs["inRange"])
+	}
+}
+
+func int64Operands(values ...int64) []mmath.CalculationInt64 {
+	calculations := make([]mmath.CalculationInt64, len(values))
+	for i := range values {
+		calculations[i] = mmath.NewConstantInt64(values[i])
+	}
+	return calculations
+}
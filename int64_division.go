@@ -0,0 +1,145 @@
+package mmath
+
+import (
+	"math"
+)
+
+// NewQuotientInt64 returns a calculation which divides the result of dividend
+// by the result of divisor, truncating towards zero like Go's / operator.
+//
+// If one or both calculations fail, an error combining those errors is
+// returned. If the divisor is zero, a *DivisionByZeroError is returned. As
+// math.MinInt64 / -1 is not representable, an *OverflowError is returned for
+// it.
+func NewQuotientInt64(dividend, divisor CalculationInt64) CalculationInt64 {
+	return createFallibleBinaryInt64(
+		KindQuotient,
+		func(left, right int64) (int64, error) {
+			if err := checkDivisionInt64("quotient", left, right); err != nil {
+				return 0, err
+			}
+			return left / right, nil
+		},
+	)(dividend, divisor)
+}
+
+// NewRemainderInt64 returns a calculation which returns the remainder of
+// dividing the result of dividend by the result of divisor, like Go's %
+// operator. The result has the sign of the dividend.
+//
+// If one or both calculations fail, an error combining those errors is
+// returned. If the divisor is zero, a *DivisionByZeroError is returned.
+func NewRemainderInt64(dividend, divisor CalculationInt64) CalculationInt64 {
+	return createFallibleBinaryInt64(
+		KindRemainder,
+		func(left, right int64) (int64, error) {
+			if right == 0 {
+				return 0, newDivisionByZeroError("remainder", left)
+			}
+			return left % right, nil
+		},
+	)(dividend, divisor)
+}
+
+// NewEuclideanQuotientInt64 returns a calculation which returns the quotient
+// of an Euclidean division, i.e. the quotient matching a remainder which is
+// never negative. Errors are handled like in NewQuotientInt64.
+func NewEuclideanQuotientInt64(dividend, divisor CalculationInt64) CalculationInt64 {
+	return createFallibleBinaryInt64(
+		KindEuclideanQuotient,
+		func(left, right int64) (int64, error) {
+			if err := checkDivisionInt64("euclidean quotient", left, right); err != nil {
+				return 0, err
+			}
+			q, _ := divideEuclideanInt64(left, right)
+			return q, nil
+		},
+	)(dividend, divisor)
+}
+
+// NewEuclideanRemainderInt64 returns a calculation which returns the remainder
+// of an Euclidean division, which is never negative. Errors are handled like
+// in NewRemainderInt64.
+func NewEuclideanRemainderInt64(dividend, divisor CalculationInt64) CalculationInt64 {
+	return createFallibleBinaryInt64(
+		KindEuclideanRemainder,
+		func(left, right int64) (int64, error) {
+			if right == 0 {
+				return 0, newDivisionByZeroError("euclidean remainder", left)
+			}
+			_, r := divideEuclideanInt64(left, right)
+			return r, nil
+		},
+	)(dividend, divisor)
+}
+
+// NewFlooredQuotientInt64 returns a calculation which divides the result of
+// dividend by the result of divisor, rounding towards negative infinity.
+// Errors are handled like in NewQuotientInt64.
+func NewFlooredQuotientInt64(dividend, divisor CalculationInt64) CalculationInt64 {
+	return createFallibleBinaryInt64(
+		KindFlooredQuotient,
+		func(left, right int64) (int64, error) {
+			if err := checkDivisionInt64("floored quotient", left, right); err != nil {
+				return 0, err
+			}
+			q, _ := divideFlooredInt64(left, right)
+			return q, nil
+		},
+	)(dividend, divisor)
+}
+
+// NewFlooredModuloInt64 returns a calculation which returns the modulo matching
+// NewFlooredQuotientInt64. The result has the sign of the divisor. Errors are
+// handled like in NewRemainderInt64.
+func NewFlooredModuloInt64(dividend, divisor CalculationInt64) CalculationInt64 {
+	return createFallibleBinaryInt64(
+		KindFlooredModulo,
+		func(left, right int64) (int64, error) {
+			if right == 0 {
+				return 0, newDivisionByZeroError("floored modulo", left)
+			}
+			_, r := divideFlooredInt64(left, right)
+			return r, nil
+		},
+	)(dividend, divisor)
+}
+
+// checkDivisionInt64 returns an error if dividend cannot be divided by divisor
+// without error.
+func checkDivisionInt64(operation string, dividend, divisor int64) error {
+	if divisor == 0 {
+		return newDivisionByZeroError(operation, dividend)
+	}
+	if dividend == math.MinInt64 && divisor == -1 {
+		return newOverflowError(operation, dividend, divisor)
+	}
+	return nil
+}
+
+// divideEuclideanInt64 divides dividend by divisor with a remainder which is
+// never negative. divisor must not be zero.
+func divideEuclideanInt64(dividend, divisor int64) (int64, int64) {
+	q, r := dividend/divisor, dividend%divisor
+	if r < 0 {
+		if divisor > 0 {
+			q--
+			r += divisor
+		} else {
+			q++
+			r -= divisor
+		}
+	}
+	return q, r
+}
+
+// divideFlooredInt64 divides dividend by divisor, rounding towards negative
+// infinity. divisor must not be zero.
+func divideFlooredInt64(dividend, divisor int64) (int64, int64) {
+	q, r := dividend/divisor, dividend%divisor
+	if r != 0 && (r < 0) != (divisor < 0) {
+		q--
+		r += divisor
+	}
+	return q, r
+}
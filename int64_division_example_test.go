@@ -0,0 +1,50 @@
+package mmath_test
+
+import (
+	"github.com/GodsBoss/mmath"
+
+	"fmt"
+)
+
+func ExampleNewQuotientInt64() {
+	divisor := mmath.NewVariableInt64()
+	quotient := mmath.NewQuotientInt64(mmath.NewConstantInt64(17), divisor)
+
+	divisor.Set(5)
+
+	v, err := quotient.CalculateInt64()
+
+	fmt.Printf("Value is %d.\n", v)
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+	}
+
+	divisor.Set(0)
+
+	v, err = quotient.CalculateInt64()
+
+	fmt.Printf("Value is %d.\n", v)
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+	}
+
+	// Output:
+	// Value is 3.
+	// Value is 0.
+	// Error is: division by zero in quotient of 17
+}
+
+func ExampleNewEuclideanRemainderInt64() {
+	v, err := mmath.NewEuclideanRemainderInt64(
+		mmath.NewConstantInt64(-7),
+		mmath.NewConstantInt64(3),
+	).CalculateInt64()
+
+	fmt.Printf("Value is %d.\n", v)
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+	}
+
+	// Output:
+	// Value is 2.
+}
@@ -0,0 +1,330 @@
+package mmath_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+)
+
+func TestDivisionInt64(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]testcaseInt64{
+		"quotient/7_by_2": {
+			calculation: mmath.NewQuotientInt64(
+				mmath.NewConstantInt64(7),
+				mmath.NewConstantInt64(2),
+			),
+			expectedValue: 3,
+		},
+		"quotient/minus_7_by_2": {
+			calculation: mmath.NewQuotientInt64(
+				mmath.NewConstantInt64(-7),
+				mmath.NewConstantInt64(2),
+			),
+			expectedValue: -3,
+		},
+		"quotient/7_by_minus_2": {
+			calculation: mmath.NewQuotientInt64(
+				mmath.NewConstantInt64(7),
+				mmath.NewConstantInt64(-2),
+			),
+			expectedValue: -3,
+		},
+		"quotient/minus_7_by_minus_2": {
+			calculation: mmath.NewQuotientInt64(
+				mmath.NewConstantInt64(-7),
+				mmath.NewConstantInt64(-2),
+			),
+			expectedValue: 3,
+		},
+		"quotient/by_zero": {
+			calculation: mmath.NewQuotientInt64(
+				mmath.NewConstantInt64(5),
+				mmath.NewConstantInt64(0),
+			),
+			expectedErrorFunc: errorIsDivisionByZero("quotient"),
+		},
+		"quotient/overflow": {
+			calculation: mmath.NewQuotientInt64(
+				mmath.NewConstantInt64(math.MinInt64),
+				mmath.NewConstantInt64(-1),
+			),
+			expectedErrorFunc: errorIsOverflow("quotient"),
+		},
+		"quotient/errors": {
+			calculation: mmath.NewQuotientInt64(
+				mmath.NewFailingCalculation(fmt.Errorf("dividend failed")),
+				mmath.NewFailingCalculation(fmt.Errorf("divisor failed")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("dividend failed"),
+				errorContainsString("divisor failed"),
+			),
+		},
+		"remainder/7_by_2": {
+			calculation: mmath.NewRemainderInt64(
+				mmath.NewConstantInt64(7),
+				mmath.NewConstantInt64(2),
+			),
+			expectedValue: 1,
+		},
+		"remainder/minus_7_by_2": {
+			calculation: mmath.NewRemainderInt64(
+				mmath.NewConstantInt64(-7),
+				mmath.NewConstantInt64(2),
+			),
+			expectedValue: -1,
+		},
+		"remainder/7_by_minus_2": {
+			calculation: mmath.NewRemainderInt64(
+				mmath.NewConstantInt64(7),
+				mmath.NewConstantInt64(-2),
+			),
+			expectedValue: 1,
+		},
+		"remainder/minus_7_by_minus_2": {
+			calculation: mmath.NewRemainderInt64(
+				mmath.NewConstantInt64(-7),
+				mmath.NewConstantInt64(-2),
+			),
+			expectedValue: -1,
+		},
+		"remainder/by_zero": {
+			calculation: mmath.NewRemainderInt64(
+				mmath.NewConstantInt64(5),
+				mmath.NewConstantInt64(0),
+			),
+			expectedErrorFunc: errorIsDivisionByZero("remainder"),
+		},
+		"remainder/min_by_minus_one": {
+			calculation: mmath.NewRemainderInt64(
+				mmath.NewConstantInt64(math.MinInt64),
+				mmath.NewConstantInt64(-1),
+			),
+			expectedValue: 0,
+		},
+		"remainder/errors": {
+			calculation: mmath.NewRemainderInt64(
+				mmath.NewFailingCalculation(fmt.Errorf("dividend failed")),
+				mmath.NewFailingCalculation(fmt.Errorf("divisor failed")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("dividend failed"),
+				errorContainsString("divisor failed"),
+			),
+		},
+		"euclidean_quotient/7_by_2": {
+			calculation: mmath.NewEuclideanQuotientInt64(
+				mmath.NewConstantInt64(7),
+				mmath.NewConstantInt64(2),
+			),
+			expectedValue: 3,
+		},
+		"euclidean_quotient/minus_7_by_2": {
+			calculation: mmath.NewEuclideanQuotientInt64(
+				mmath.NewConstantInt64(-7),
+				mmath.NewConstantInt64(2),
+			),
+			expectedValue: -4,
+		},
+		"euclidean_quotient/7_by_minus_2": {
+			calculation: mmath.NewEuclideanQuotientInt64(
+				mmath.NewConstantInt64(7),
+				mmath.NewConstantInt64(-2),
+			),
+			expectedValue: -3,
+		},
+		"euclidean_quotient/minus_7_by_minus_2": {
+			calculation: mmath.NewEuclideanQuotientInt64(
+				mmath.NewConstantInt64(-7),
+				mmath.NewConstantInt64(-2),
+			),
+			expectedValue: 4,
+		},
+		"euclidean_quotient/by_zero": {
+			calculation: mmath.NewEuclideanQuotientInt64(
+				mmath.NewConstantInt64(5),
+				mmath.NewConstantInt64(0),
+			),
+			expectedErrorFunc: errorIsDivisionByZero("euclidean quotient"),
+		},
+		"euclidean_quotient/overflow": {
+			calculation: mmath.NewEuclideanQuotientInt64(
+				mmath.NewConstantInt64(math.MinInt64),
+				mmath.NewConstantInt64(-1),
+			),
+			expectedErrorFunc: errorIsOverflow("euclidean quotient"),
+		},
+		"euclidean_quotient/errors": {
+			calculation: mmath.NewEuclideanQuotientInt64(
+				mmath.NewFailingCalculation(fmt.Errorf("dividend failed")),
+				mmath.NewFailingCalculation(fmt.Errorf("divisor failed")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("dividend failed"),
+				errorContainsString("divisor failed"),
+			),
+		},
+		"euclidean_remainder/7_by_2": {
+			calculation: mmath.NewEuclideanRemainderInt64(
+				mmath.NewConstantInt64(7),
+				mmath.NewConstantInt64(2),
+			),
+			expectedValue: 1,
+		},
+		"euclidean_remainder/minus_7_by_2": {
+			calculation: mmath.NewEuclideanRemainderInt64(
+				mmath.NewConstantInt64(-7),
+				mmath.NewConstantInt64(2),
+			),
+			expectedValue: 1,
+		},
+		"euclidean_remainder/7_by_minus_2": {
+			calculation: mmath.NewEuclideanRemainderInt64(
+				mmath.NewConstantInt64(7),
+				mmath.NewConstantInt64(-2),
+			),
+			expectedValue: 1,
+		},
+		"euclidean_remainder/minus_7_by_minus_2": {
+			calculation: mmath.NewEuclideanRemainderInt64(
+				mmath.NewConstantInt64(-7),
+				mmath.NewConstantInt64(-2),
+			),
+			expectedValue: 1,
+		},
+		"euclidean_remainder/by_zero": {
+			calculation: mmath.NewEuclideanRemainderInt64(
+				mmath.NewConstantInt64(5),
+				mmath.NewConstantInt64(0),
+			),
+			expectedErrorFunc: errorIsDivisionByZero("euclidean remainder"),
+		},
+		"euclidean_remainder/min_by_minus_one": {
+			calculation: mmath.NewEuclideanRemainderInt64(
+				mmath.NewConstantInt64(math.MinInt64),
+				mmath.NewConstantInt64(-1),
+			),
+			expectedValue: 0,
+		},
+		"euclidean_remainder/errors": {
+			calculation: mmath.NewEuclideanRemainderInt64(
+				mmath.NewFailingCalculation(fmt.Errorf("dividend failed")),
+				mmath.NewFailingCalculation(fmt.Errorf("divisor failed")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("dividend failed"),
+				errorContainsString("divisor failed"),
+			),
+		},
+		"floored_quotient/7_by_2": {
+			calculation: mmath.NewFlooredQuotientInt64(
+				mmath.NewConstantInt64(7),
+				mmath.NewConstantInt64(2),
+			),
+			expectedValue: 3,
+		},
+		"floored_quotient/minus_7_by_2": {
+			calculation: mmath.NewFlooredQuotientInt64(
+				mmath.NewConstantInt64(-7),
+				mmath.NewConstantInt64(2),
+			),
+			expectedValue: -4,
+		},
+		"floored_quotient/7_by_minus_2": {
+			calculation: mmath.NewFlooredQuotientInt64(
+				mmath.NewConstantInt64(7),
+				mmath.NewConstantInt64(-2),
+			),
+			expectedValue: -4,
+		},
+		"floored_quotient/minus_7_by_minus_2": {
+			calculation: mmath.NewFlooredQuotientInt64(
+				mmath.NewConstantInt64(-7),
+				mmath.NewConstantInt64(-2),
+			),
+			expectedValue: 3,
+		},
+		"floored_quotient/by_zero": {
+			calculation: mmath.NewFlooredQuotientInt64(
+				mmath.NewConstantInt64(5),
+				mmath.NewConstantInt64(0),
+			),
+			expectedErrorFunc: errorIsDivisionByZero("floored quotient"),
+		},
+		"floored_quotient/overflow": {
+			calculation: mmath.NewFlooredQuotientInt64(
+				mmath.NewConstantInt64(math.MinInt64),
+				mmath.NewConstantInt64(-1),
+			),
+			expectedErrorFunc: errorIsOverflow("floored quotient"),
+		},
+		"floored_quotient/errors": {
+			calculation: mmath.NewFlooredQuotientInt64(
+				mmath.NewFailingCalculation(fmt.Errorf("dividend failed")),
+				mmath.NewFailingCalculation(fmt.Errorf("divisor failed")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("dividend failed"),
+				errorContainsString("divisor failed"),
+			),
+		},
+		"floored_modulo/7_by_2": {
+			calculation: mmath.NewFlooredModuloInt64(
+				mmath.NewConstantInt64(7),
+				mmath.NewConstantInt64(2),
+			),
+			expectedValue: 1,
+		},
+		"floored_modulo/minus_7_by_2": {
+			calculation: mmath.NewFlooredModuloInt64(
+				mmath.NewConstantInt64(-7),
+				mmath.NewConstantInt64(2),
+			),
+			expectedValue: 1,
+		},
+		"floored_modulo/7_by_minus_2": {
+			calculation: mmath.NewFlooredModuloInt64(
+				mmath.NewConstantInt64(7),
+				mmath.NewConstantInt64(-2),
+			),
+			expectedValue: -1,
+		},
+		"floored_modulo/minus_7_by_minus_2": {
+			calculation: mmath.NewFlooredModuloInt64(
+				mmath.NewConstantInt64(-7),
+				mmath.NewConstantInt64(-2),
+			),
+			expectedValue: -1,
+		},
+		"floored_modulo/by_zero": {
+			calculation: mmath.NewFlooredModuloInt64(
+				mmath.NewConstantInt64(5),
+				mmath.NewConstantInt64(0),
+			),
+			expectedErrorFunc: errorIsDivisionByZero("floored modulo"),
+		},
+		"floored_modulo/min_by_minus_one": {
+			calculation: mmath.NewFlooredModuloInt64(
+				mmath.NewConstantInt64(math.MinInt64),
+				mmath.NewConstantInt64(-1),
+			),
+			expectedValue: 0,
+		},
+		"floored_modulo/errors": {
+			calculation: mmath.NewFlooredModuloInt64(
+				mmath.NewFailingCalculation(fmt.Errorf("dividend failed")),
+				mmath.NewFailingCalculation(fmt.Errorf("divisor failed")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("dividend failed"),
+				errorContainsString("divisor failed"),
+			),
+		},
+	}
+
+	runTestcasesInt64(t, testcases)
+}
@@ -173,3 +173,36 @@ func ExampleNewReduceLeft() {
 	// Output:
 	// Value is 2375.
 }
+
+func ExampleNewCreateFallibleBinaryInt64() {
+	clamp := mmath.NewCreateFallibleBinaryInt64(
+		func(value, limit int64) (int64, error) {
+			if limit < 0 {
+				return 0, fmt.Errorf("negative limit %d", limit)
+			}
+			if value > limit {
+				return limit, nil
+			}
+			return value, nil
+		},
+	)
+
+	v, err := clamp(mmath.NewConstantInt64(120), mmath.NewConstantInt64(100)).CalculateInt64()
+
+	fmt.Printf("Value is %d.\n", v)
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+	}
+
+	v, err = clamp(mmath.NewConstantInt64(120), mmath.NewConstantInt64(-1)).CalculateInt64()
+
+	fmt.Printf("Value is %d.\n", v)
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+	}
+
+	// Output:
+	// Value is 100.
+	// Value is 0.
+	// Error is: negative limit -1
+}
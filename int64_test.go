@@ -55,6 +55,31 @@ func TestInt64(t *testing.T) {
 				errorContainsString("123"),
 			),
 		},
+		"difference/value": {
+			calculation: mmath.NewDifferenceInt64(
+				mmath.NewConstantInt64(5),
+				mmath.NewConstantInt64(8),
+			),
+			expectedValue: -3,
+		},
+		"difference/errors": {
+			calculation: mmath.NewDifferenceInt64(
+				mmath.NewFailingCalculation(fmt.Errorf("left")),
+				mmath.NewFailingCalculation(fmt.Errorf("right")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("left"),
+				errorContainsString("right"),
+			),
+		},
+		"negation/value": {
+			calculation:   mmath.NewNegationInt64(mmath.NewConstantInt64(5)),
+			expectedValue: -5,
+		},
+		"negation/error": {
+			calculation:       mmath.NewNegationInt64(mmath.NewFailingCalculation(fmt.Errorf("nope"))),
+			expectedErrorFunc: errorContainsString("nope"),
+		},
 		"conditional/error": {
 			calculation: mmath.NewConditionalInt64(
 				mmath.NewFailingCalculation(fmt.Errorf("hello")),
@@ -77,6 +102,90 @@ func TestInt64(t *testing.T) {
 				errorContainsString("world"),
 			),
 		},
+		"fallibleBinary/errors": {
+			calculation: mmath.NewCreateFallibleBinaryInt64(
+				func(i, j int64) (int64, error) {
+					return i + j, nil
+				},
+			)(
+				mmath.NewFailingCalculation(fmt.Errorf("hello")),
+				mmath.NewFailingCalculation(fmt.Errorf("world")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("hello"),
+				errorContainsString("world"),
+			),
+		},
+		"fallibleBinary/failure": {
+			calculation: mmath.NewCreateFallibleBinaryInt64(
+				func(i, j int64) (int64, error) {
+					return 0, fmt.Errorf("%d and %d do not fit", i, j)
+				},
+			)(
+				mmath.NewConstantInt64(2),
+				mmath.NewConstantInt64(3),
+			),
+			expectedErrorFunc: errorContainsString("2 and 3 do not fit"),
+		},
+		"fallibleUnary/value": {
+			calculation: mmath.NewCreateFallibleUnaryInt64(
+				func(i int64) (int64, error) {
+					return i * i, nil
+				},
+			)(mmath.NewConstantInt64(-4)),
+			expectedValue: 16,
+		},
+		"fallibleUnary/error": {
+			calculation: mmath.NewCreateFallibleUnaryInt64(
+				func(i int64) (int64, error) {
+					return i, nil
+				},
+			)(mmath.NewFailingCalculation(fmt.Errorf("unary operand"))),
+			expectedErrorFunc: errorContainsString("unary operand"),
+		},
+		"fallibleUnary/failure": {
+			calculation: mmath.NewCreateFallibleUnaryInt64(
+				func(i int64) (int64, error) {
+					return 0, fmt.Errorf("invalid %d", i)
+				},
+			)(mmath.NewConstantInt64(13)),
+			expectedErrorFunc: errorContainsString("invalid 13"),
+		},
+		"fallibleNary/value": {
+			calculation: mmath.NewCreateFallibleNaryInt64(
+				func(values []int64) (int64, error) {
+					return int64(len(values)), nil
+				},
+			)(
+				mmath.NewConstantInt64(5),
+				mmath.NewConstantInt64(5),
+				mmath.NewConstantInt64(5),
+			),
+			expectedValue: 3,
+		},
+		"fallibleNary/errors": {
+			calculation: mmath.NewCreateFallibleNaryInt64(
+				func(values []int64) (int64, error) {
+					return 0, nil
+				},
+			)(
+				mmath.NewFailingCalculation(fmt.Errorf("first")),
+				mmath.NewConstantInt64(5),
+				mmath.NewFailingCalculation(fmt.Errorf("third")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("first"),
+				errorContainsString("third"),
+			),
+		},
+		"fallibleNary/failure": {
+			calculation: mmath.NewCreateFallibleNaryInt64(
+				func(values []int64) (int64, error) {
+					return 0, fmt.Errorf("not enough values")
+				},
+			)(),
+			expectedErrorFunc: errorContainsString("not enough values"),
+		},
 		"signum/error": {
 			calculation: mmath.NewSignumInt64(
 				mmath.NewFailingCalculation(fmt.Errorf("xyz")),
@@ -126,6 +235,10 @@ func TestInt64(t *testing.T) {
 		},
 	}
 
+	runTestcasesInt64(t, testcases)
+}
+
+func runTestcasesInt64(t *testing.T, testcases map[string]testcaseInt64) {
 	for name := range testcases {
 		testcaseInt64 := testcases[name]
 
@@ -0,0 +1,132 @@
+// Package generated contains functions generated via mmath.GenerateGoInt64
+// and mmath.GenerateGoBool. Its tests make sure that the generated code is up
+// to date and calculates the same results as the calculations it was
+// generated from. Run
+//
+//	go test ./internal/generated -update
+//
+// to regenerate the code after changing the generator or the calculations.
+package generated
+
+import (
+	"github.com/GodsBoss/mmath"
+
+	"errors"
+	"math"
+)
+
+// Calculation is a calculation a function of this package was generated from.
+type Calculation struct {
+	// Name is the name of the generated function.
+	Name string
+
+	// Calculation is a mmath.CalculationInt64 or a mmath.CalculationBool.
+	Calculation interface{}
+
+	// Variables contains the variables of Calculation, in order of the
+	// parameters of Function.
+	Variables []interface{}
+
+	// Function is the generated function.
+	Function interface{}
+}
+
+// Calculations returns all calculations functions were generated from.
+func Calculations() []Calculation {
+	x := mmath.NewNamedVariableInt64("x")
+	y := mmath.NewNamedVariableInt64("y")
+	p := mmath.NewNamedVariableBool("p")
+	q := mmath.NewNamedVariableBool("q")
+	unnamed := mmath.NewVariableInt64()
+	failure := mmath.NewFailingCalculation(errors.New("no price available"))
+
+	return []Calculation{
+		{
+			Name: "Price",
+			Calculation: mmath.NewConditionalInt64(
+				mmath.NewAnd(mmath.ShortCircuit, p, mmath.NewInt64Less(x, y)),
+				mmath.NewCheckedSumInt64(x, y, mmath.NewConstantInt64(3)),
+				mmath.NewSumInt64(
+					mmath.NewFlooredQuotientInt64(x, y),
+					mmath.NewCheckedAbsInt64(x),
+					mmath.NewConditionalInt64(q, mmath.NewSignumInt64(failure), mmath.NewConstantInt64(0)),
+				),
+			),
+			Variables: []interface{}{p, x, y, q},
+			Function:  Price,
+		},
+		{
+			Name: "Wrapping",
+			Calculation: mmath.NewSumInt64(
+				mmath.NewProductInt64(x, unnamed, mmath.NewConstantInt64(-3)),
+				mmath.NewDifferenceInt64(mmath.NewConstantInt64(math.MinInt64), mmath.NewConstantInt64(1)),
+				mmath.NewNegationInt64(mmath.NewConstantInt64(math.MinInt64)),
+				mmath.NewNegationInt64(mmath.NewSumInt64(x, mmath.NewConstantInt64(math.MaxInt64))),
+				mmath.NewSumInt64(),
+			),
+			Variables: []interface{}{x, unnamed},
+			Function:  Wrapping,
+		},
+		{
+			Name: "Divisions",
+			Calculation: mmath.NewSumInt64(
+				mmath.NewQuotientInt64(x, y),
+				mmath.NewRemainderInt64(x, mmath.NewConstantInt64(0)),
+				mmath.NewEuclideanQuotientInt64(x, y),
+				mmath.NewEuclideanRemainderInt64(y, x),
+				mmath.NewFlooredModuloInt64(x, mmath.NewConstantInt64(-7)),
+			),
+			Variables: []interface{}{x, y},
+			Function:  Divisions,
+		},
+		{
+			Name: "Checked",
+			Calculation: mmath.NewCheckedProductInt64(
+				mmath.NewCheckedDifferenceInt64(x, y),
+				mmath.NewCheckedNegationInt64(y),
+				mmath.NewCheckedSumInt64(),
+				mmath.NewCheckedProductInt64(mmath.NewConstantInt64(2), x),
+			),
+			Variables: []interface{}{x, y},
+			Function:  Checked,
+		},
+		{
+			Name: "Failing",
+			Calculation: mmath.NewNegationInt64(
+				mmath.NewConditionalInt64(failure, x, y),
+			),
+			Variables: []interface{}{x, y},
+			Function:  Failing,
+		},
+		{
+			Name: "Eligible",
+			Calculation: mmath.NewOr(
+				mmath.ShortCircuit,
+				mmath.NewAnd(
+					mmath.ShortCircuit,
+					mmath.NewInt64Between(x, mmath.NewConstantInt64(0), mmath.NewConstantInt64(7)),
+					mmath.NewNot(p),
+				),
+				mmath.NewImplies(mmath.ShortCircuit, p, q, mmath.NewInt64ChainLess(x, y, mmath.NewConstantInt64(100))),
+				mmath.NewEquivalent(mmath.ShortCircuit, q, mmath.NewInt64InRange(y, x, mmath.NewConstantInt64(10)), p),
+				mmath.NewXor(mmath.ShortCircuit, p, mmath.NewInt64Equals(mmath.NewCheckedNegationInt64(x), y)),
+			),
+			Variables: []interface{}{x, p, q, y},
+			Function:  Eligible,
+		},
+		{
+			Name: "Strict",
+			Calculation: mmath.NewAnd(
+				mmath.Strict,
+				mmath.NewOr(mmath.Strict, p, mmath.NewInt64NotEquals(mmath.NewQuotientInt64(x, y), y)),
+				mmath.NewXor(mmath.Strict, q, p, mmath.NewInt64GreaterOrEqual(x, y)),
+				mmath.NewImplies(mmath.Strict, q, mmath.NewInt64ChainGreater(x, y, mmath.NewConstantInt64(-5))),
+				mmath.NewEquivalent(mmath.Strict, mmath.NewInt64LessOrEqual(x, y), mmath.NewNot(failure)),
+				mmath.NewOr(mmath.ShortCircuit),
+				mmath.NewConstantBool(true),
+			),
+			Variables: []interface{}{p, x, y, q},
+			Function:  Strict,
+		},
+	}
+}
@@ -0,0 +1,133 @@
+package generated_test
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+	"github.com/GodsBoss/mmath/internal/generated"
+)
+
+var update = flag.Bool("update", false, "regenerate the generated files")
+
+func TestGeneratedFilesAreUpToDate(t *testing.T) {
+	for _, calculation := range generated.Calculations() {
+		var source []byte
+		var err error
+		switch c := calculation.Calculation.(type) {
+		case mmath.CalculationInt64:
+			source, err = mmath.GenerateGoInt64("generated", calculation.Name, c)
+		case mmath.CalculationBool:
+			source, err = mmath.GenerateGoBool("generated", calculation.Name, c)
+		}
+		if err != nil {
+			t.Errorf("%s: could not generate code: %+v", calculation.Name, err)
+			continue
+		}
+
+		filename := strings.ToLower(calculation.Name) + ".go"
+		if *update {
+			if err := os.WriteFile(filename, source, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		existing, err := os.ReadFile(filename)
+		if err != nil {
+			t.Errorf("%s: could not read %s: %+v", calculation.Name, filename, err)
+			continue
+		}
+		if !bytes.Equal(existing, source) {
+			t.Errorf("%s: %s is outdated, run go test with -update", calculation.Name, filename)
+		}
+	}
+}
+
+var (
+	int64Values = []int64{0, 1, -1, 2, 7, -8, 99, math.MaxInt64, math.MinInt64}
+	boolValues  = []bool{false, true}
+)
+
+func TestGeneratedFunctionsCalculateLikeCalculations(t *testing.T) {
+	for _, calculation := range generated.Calculations() {
+		function := reflect.ValueOf(calculation.Function)
+		if function.Type().NumIn() != len(calculation.Variables) {
+			t.Errorf("%s: expected %d parameters, got %d", calculation.Name, len(calculation.Variables), function.Type().NumIn())
+			continue
+		}
+		args := make([]reflect.Value, len(calculation.Variables))
+
+		var check func(i int)
+		check = func(i int) {
+			if i < len(calculation.Variables) {
+				switch variable := calculation.Variables[i].(type) {
+				case mmath.VariableInt64:
+					for _, value := range int64Values {
+						variable.Set(value)
+						args[i] = reflect.ValueOf(value)
+						check(i + 1)
+					}
+				case mmath.VariableBool:
+					for _, value := range boolValues {
+						variable.Set(value)
+						args[i] = reflect.ValueOf(value)
+						check(i + 1)
+					}
+				}
+				return
+			}
+
+			var expected, expectedErr interface{}
+			switch c := calculation.Calculation.(type) {
+			case mmath.CalculationInt64:
+				value, err := c.CalculateInt64()
+				expected, expectedErr = value, err
+			case mmath.CalculationBool:
+				value, err := c.CalculateBool()
+				expected, expectedErr = value, err
+			}
+			results := function.Call(args)
+			actual, actualErr := results[0].Interface(), results[1].Interface()
+
+			if actual != expected || !reflect.DeepEqual(actualErr, expectedErr) {
+				t.Errorf(
+					"%s%v: expected %v and %+v, got %v and %+v",
+					calculation.Name, arguments(args), expected, expectedErr, actual, actualErr,
+				)
+			}
+		}
+		check(0)
+	}
+}
+
+// TestOnlyErrorsOfErrorsNewAreGenerated makes sure that errors which the
+// generated code could not reproduce are rejected instead of being replaced by
+// errors.New.
+func TestOnlyErrorsOfErrorsNewAreGenerated(t *testing.T) {
+	x := mmath.NewNamedVariableInt64("x")
+	errs := map[string]error{
+		"custom":   &os.PathError{Op: "open", Path: "prices.csv", Err: os.ErrNotExist},
+		"wrapping": fmt.Errorf("no price available: %w", os.ErrNotExist),
+	}
+
+	for name, err := range errs {
+		calculation := mmath.NewSumInt64(x, mmath.NewFailingCalculation(err))
+		if source, err := mmath.GenerateGoInt64("generated", "Failing", calculation); err == nil {
+			t.Errorf("%s: expected an error, got source\n%s", name, source)
+		}
+	}
+}
+
+func arguments(args []reflect.Value) []interface{} {
+	values := make([]interface{}, len(args))
+	for i := range args {
+		values[i] = args[i].Interface()
+	}
+	return values
+}
@@ -0,0 +1,343 @@
+package mmath
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Registry encodes calculations as JSON and decodes them again. It knows how
+// to decode every kind registered, nodes of other kinds can neither be
+// encoded nor decoded.
+//
+// Every node is encoded as an object containing its kind, its params as an
+// array (if there are any) and its children (if there are any), e.g.
+//
+//	{"kind":"and","params":["strict"],"children":[...]}
+//
+// Constants and variables also contain their type, "int64" or "bool", e.g.
+//
+//	{"kind":"variable","type":"int64","params":["price"]}
+//
+// Variables are encoded by name, so unnamed variables cannot be encoded. When
+// decoding, variables are looked up by name.
+//
+// Marshalling and unmarshalling is safe for concurrent use, but registering
+// kinds is not, so register all kinds before sharing a registry.
+type Registry struct {
+	decoders map[Kind]NodeDecoder
+}
+
+// NodeDecoder creates a node from its params, which are JSON encoded like the
+// result of Node.Params, and its already decoded children. params is nil if
+// the node had no params.
+type NodeDecoder func(params json.RawMessage, children []Node) (Node, error)
+
+// NewRegistry creates a registry knowing all kinds created by this package,
+// except for KindFunction, KindReduceLeft, KindFailing and KindOpaque, as
+// those contain Go values like functions which cannot be encoded.
+func NewRegistry() *Registry {
+	registry := &Registry{
+		decoders: make(map[Kind]NodeDecoder),
+	}
+
+	zero := NewConstantInt64(0)
+	yes := NewTrue()
+	prototypes := map[Kind]interface{}{
+		KindSum:                 NewSumInt64(),
+		KindProduct:             NewProductInt64(),
+		KindDifference:          NewDifferenceInt64(zero, zero),
+		KindNegation:            NewNegationInt64(zero),
+		KindSignum:              NewSignumInt64(zero),
+		KindConditional:         NewConditionalInt64(yes, zero, zero),
+		KindCheckedSum:          NewCheckedSumInt64(),
+		KindCheckedProduct:      NewCheckedProductInt64(),
+		KindCheckedDifference:   NewCheckedDifferenceInt64(zero, zero),
+		KindCheckedNegation:     NewCheckedNegationInt64(zero),
+		KindAbs:                 NewCheckedAbsInt64(zero),
+		KindQuotient:            NewQuotientInt64(zero, zero),
+		KindRemainder:           NewRemainderInt64(zero, zero),
+		KindEuclideanQuotient:   NewEuclideanQuotientInt64(zero, zero),
+		KindEuclideanRemainder:  NewEuclideanRemainderInt64(zero, zero),
+		KindFlooredQuotient:     NewFlooredQuotientInt64(zero, zero),
+		KindFlooredModulo:       NewFlooredModuloInt64(zero, zero),
+		KindNot:                 NewNot(yes),
+		KindEquals:              NewInt64Equals(zero, zero),
+		KindNotEquals:           NewInt64NotEquals(zero, zero),
+		KindLess:                NewInt64Less(zero, zero),
+		KindLessOrEqual:         NewInt64LessOrEqual(zero, zero),
+		KindGreater:             NewInt64Greater(zero, zero),
+		KindGreaterOrEqual:      NewInt64GreaterOrEqual(zero, zero),
+		KindChainLess:           NewInt64ChainLess(),
+		KindChainLessOrEqual:    NewInt64ChainLessOrEqual(),
+		KindChainGreater:        NewInt64ChainGreater(),
+		KindChainGreaterOrEqual: NewInt64ChainGreaterOrEqual(),
+		KindBetween:             NewInt64Between(zero, zero, zero),
+		KindInRange:             NewInt64InRange(zero, zero, zero),
+	}
+	for kind := range prototypes {
+		registry.decoders[kind] = decodeLike(prototypes[kind])
+	}
+
+	logicalOperators := map[Kind]func(EvaluationStrategy, ...CalculationBool) CalculationBool{
+		KindAnd:        NewAnd,
+		KindOr:         NewOr,
+		KindXor:        NewXor,
+		KindImplies:    NewImplies,
+		KindEquivalent: NewEquivalent,
+	}
+	for kind := range logicalOperators {
+		registry.decoders[kind] = decodeLogicalOperator(logicalOperators[kind])
+	}
+
+	return registry
+}
+
+// Register registers a decoder for nodes of a kind. Afterwards, such nodes can
+// be encoded and decoded. Their params must be encodable via encoding/json.
+// Kinds cannot be registered twice.
+func (registry *Registry) Register(kind Kind, decoder NodeDecoder) error {
+	if _, ok := registry.decoders[kind]; ok || kind == KindConstant || kind == KindVariable {
+		return fmt.Errorf("kind %q is already registered", kind)
+	}
+	registry.decoders[kind] = decoder
+	return nil
+}
+
+// Marshal encodes calculation, which must be a CalculationInt64 or a
+// CalculationBool, as JSON. If the calculation contains nodes of unregistered
+// kinds, an *UnknownKindError is returned.
+func (registry *Registry) Marshal(calculation interface{}) ([]byte, error) {
+	encoded, err := registry.encode(NodeOf(calculation))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(encoded)
+}
+
+// Unmarshal decodes a calculation encoded by Marshal. Variables are looked up
+// in variables. If the data contains nodes of unregistered kinds, an
+// *UnknownKindError is returned.
+func (registry *Registry) Unmarshal(data []byte, variables Variables) (Node, error) {
+	var encoded jsonNode
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&encoded); err != nil {
+		return nil, err
+	}
+	return registry.decode(encoded, variables)
+}
+
+// UnmarshalInt64 works like Unmarshal, but the calculation must result in an
+// int64.
+func (registry *Registry) UnmarshalInt64(data []byte, variables Variables) (CalculationInt64, error) {
+	node, err := registry.Unmarshal(data, variables)
+	if err != nil {
+		return nil, err
+	}
+	calculation, ok := node.(CalculationInt64)
+	if !ok {
+		return nil, fmt.Errorf("%s does not result in an int64", node.Kind())
+	}
+	return calculation, nil
+}
+
+// UnmarshalBool works like Unmarshal, but the calculation must result in a
+// bool.
+func (registry *Registry) UnmarshalBool(data []byte, variables Variables) (CalculationBool, error) {
+	node, err := registry.Unmarshal(data, variables)
+	if err != nil {
+		return nil, err
+	}
+	calculation, ok := node.(CalculationBool)
+	if !ok {
+		return nil, fmt.Errorf("%s does not result in a bool", node.Kind())
+	}
+	return calculation, nil
+}
+
+// UnknownKindError is returned by Registry if it encounters a node of a kind
+// which was not registered.
+type UnknownKindError struct {
+	Kind Kind
+}
+
+func (err *UnknownKindError) Error() string {
+	return fmt.Sprintf("unknown kind %q", err.Kind)
+}
+
+const (
+	jsonTypeInt64 = "int64"
+	jsonTypeBool  = "bool"
+)
+
+type jsonNode struct {
+	Kind     Kind            `json:"kind"`
+	Type     string          `json:"type,omitempty"`
+	Params   json.RawMessage `json:"params,omitempty"`
+	Children []jsonNode      `json:"children,omitempty"`
+}
+
+func (registry *Registry) encode(node Node) (jsonNode, error) {
+	encoded := jsonNode{
+		Kind: node.Kind(),
+	}
+
+	switch node.Kind() {
+	case KindConstant:
+		switch node.Params()[0].(type) {
+		case int64:
+			encoded.Type = jsonTypeInt64
+		case bool:
+			encoded.Type = jsonTypeBool
+		}
+	case KindVariable:
+		if node.Params()[0] == "" {
+			return jsonNode{}, fmt.Errorf("unnamed variables cannot be encoded")
+		}
+		if _, ok := node.(CalculationInt64); ok {
+			encoded.Type = jsonTypeInt64
+		} else {
+			encoded.Type = jsonTypeBool
+		}
+	default:
+		if _, ok := registry.decoders[node.Kind()]; !ok {
+			return jsonNode{}, &UnknownKindError{Kind: node.Kind()}
+		}
+	}
+
+	if params := node.Params(); len(params) > 0 {
+		var err error
+		encoded.Params, err = json.Marshal(params)
+		if err != nil {
+			return jsonNode{}, fmt.Errorf("params of %s: %w", node.Kind(), err)
+		}
+	}
+
+	children := node.Children()
+	for i := range children {
+		child, err := registry.encode(children[i])
+		if err != nil {
+			return jsonNode{}, fmt.Errorf("child %d of %s: %w", i, node.Kind(), err)
+		}
+		encoded.Children = append(encoded.Children, child)
+	}
+
+	return encoded, nil
+}
+
+func (registry *Registry) decode(encoded jsonNode, variables Variables) (Node, error) {
+	switch encoded.Kind {
+	case KindConstant:
+		return decodeConstant(encoded)
+	case KindVariable:
+		return decodeVariable(encoded, variables)
+	}
+
+	decoder, ok := registry.decoders[encoded.Kind]
+	if !ok {
+		return nil, &UnknownKindError{Kind: encoded.Kind}
+	}
+
+	children := make([]Node, len(encoded.Children))
+	for i := range encoded.Children {
+		child, err := registry.decode(encoded.Children[i], variables)
+		if err != nil {
+			return nil, fmt.Errorf("child %d of %s: %w", i, encoded.Kind, err)
+		}
+		children[i] = child
+	}
+
+	node, err := decoder(encoded.Params, children)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", encoded.Kind, err)
+	}
+	return node, nil
+}
+
+func decodeConstant(encoded jsonNode) (Node, error) {
+	var params []json.RawMessage
+	if err := json.Unmarshal(encoded.Params, &params); err != nil || len(params) != 1 {
+		return nil, fmt.Errorf("constant needs exactly one param")
+	}
+
+	switch encoded.Type {
+	case jsonTypeInt64:
+		i, err := strconv.ParseInt(string(params[0]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid int64 constant %s", params[0])
+		}
+		return NodeOf(NewConstantInt64(i)), nil
+	case jsonTypeBool:
+		var b bool
+		if err := json.Unmarshal(params[0], &b); err != nil {
+			return nil, fmt.Errorf("invalid bool constant %s", params[0])
+		}
+		return NodeOf(NewConstantBool(b)), nil
+	default:
+		return nil, fmt.Errorf("constant has unknown type %q", encoded.Type)
+	}
+}
+
+func decodeVariable(encoded jsonNode, variables Variables) (Node, error) {
+	var name string
+	if err := decodeParams(encoded.Params, &name); err != nil {
+		return nil, fmt.Errorf("variable needs its name as param")
+	}
+
+	switch encoded.Type {
+	case jsonTypeInt64:
+		if variable, ok := variables.Int64[name]; ok {
+			return NodeOf(variable), nil
+		}
+	case jsonTypeBool:
+		if variable, ok := variables.Bool[name]; ok {
+			return NodeOf(variable), nil
+		}
+	default:
+		return nil, fmt.Errorf("variable %q has unknown type %q", name, encoded.Type)
+	}
+	return nil, fmt.Errorf("unknown %s variable %q", encoded.Type, name)
+}
+
+// decodeParams decodes params, which must be an array with exactly one value
+// per target.
+func decodeParams(params json.RawMessage, targets ...interface{}) error {
+	var values []json.RawMessage
+	if params != nil {
+		if err := json.Unmarshal(params, &values); err != nil {
+			return err
+		}
+	}
+	if len(values) != len(targets) {
+		return fmt.Errorf("expected %d params, got %d", len(targets), len(values))
+	}
+	for i := range values {
+		if err := json.Unmarshal(values[i], targets[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeLike returns a decoder for nodes without params which creates nodes
+// like prototype, but with the decoded children.
+func decodeLike(prototype interface{}) NodeDecoder {
+	return func(params json.RawMessage, children []Node) (Node, error) {
+		if err := decodeParams(params); err != nil {
+			return nil, err
+		}
+		return NodeOf(prototype).WithChildren(children...)
+	}
+}
+
+func decodeLogicalOperator(create func(EvaluationStrategy, ...CalculationBool) CalculationBool) NodeDecoder {
+	return func(params json.RawMessage, children []Node) (Node, error) {
+		var strategy EvaluationStrategy
+		if err := decodeParams(params, &strategy); err != nil {
+			return nil, err
+		}
+		return NodeOf(create(strategy)).WithChildren(children...)
+	}
+}
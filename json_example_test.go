@@ -0,0 +1,47 @@
+package mmath_test
+
+import (
+	"github.com/GodsBoss/mmath"
+
+	"fmt"
+)
+
+func ExampleRegistry() {
+	registry := mmath.NewRegistry()
+
+	data, err := registry.Marshal(
+		mmath.NewSumInt64(mmath.NewNamedVariableInt64("price"), mmath.NewConstantInt64(5)),
+	)
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+		return
+	}
+	fmt.Println(string(data))
+
+	price := mmath.NewNamedVariableInt64("price")
+	price.Set(20)
+
+	calc, err := registry.UnmarshalInt64(
+		data,
+		mmath.Variables{
+			Int64: map[string]mmath.VariableInt64{
+				"price": price,
+			},
+		},
+	)
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+		return
+	}
+
+	v, err := calc.CalculateInt64()
+
+	fmt.Printf("Value is %d.\n", v)
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+	}
+
+	// Output:
+	// {"kind":"sum","children":[{"kind":"variable","type":"int64","params":["price"]},{"kind":"constant","type":"int64","params":[5]}]}
+	// Value is 25.
+}
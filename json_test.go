@@ -0,0 +1,348 @@
+package mmath_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+)
+
+func TestRegistryRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	x := mmath.NewNamedVariableInt64("x")
+	x.Set(-7)
+	y := mmath.NewNamedVariableInt64("y")
+	y.Set(3)
+	p := mmath.NewNamedVariableBool("p")
+	p.Set(true)
+
+	variables := mmath.Variables{
+		Int64: map[string]mmath.VariableInt64{"x": x, "y": y},
+		Bool:  map[string]mmath.VariableBool{"p": p},
+	}
+
+	testcases := map[string]interface{}{
+		"constant":    mmath.NewConstantInt64(math.MinInt64),
+		"boolean":     mmath.NewFalse(),
+		"variable":    x,
+		"sum":         mmath.NewSumInt64(x, y, mmath.NewConstantInt64(10)),
+		"product":     mmath.NewProductInt64(x, mmath.NewSignumInt64(y)),
+		"conditional": mmath.NewConditionalInt64(mmath.NewNot(p), x, mmath.NewNegationInt64(y)),
+		"equals":      mmath.NewInt64Equals(x, y),
+		"division":    mmath.NewFlooredModuloInt64(x, mmath.NewCheckedAbsInt64(y)),
+		"logic":       mmath.NewOr(mmath.Strict, p, mmath.NewAnd(mmath.ShortCircuit, p, mmath.NewFalse())),
+		"chain":       mmath.NewInt64ChainLessOrEqual(x, y, mmath.NewConstantInt64(3)),
+		"inRange":     mmath.NewInt64InRange(y, x, mmath.NewConstantInt64(4)),
+		"baseline":    mmath.NewNot(mmath.NewInt64Equals(mmath.NewSignumInt64(x), mmath.NewConstantInt64(-1))),
+		"constBool":   mmath.NewConstantBool(true),
+	}
+
+	registry := mmath.NewRegistry()
+
+	for name := range testcases {
+		calculation := testcases[name]
+
+		t.Run(
+			name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				data, err := registry.Marshal(calculation)
+				if err != nil {
+					t.Fatalf("expected no error, got %+v", err)
+				}
+
+				node, err := registry.Unmarshal(data, variables)
+				if err != nil {
+					t.Fatalf("expected no error, got %+v", err)
+				}
+
+				if expected, actual := mmath.FormatTree(calculation), mmath.FormatTree(node); expected != actual {
+					t.Errorf("expected tree\n%s\ngot\n%s", expected, actual)
+				}
+				if expected, actual := evaluate(calculation), evaluate(node); expected != actual {
+					t.Errorf("expected %v, got %v", expected, actual)
+				}
+			},
+		)
+	}
+}
+
+func TestRegistryMarshal(t *testing.T) {
+	calc := mmath.NewAnd(
+		mmath.Strict,
+		mmath.NewInt64Less(mmath.NewNamedVariableInt64("stock"), mmath.NewConstantInt64(10)),
+		mmath.NewTrue(),
+	)
+
+	data, err := mmath.NewRegistry().Marshal(calc)
+	if err != nil {
+		t.Fatalf("expected no error, got %+v", err)
+	}
+
+	expected := `{"kind":"and","params":["strict"],"children":[` +
+		`{"kind":"less","children":[` +
+		`{"kind":"variable","type":"int64","params":["stock"]},` +
+		`{"kind":"constant","type":"int64","params":[10]}]},` +
+		`{"kind":"constant","type":"bool","params":[true]}]}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+}
+
+func TestRegistryMarshalBaselineConstructors(t *testing.T) {
+	calc := mmath.NewNot(
+		mmath.NewInt64Equals(mmath.NewSignumInt64(mmath.NewNamedVariableInt64("stock")), mmath.NewConstantInt64(1)),
+	)
+
+	data, err := mmath.NewRegistry().Marshal(calc)
+	if err != nil {
+		t.Fatalf("expected no error, got %+v", err)
+	}
+
+	expected := `{"kind":"not","children":[` +
+		`{"kind":"equals","children":[` +
+		`{"kind":"signum","children":[{"kind":"variable","type":"int64","params":["stock"]}]},` +
+		`{"kind":"constant","type":"int64","params":[1]}]}]}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+}
+
+func TestRegistryMarshalErrors(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		calculation interface{}
+		unknownKind mmath.Kind
+	}{
+		"unnamedVariable": {
+			calculation: mmath.NewSumInt64(mmath.NewVariableInt64()),
+		},
+		"function": {
+			calculation: mmath.NewNot(mmath.NewCreateFallibleUnaryBool(func(b bool) (bool, error) { return b, nil })(mmath.NewTrue())),
+			unknownKind: mmath.KindFunction,
+		},
+		"opaque": {
+			calculation: mmath.NewSignumInt64(mmath.CalculationInt64Func(func() (int64, error) { return 0, nil })),
+			unknownKind: mmath.KindOpaque,
+		},
+		"failing": {
+			calculation: mmath.NewNot(mmath.NewFailingCalculation(errors.New("broken"))),
+			unknownKind: mmath.KindFailing,
+		},
+	}
+
+	for name := range testcases {
+		testcase := testcases[name]
+
+		t.Run(
+			name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				_, err := mmath.NewRegistry().Marshal(testcase.calculation)
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+				if testcase.unknownKind == "" {
+					return
+				}
+				var unknownKindErr *mmath.UnknownKindError
+				if !errors.As(err, &unknownKindErr) {
+					t.Fatalf("expected unknown kind error, got %+v", err)
+				}
+				if unknownKindErr.Kind != testcase.unknownKind {
+					t.Errorf("expected kind %s, got %s", testcase.unknownKind, unknownKindErr.Kind)
+				}
+			},
+		)
+	}
+}
+
+func TestRegistryUnmarshalErrors(t *testing.T) {
+	t.Parallel()
+
+	variables := mmath.Variables{
+		Int64: map[string]mmath.VariableInt64{"x": mmath.NewNamedVariableInt64("x")},
+	}
+
+	testcases := map[string]struct {
+		data        string
+		unknownKind mmath.Kind
+	}{
+		"invalidJSON":     {data: `{"kind":`},
+		"unknownField":    {data: `{"kind":"sum","operands":[]}`},
+		"unknownKind":     {data: `{"kind":"sum","children":[{"kind":"max"}]}`, unknownKind: "max"},
+		"unknownVariable": {data: `{"kind":"variable","type":"int64","params":["y"]}`},
+		"variableType":    {data: `{"kind":"variable","type":"bool","params":["x"]}`},
+		"constantType":    {data: `{"kind":"constant","type":"float64","params":[1.5]}`},
+		"constantValue":   {data: `{"kind":"constant","type":"int64","params":[1.5]}`},
+		"constantRange":   {data: `{"kind":"constant","type":"int64","params":[9223372036854775808]}`},
+		"unexpectedParam": {data: `{"kind":"sum","params":[1]}`},
+		"strategy":        {data: `{"kind":"and","params":["lazy"]}`},
+		"childCount": {
+			data: `{"kind":"negation","children":[` +
+				`{"kind":"constant","type":"int64","params":[1]},` +
+				`{"kind":"constant","type":"int64","params":[2]}]}`,
+		},
+		"childType": {
+			data: `{"kind":"not","children":[{"kind":"constant","type":"int64","params":[1]}]}`,
+		},
+	}
+
+	for name := range testcases {
+		testcase := testcases[name]
+
+		t.Run(
+			name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				_, err := mmath.NewRegistry().Unmarshal([]byte(testcase.data), variables)
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+				if testcase.unknownKind == "" {
+					return
+				}
+				var unknownKindErr *mmath.UnknownKindError
+				if !errors.As(err, &unknownKindErr) {
+					t.Fatalf("expected unknown kind error, got %+v", err)
+				}
+				if unknownKindErr.Kind != testcase.unknownKind {
+					t.Errorf("expected kind %s, got %s", testcase.unknownKind, unknownKindErr.Kind)
+				}
+			},
+		)
+	}
+}
+
+func TestRegistryUnmarshalChecksResultType(t *testing.T) {
+	registry := mmath.NewRegistry()
+	data := []byte(`{"kind":"constant","type":"bool","params":[true]}`)
+
+	if _, err := registry.UnmarshalInt64(data, mmath.Variables{}); err == nil {
+		t.Errorf("expected error for bool calculation")
+	}
+	if _, err := registry.UnmarshalBool(data, mmath.Variables{}); err != nil {
+		t.Errorf("expected no error, got %+v", err)
+	}
+}
+
+func TestRegistryRegister(t *testing.T) {
+	registry := mmath.NewRegistry()
+
+	if err := registry.Register(mmath.KindSum, nil); err == nil {
+		t.Errorf("expected error when registering built-in kind")
+	}
+	if err := registry.Register(kindClamp, decodeClamp); err != nil {
+		t.Fatalf("expected no error, got %+v", err)
+	}
+	if err := registry.Register(kindClamp, decodeClamp); err == nil {
+		t.Errorf("expected error when registering kind twice")
+	}
+
+	x := mmath.NewNamedVariableInt64("x")
+	x.Set(150)
+	calc := mmath.NewSumInt64(newClamp(0, 100, x), mmath.NewConstantInt64(1))
+
+	data, err := registry.Marshal(calc)
+	if err != nil {
+		t.Fatalf("expected no error, got %+v", err)
+	}
+	decoded, err := registry.UnmarshalInt64(data, mmath.Variables{Int64: map[string]mmath.VariableInt64{"x": x}})
+	if err != nil {
+		t.Fatalf("expected no error, got %+v", err)
+	}
+
+	v, err := decoded.CalculateInt64()
+	if v != 101 || err != nil {
+		t.Errorf("expected 101 and no error, got %d and %+v", v, err)
+	}
+}
+
+const kindClamp mmath.Kind = "clamp"
+
+// clamp is a custom node limiting the value of its child to [min, max].
+type clamp struct {
+	min, max int64
+	value    mmath.CalculationInt64
+}
+
+func newClamp(min, max int64, value mmath.CalculationInt64) *clamp {
+	return &clamp{
+		min:   min,
+		max:   max,
+		value: value,
+	}
+}
+
+func decodeClamp(params json.RawMessage, children []mmath.Node) (mmath.Node, error) {
+	var limits []int64
+	if err := json.Unmarshal(params, &limits); err != nil || len(limits) != 2 {
+		return nil, errors.New("clamp needs two limits")
+	}
+	if len(children) != 1 {
+		return nil, errors.New("clamp needs exactly one child")
+	}
+	value, ok := children[0].(mmath.CalculationInt64)
+	if !ok {
+		return nil, errors.New("clamp needs an int64 child")
+	}
+	return newClamp(limits[0], limits[1], value), nil
+}
+
+func (c *clamp) CalculateInt64() (int64, error) {
+	v, err := c.value.CalculateInt64()
+	if err != nil {
+		return 0, err
+	}
+	if v < c.min {
+		return c.min, nil
+	}
+	if v > c.max {
+		return c.max, nil
+	}
+	return v, nil
+}
+
+func (c *clamp) Kind() mmath.Kind {
+	return kindClamp
+}
+
+func (c *clamp) Children() []mmath.Node {
+	return []mmath.Node{mmath.NodeOf(c.value)}
+}
+
+func (c *clamp) Params() []interface{} {
+	return []interface{}{c.min, c.max}
+}
+
+func (c *clamp) WithChildren(children ...mmath.Node) (mmath.Node, error) {
+	params, err := json.Marshal(c.Params())
+	if err != nil {
+		return nil, err
+	}
+	return decodeClamp(params, children)
+}
+
+// evaluate calculates an int64 or bool calculation, returning the value or
+// the error.
+func evaluate(calculation interface{}) interface{} {
+	var v interface{}
+	var err error
+	switch calc := calculation.(type) {
+	case mmath.CalculationInt64:
+		v, err = calc.CalculateInt64()
+	case mmath.CalculationBool:
+		v, err = calc.CalculateBool()
+	}
+	if err != nil {
+		return err.Error()
+	}
+	return v
+}
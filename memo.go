@@ -0,0 +1,278 @@
+package mmath
+
+import (
+	"sync"
+)
+
+// MemoStats contains statistics about the evaluation of a memoized
+// calculation.
+type MemoStats struct {
+	// Hits is the number of times a cached result of a sub-calculation was
+	// used.
+	Hits int
+
+	// Recomputations is the number of times a sub-calculation had to be
+	// calculated, because it was calculated for the first time or because a
+	// variable it depends on was set.
+	Recomputations int
+}
+
+// NewMemoizedInt64 returns a calculation which works like calculation, but
+// caches the results of sub-calculations like sums and conditionals, including
+// errors. A cached result is used until a variable the sub-calculation
+// depends on is set, even if it is set to the same value. Sub-calculations
+// used more than once in calculation share their cache.
+//
+// Only variables created by this package are tracked. Sub-calculations
+// containing calculations which are no nodes, e.g. a CalculationInt64Func, or
+// nodes without children other than constants, variables and
+// FailingCalculation are calculated every time, as they may depend on
+// anything.
+//
+// The memoized calculation is safe for concurrent use, but calculations are
+// serialized, so it does not calculate faster when used by multiple goroutines.
+func NewMemoizedInt64(calculation CalculationInt64) *MemoizedInt64 {
+	m := newMemoizer()
+	return &MemoizedInt64{
+		calculation: m.memoize(NodeOf(calculation)).calculation.(CalculationInt64),
+		stats:       m.stats,
+	}
+}
+
+// MemoizedInt64 is a memoized int64 calculation, see NewMemoizedInt64.
+type MemoizedInt64 struct {
+	mutex       sync.Mutex
+	calculation CalculationInt64
+	stats       *MemoStats
+}
+
+// CalculateInt64 returns the result of the calculation, using cached results
+// of sub-calculations where possible.
+func (memoized *MemoizedInt64) CalculateInt64() (int64, error) {
+	memoized.mutex.Lock()
+	defer memoized.mutex.Unlock()
+	return memoized.calculation.CalculateInt64()
+}
+
+// Stats returns the statistics of all calculations so far.
+func (memoized *MemoizedInt64) Stats() MemoStats {
+	memoized.mutex.Lock()
+	defer memoized.mutex.Unlock()
+	return *memoized.stats
+}
+
+// NewMemoizedBool works like NewMemoizedInt64, but for bool calculations.
+func NewMemoizedBool(calculation CalculationBool) *MemoizedBool {
+	m := newMemoizer()
+	return &MemoizedBool{
+		calculation: m.memoize(NodeOf(calculation)).calculation.(CalculationBool),
+		stats:       m.stats,
+	}
+}
+
+// MemoizedBool is a memoized bool calculation, see NewMemoizedBool.
+type MemoizedBool struct {
+	mutex       sync.Mutex
+	calculation CalculationBool
+	stats       *MemoStats
+}
+
+// CalculateBool returns the result of the calculation, using cached results
+// of sub-calculations where possible.
+func (memoized *MemoizedBool) CalculateBool() (bool, error) {
+	memoized.mutex.Lock()
+	defer memoized.mutex.Unlock()
+	return memoized.calculation.CalculateBool()
+}
+
+// Stats returns the statistics of all calculations so far.
+func (memoized *MemoizedBool) Stats() MemoStats {
+	memoized.mutex.Lock()
+	defer memoized.mutex.Unlock()
+	return *memoized.stats
+}
+
+// versioned is implemented by variables. Their version changes every time they
+// are set.
+type versioned interface {
+	currentVersion() uint64
+}
+
+type memoizer struct {
+	stats *MemoStats
+
+	// results contains already memoized nodes, so nodes used more than once
+	// share their cache.
+	results transformed[memoizeResult]
+}
+
+func newMemoizer() *memoizer {
+	return &memoizer{
+		stats:   &MemoStats{},
+		results: make(transformed[memoizeResult]),
+	}
+}
+
+type memoizeResult struct {
+	// calculation is the memoized calculation.
+	calculation interface{}
+
+	// dependencies contains the variables calculation depends on.
+	dependencies []versioned
+
+	// volatile is true if calculation may depend on something other than
+	// dependencies.
+	volatile bool
+}
+
+func (m *memoizer) memoize(node Node) memoizeResult {
+	return m.results.get(node, m.memoizeUncached)
+}
+
+func (m *memoizer) memoizeUncached(node Node) memoizeResult {
+	children := node.Children()
+
+	if len(children) == 0 {
+		switch node.Kind() {
+		case KindConstant, KindFailing:
+			return memoizeResult{calculation: calculationOf(node)}
+		case KindVariable:
+			if v, ok := node.(versioned); ok {
+				return memoizeResult{
+					calculation:  node,
+					dependencies: []versioned{v},
+				}
+			}
+		}
+		return memoizeResult{
+			calculation: calculationOf(node),
+			volatile:    true,
+		}
+	}
+
+	memoizedChildren := make([]Node, len(children))
+	dependencies := []versioned{}
+	seen := make(map[versioned]bool)
+	volatile := false
+	for i := range children {
+		child := m.memoize(children[i])
+		memoizedChildren[i] = NodeOf(child.calculation)
+		volatile = volatile || child.volatile
+		for _, dependency := range child.dependencies {
+			if !seen[dependency] {
+				seen[dependency] = true
+				dependencies = append(dependencies, dependency)
+			}
+		}
+	}
+
+	rebuilt, err := node.WithChildren(memoizedChildren...)
+	if err != nil {
+		return memoizeResult{
+			calculation: calculationOf(node),
+			volatile:    true,
+		}
+	}
+	if volatile {
+		return memoizeResult{
+			calculation: calculationOf(rebuilt),
+			volatile:    true,
+		}
+	}
+
+	c := &cache{
+		stats:        m.stats,
+		dependencies: dependencies,
+		versions:     make([]uint64, len(dependencies)),
+	}
+	result := memoizeResult{
+		calculation:  calculationOf(rebuilt),
+		dependencies: dependencies,
+	}
+
+	result.calculation = byResultType(
+		rebuilt,
+		func(calculation CalculationInt64) interface{} {
+			return &memoizedInt64Node{
+				Node:        rebuilt,
+				calculation: calculation,
+				cache:       c,
+			}
+		},
+		func(calculation CalculationBool) interface{} {
+			return &memoizedBoolNode{
+				Node:        rebuilt,
+				calculation: calculation,
+				cache:       c,
+			}
+		},
+		result.calculation,
+	)
+	return result
+}
+
+// cache contains the state shared by memoized nodes of all types.
+type cache struct {
+	stats        *MemoStats
+	dependencies []versioned
+	versions     []uint64
+	valid        bool
+	err          error
+}
+
+// lookup returns wether the cached result is still valid.
+func (c *cache) lookup() bool {
+	if !c.valid {
+		return false
+	}
+	for i := range c.dependencies {
+		if c.dependencies[i].currentVersion() != c.versions[i] {
+			return false
+		}
+	}
+	c.stats.Hits++
+	return true
+}
+
+// prepare is called before recalculating the result.
+func (c *cache) prepare() {
+	for i := range c.dependencies {
+		c.versions[i] = c.dependencies[i].currentVersion()
+	}
+	c.stats.Recomputations++
+}
+
+// memoizedInt64Node caches the result of a node.
+type memoizedInt64Node struct {
+	Node
+	calculation CalculationInt64
+	*cache
+	value int64
+}
+
+func (node *memoizedInt64Node) CalculateInt64() (int64, error) {
+	if node.lookup() {
+		return node.value, node.err
+	}
+	node.prepare()
+	node.value, node.err = node.calculation.CalculateInt64()
+	node.valid = true
+	return node.value, node.err
+}
+
+type memoizedBoolNode struct {
+	Node
+	calculation CalculationBool
+	*cache
+	value bool
+}
+
+func (node *memoizedBoolNode) CalculateBool() (bool, error) {
+	if node.lookup() {
+		return node.value, node.err
+	}
+	node.prepare()
+	node.value, node.err = node.calculation.CalculateBool()
+	node.valid = true
+	return node.value, node.err
+}
@@ -0,0 +1,46 @@
+package mmath_test
+
+import (
+	"github.com/GodsBoss/mmath"
+
+	"fmt"
+)
+
+func ExampleNewMemoizedInt64() {
+	price := mmath.NewVariableInt64()
+	quantity := mmath.NewVariableInt64()
+	shipping := mmath.NewVariableInt64()
+
+	total := mmath.NewMemoizedInt64(
+		mmath.NewSumInt64(
+			mmath.NewProductInt64(price, quantity),
+			mmath.NewConditionalInt64(
+				mmath.NewInt64Less(shipping, mmath.NewConstantInt64(0)),
+				mmath.NewConstantInt64(0),
+				shipping,
+			),
+		),
+	)
+
+	price.Set(20)
+	quantity.Set(3)
+	shipping.Set(5)
+
+	for _, q := range []int64{3, 4} {
+		quantity.Set(q)
+
+		v, err := total.CalculateInt64()
+
+		fmt.Printf("Value is %d.\n", v)
+		if err != nil {
+			fmt.Printf("Error is: %v\n", err)
+		}
+	}
+
+	fmt.Printf("%+v\n", total.Stats())
+
+	// Output:
+	// Value is 65.
+	// Value is 85.
+	// {Hits:1 Recomputations:6}
+}
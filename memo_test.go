@@ -0,0 +1,188 @@
+package mmath_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+)
+
+func TestMemoizedInt64(t *testing.T) {
+	x := mmath.NewVariableInt64()
+	y := mmath.NewVariableInt64()
+	calc := mmath.NewMemoizedInt64(
+		mmath.NewSumInt64(
+			mmath.NewProductInt64(x, mmath.NewConstantInt64(2)),
+			mmath.NewProductInt64(y, mmath.NewConstantInt64(3)),
+		),
+	)
+
+	steps := []struct {
+		set           func()
+		expectedValue int64
+		expectedStats mmath.MemoStats
+	}{
+		{
+			set:           func() { x.Set(1); y.Set(2) },
+			expectedValue: 8,
+			expectedStats: mmath.MemoStats{Recomputations: 3},
+		},
+		{
+			set:           func() {},
+			expectedValue: 8,
+			expectedStats: mmath.MemoStats{Hits: 1, Recomputations: 3},
+		},
+		{
+			set:           func() { x.Set(5) },
+			expectedValue: 16,
+			expectedStats: mmath.MemoStats{Hits: 2, Recomputations: 5},
+		},
+		{
+			set:           func() { y.Set(1) },
+			expectedValue: 13,
+			expectedStats: mmath.MemoStats{Hits: 3, Recomputations: 7},
+		},
+	}
+
+	for i, step := range steps {
+		step.set()
+
+		v, err := calc.CalculateInt64()
+		if err != nil {
+			t.Errorf("step %d: expected no error, got %+v", i, err)
+		}
+		if v != step.expectedValue {
+			t.Errorf("step %d: expected %d, got %d", i, step.expectedValue, v)
+		}
+		if stats := calc.Stats(); stats != step.expectedStats {
+			t.Errorf("step %d: expected stats %+v, got %+v", i, step.expectedStats, stats)
+		}
+	}
+}
+
+func TestMemoizedInt64SharesCacheOfSharedCalculations(t *testing.T) {
+	x := mmath.NewVariableInt64()
+	x.Set(4)
+	shared := mmath.NewSumInt64(x, mmath.NewConstantInt64(1))
+	calc := mmath.NewMemoizedInt64(mmath.NewProductInt64(shared, shared))
+
+	v, err := calc.CalculateInt64()
+	if v != 25 || err != nil {
+		t.Errorf("expected 25 and no error, got %d and %+v", v, err)
+	}
+
+	expected := mmath.MemoStats{Hits: 1, Recomputations: 2}
+	if stats := calc.Stats(); stats != expected {
+		t.Errorf("expected stats %+v, got %+v", expected, stats)
+	}
+}
+
+func TestMemoizedInt64RecalculatesOpaqueCalculations(t *testing.T) {
+	calls := int64(0)
+	opaque := mmath.CalculationInt64Func(
+		func() (int64, error) {
+			calls++
+			return calls, nil
+		},
+	)
+	x := mmath.NewVariableInt64()
+	constant := mmath.NewProductInt64(mmath.NewConstantInt64(6), mmath.NewConstantInt64(7))
+	calc := mmath.NewMemoizedInt64(mmath.NewSumInt64(mmath.NewNegationInt64(x), opaque, constant))
+
+	for i := int64(1); i <= 3; i++ {
+		v, err := calc.CalculateInt64()
+		if v != 42+i || err != nil {
+			t.Errorf("expected %d and no error, got %d and %+v", 42+i, v, err)
+		}
+	}
+
+	expected := mmath.MemoStats{Hits: 4, Recomputations: 2}
+	if stats := calc.Stats(); stats != expected {
+		t.Errorf("expected stats %+v, got %+v", expected, stats)
+	}
+}
+
+func TestMemoizedInt64CachesErrors(t *testing.T) {
+	x := mmath.NewVariableInt64()
+	calc := mmath.NewMemoizedInt64(mmath.NewQuotientInt64(mmath.NewConstantInt64(10), x))
+
+	for i := 0; i < 2; i++ {
+		_, err := calc.CalculateInt64()
+		errorIsDivisionByZero("quotient")(t, err)
+	}
+
+	x.Set(5)
+	v, err := calc.CalculateInt64()
+	if v != 2 || err != nil {
+		t.Errorf("expected 2 and no error, got %d and %+v", v, err)
+	}
+
+	expected := mmath.MemoStats{Hits: 1, Recomputations: 2}
+	if stats := calc.Stats(); stats != expected {
+		t.Errorf("expected stats %+v, got %+v", expected, stats)
+	}
+}
+
+func TestMemoizedBool(t *testing.T) {
+	p := mmath.NewVariableBool()
+	x := mmath.NewVariableInt64()
+	calc := mmath.NewMemoizedBool(
+		mmath.NewOr(
+			mmath.Strict,
+			mmath.NewNot(p),
+			mmath.NewInt64Less(x, mmath.NewConstantInt64(0)),
+			mmath.NewNot(mmath.NewFailingCalculation(errors.New("unused"))),
+		),
+	)
+	p.Set(true)
+
+	steps := []func(){
+		func() {},
+		func() { x.Set(-1) },
+		func() { p.Set(false) },
+	}
+
+	for i, set := range steps {
+		set()
+
+		_, err := calc.CalculateBool()
+		if err == nil {
+			t.Errorf("step %d: expected error", i)
+		}
+	}
+
+	expected := mmath.MemoStats{Hits: 4, Recomputations: 8}
+	if stats := calc.Stats(); stats != expected {
+		t.Errorf("expected stats %+v, got %+v", expected, stats)
+	}
+}
+
+func TestMemoizedBoolCachesConstantSubCalculations(t *testing.T) {
+	x := mmath.NewVariableInt64()
+	calc := mmath.NewMemoizedBool(
+		mmath.NewAnd(
+			mmath.Strict,
+			mmath.NewNot(mmath.NewInt64Equals(x, mmath.NewConstantInt64(3))),
+			mmath.NewNot(mmath.NewInt64Equals(mmath.NewSignumInt64(mmath.NewConstantInt64(-2)), mmath.NewConstantInt64(0))),
+			mmath.NewConstantBool(true),
+		),
+	)
+
+	x.Set(3)
+	for i := 0; i < 2; i++ {
+		if v, _ := calc.CalculateBool(); v {
+			t.Errorf("x=3: expected false")
+		}
+	}
+	x.Set(4)
+	if v, _ := calc.CalculateBool(); !v {
+		t.Errorf("x=4: expected true")
+	}
+
+	// After x changed, only the root and the operand depending on x are
+	// calculated again, the operand built from constants is a hit.
+	expected := mmath.MemoStats{Hits: 2, Recomputations: 9}
+	if stats := calc.Stats(); stats != expected {
+		t.Errorf("expected stats %+v, got %+v", expected, stats)
+	}
+}
@@ -0,0 +1,454 @@
+package mmath
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Node is a calculation exposing its structure, i.e. its operator, operands and
+// parameters. All int64 and bool calculations created by this package are
+// nodes, including variables and FailingCalculation. Every node created by
+// this package also implements CalculationInt64, CalculationBool or both.
+type Node interface {
+	// Kind returns the operator of the node.
+	Kind() Kind
+
+	// Children returns the operands of the node. Operands which are no nodes
+	// are wrapped via NodeOf.
+	Children() []Node
+
+	// Params returns everything besides the children which determines the
+	// calculation, see the documentation of the kinds. Kinds not mentioned
+	// there have no params.
+	Params() []interface{}
+
+	// WithChildren returns a node of the same kind with the same params, but
+	// with other children. Children must have the same types as the original
+	// children, and for kinds with a fixed number of operands, their count must
+	// not change. Nodes without children return themselves if called without
+	// children.
+	WithChildren(children ...Node) (Node, error)
+}
+
+// Kind identifies the operator of a node.
+type Kind string
+
+// Kinds of nodes not belonging to a specific type.
+const (
+	// KindConstant is a constant created by NewConstantInt64 or
+	// NewConstantBool. Params contains the int64 or bool value.
+	KindConstant Kind = "constant"
+
+	// KindVariable is a variable. Params contains the name of the variable,
+	// which is empty for unnamed variables.
+	KindVariable Kind = "variable"
+
+	// KindFunction is created by the NewCreate... functions, e.g.
+	// NewCreateBinaryInt64.
+	KindFunction Kind = "function"
+
+	// KindFailing is a FailingCalculation. Params contains its error.
+	KindFailing Kind = "failing"
+
+	// KindConditional is created by NewConditionalInt64. Its children are the
+	// condition and both branches.
+	KindConditional Kind = "conditional"
+
+	// KindOpaque is a calculation not exposing its structure, wrapped by NodeOf.
+	// Params contains the calculation.
+	KindOpaque Kind = "opaque"
+)
+
+// Kinds of nodes resulting in an int64. Every kind is created by the
+// constructor with the same name, e.g. KindSum by NewSumInt64 and KindAbs by
+// NewCheckedAbsInt64.
+const (
+	KindSum                Kind = "sum"
+	KindProduct            Kind = "product"
+	KindDifference         Kind = "difference"
+	KindNegation           Kind = "negation"
+	KindSignum             Kind = "signum"
+	KindReduceLeft         Kind = "reduceLeft"
+	KindCheckedSum         Kind = "checkedSum"
+	KindCheckedProduct     Kind = "checkedProduct"
+	KindCheckedDifference  Kind = "checkedDifference"
+	KindCheckedNegation    Kind = "checkedNegation"
+	KindAbs                Kind = "abs"
+	KindQuotient           Kind = "quotient"
+	KindRemainder          Kind = "remainder"
+	KindEuclideanQuotient  Kind = "euclideanQuotient"
+	KindEuclideanRemainder Kind = "euclideanRemainder"
+	KindFlooredQuotient    Kind = "flooredQuotient"
+	KindFlooredModulo      Kind = "flooredModulo"
+)
+
+// Kinds of nodes resulting in a bool. Every kind is created by the constructor
+// with the same name, e.g. KindNot by NewNot and KindLess by NewInt64Less.
+// The logical operators KindAnd, KindOr, KindXor, KindImplies and
+// KindEquivalent have the EvaluationStrategy as param.
+const (
+	KindNot                 Kind = "not"
+	KindAnd                 Kind = "and"
+	KindOr                  Kind = "or"
+	KindXor                 Kind = "xor"
+	KindImplies             Kind = "implies"
+	KindEquivalent          Kind = "equivalent"
+	KindEquals              Kind = "equals"
+	KindNotEquals           Kind = "notEquals"
+	KindLess                Kind = "less"
+	KindLessOrEqual         Kind = "lessOrEqual"
+	KindGreater             Kind = "greater"
+	KindGreaterOrEqual      Kind = "greaterOrEqual"
+	KindChainLess           Kind = "chainLess"
+	KindChainLessOrEqual    Kind = "chainLessOrEqual"
+	KindChainGreater        Kind = "chainGreater"
+	KindChainGreaterOrEqual Kind = "chainGreaterOrEqual"
+	KindBetween             Kind = "between"
+	KindInRange             Kind = "inRange"
+)
+
+// NodeOf returns calculation as node. If calculation is a node, it is returned
+// as is, else it is wrapped into a node of kind KindOpaque, which implements
+// the same calculation interfaces as calculation.
+func NodeOf(calculation interface{}) Node {
+	if node, ok := calculation.(Node); ok {
+		return node
+	}
+
+	base := opaqueNode{
+		calculation: calculation,
+	}
+	int64Calc, isInt64 := calculation.(CalculationInt64)
+	boolCalc, isBool := calculation.(CalculationBool)
+
+	switch {
+	case isInt64 && isBool:
+		return &opaqueInt64BoolNode{
+			opaqueNode:       base,
+			CalculationInt64: int64Calc,
+			CalculationBool:  boolCalc,
+		}
+	case isInt64:
+		return &opaqueInt64Node{
+			opaqueNode:       base,
+			CalculationInt64: int64Calc,
+		}
+	case isBool:
+		return &opaqueBoolNode{
+			opaqueNode:      base,
+			CalculationBool: boolCalc,
+		}
+	default:
+		return &base
+	}
+}
+
+// calculationOf is the reverse of NodeOf.
+func calculationOf(node Node) interface{} {
+	if node.Kind() == KindOpaque {
+		return node.Params()[0]
+	}
+	return node
+}
+
+type opaqueNode struct {
+	calculation interface{}
+}
+
+func (node *opaqueNode) Kind() Kind {
+	return KindOpaque
+}
+
+func (node *opaqueNode) Children() []Node {
+	return nil
+}
+
+func (node *opaqueNode) Params() []interface{} {
+	return []interface{}{node.calculation}
+}
+
+func (node *opaqueNode) WithChildren(children ...Node) (Node, error) {
+	return withoutChildren(node, children)
+}
+
+type opaqueInt64Node struct {
+	opaqueNode
+	CalculationInt64
+}
+
+func (node *opaqueInt64Node) WithChildren(children ...Node) (Node, error) {
+	return withoutChildren(node, children)
+}
+
+type opaqueBoolNode struct {
+	opaqueNode
+	CalculationBool
+}
+
+func (node *opaqueBoolNode) WithChildren(children ...Node) (Node, error) {
+	return withoutChildren(node, children)
+}
+
+type opaqueInt64BoolNode struct {
+	opaqueNode
+	CalculationInt64
+	CalculationBool
+}
+
+func (node *opaqueInt64BoolNode) WithChildren(children ...Node) (Node, error) {
+	return withoutChildren(node, children)
+}
+
+// byResultType returns ifInt64 or ifBool applied to calculation, depending on
+// which result calculation has. For calculations having both or none, which
+// result is needed is not known, so otherwise is returned.
+func byResultType[R any](
+	calculation interface{},
+	ifInt64 func(calculation CalculationInt64) R,
+	ifBool func(calculation CalculationBool) R,
+	otherwise R,
+) R {
+	int64Calc, isInt64 := calculation.(CalculationInt64)
+	boolCalc, isBool := calculation.(CalculationBool)
+	switch {
+	case isInt64 && isBool:
+		return otherwise
+	case isInt64:
+		return ifInt64(int64Calc)
+	case isBool:
+		return ifBool(boolCalc)
+	}
+	return otherwise
+}
+
+// transformed caches the results of transforming nodes, so nodes used more
+// than once are transformed only once and share the result. Transformations
+// replacing nodes by wrappers, e.g. memoized or parallel calculations, embed
+// the transformed node in the wrapper, so the structure can still be
+// inspected. Only pointers are used as keys, as other values may not be
+// comparable.
+type transformed[R any] map[Node]R
+
+// get returns the cached result for node, or transforms node if there is
+// none.
+func (results transformed[R]) get(node Node, transform func(node Node) R) R {
+	isPointer := reflect.ValueOf(node).Kind() == reflect.Ptr
+	if isPointer {
+		if result, ok := results[node]; ok {
+			return result
+		}
+	}
+
+	result := transform(node)
+	if isPointer {
+		results[node] = result
+	}
+	return result
+}
+
+// withoutChildren implements WithChildren for nodes without children.
+func withoutChildren(node Node, children []Node) (Node, error) {
+	if len(children) > 0 {
+		return nil, fmt.Errorf("%s expects no children, got %d", node.Kind(), len(children))
+	}
+	return node, nil
+}
+
+// rebuilder creates a calculation like the one it belongs to, but with other
+// children.
+type rebuilder func(children []interface{}) (interface{}, error)
+
+// structure contains what nodes created by this package have in common.
+type structure struct {
+	kind     Kind
+	children []interface{}
+	params   []interface{}
+	rebuild  rebuilder
+
+	// operation is the function applied to the operands by functions,
+	// reductions, comparisons and some operators, so the calculation can be
+	// compiled or calculated in parallel, see CompileInt64 and
+	// NewParallelInt64. It is nil for all other calculations.
+	operation interface{}
+}
+
+func (s structure) Kind() Kind {
+	return s.kind
+}
+
+func (s structure) Children() []Node {
+	children := make([]Node, len(s.children))
+	for i := range s.children {
+		children[i] = NodeOf(s.children[i])
+	}
+	return children
+}
+
+func (s structure) Params() []interface{} {
+	return append([]interface{}(nil), s.params...)
+}
+
+// withChildren implements WithChildren for node, which contains s. Without a
+// rebuilder, node has no children.
+func (s structure) withChildren(node Node, children []Node) (Node, error) {
+	if s.rebuild == nil {
+		return withoutChildren(node, children)
+	}
+	calculations := make([]interface{}, len(children))
+	for i := range children {
+		calculations[i] = calculationOf(children[i])
+	}
+	calculation, err := s.rebuild(calculations)
+	if err != nil {
+		return nil, err
+	}
+	return NodeOf(calculation), nil
+}
+
+// int64Node is the calculation created by most int64 constructors.
+type int64Node struct {
+	structure
+	calculate func() (int64, error)
+
+	// contextTrees is used by CalculateInt64Context, see contextTrees.
+	contextTrees *contextTrees
+}
+
+func newInt64Node(
+	kind Kind,
+	children []interface{},
+	params []interface{},
+	rebuild rebuilder,
+	calculate func() (int64, error),
+) *int64Node {
+	return &int64Node{
+		structure: structure{
+			kind:     kind,
+			children: children,
+			params:   params,
+			rebuild:  rebuild,
+		},
+		calculate:    calculate,
+		contextTrees: new(contextTrees),
+	}
+}
+
+// withOperation sets the operation of node, see structure.
+func (node *int64Node) withOperation(operation interface{}) *int64Node {
+	node.operation = operation
+	return node
+}
+
+func (node *int64Node) CalculateInt64() (int64, error) {
+	return node.calculate()
+}
+
+func (node *int64Node) WithChildren(children ...Node) (Node, error) {
+	return node.withChildren(node, children)
+}
+
+// String returns the calculation as formula, see FormatFormula.
+func (node *int64Node) String() string {
+	return FormatFormula(node)
+}
+
+// boolNode is the calculation created by most bool constructors.
+type boolNode struct {
+	structure
+	calculate func() (bool, error)
+
+	// contextTrees is used by CalculateBoolContext, see contextTrees.
+	contextTrees *contextTrees
+}
+
+func newBoolNode(
+	kind Kind,
+	children []interface{},
+	params []interface{},
+	rebuild rebuilder,
+	calculate func() (bool, error),
+) *boolNode {
+	return &boolNode{
+		structure: structure{
+			kind:     kind,
+			children: children,
+			params:   params,
+			rebuild:  rebuild,
+		},
+		calculate:    calculate,
+		contextTrees: new(contextTrees),
+	}
+}
+
+// withOperation sets the operation of node, see structure.
+func (node *boolNode) withOperation(operation interface{}) *boolNode {
+	node.operation = operation
+	return node
+}
+
+func (node *boolNode) CalculateBool() (bool, error) {
+	return node.calculate()
+}
+
+func (node *boolNode) WithChildren(children ...Node) (Node, error) {
+	return node.withChildren(node, children)
+}
+
+// String returns the calculation as formula, see FormatFormula.
+func (node *boolNode) String() string {
+	return FormatFormula(node)
+}
+
+func int64Children(calculations ...CalculationInt64) []interface{} {
+	children := make([]interface{}, len(calculations))
+	for i := range calculations {
+		children[i] = calculations[i]
+	}
+	return children
+}
+
+func boolChildren(calculations ...CalculationBool) []interface{} {
+	children := make([]interface{}, len(calculations))
+	for i := range calculations {
+		children[i] = calculations[i]
+	}
+	return children
+}
+
+// rebuildOperands returns a rebuilder which checks that all children are of
+// type T and passes them to create. If count is not negative, exactly count
+// children are required.
+func rebuildOperands[T any](kind Kind, count int, create func(operands []T) interface{}) rebuilder {
+	return func(children []interface{}) (interface{}, error) {
+		if count >= 0 && len(children) != count {
+			return nil, fmt.Errorf("%s expects %d children, got %d", kind, count, len(children))
+		}
+		operands := make([]T, len(children))
+		for i := range children {
+			operand, ok := children[i].(T)
+			if !ok {
+				return nil, fmt.Errorf("child %d of %s has invalid type %T", i, kind, children[i])
+			}
+			operands[i] = operand
+		}
+		return create(operands), nil
+	}
+}
+
+func rebuildUnary[T any, R any](kind Kind, create func(operand T) R) rebuilder {
+	return rebuildOperands(kind, 1, func(operands []T) interface{} {
+		return create(operands[0])
+	})
+}
+
+func rebuildBinary[T any, R any](kind Kind, create func(left, right T) R) rebuilder {
+	return rebuildOperands(kind, 2, func(operands []T) interface{} {
+		return create(operands[0], operands[1])
+	})
+}
+
+func rebuildNary[T any, R any](kind Kind, create func(operands ...T) R) rebuilder {
+	return rebuildOperands(kind, -1, func(operands []T) interface{} {
+		return create(operands...)
+	})
+}
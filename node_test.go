@@ -0,0 +1,348 @@
+package mmath_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+)
+
+func TestNodeStructure(t *testing.T) {
+	t.Parallel()
+
+	x := mmath.NewNamedVariableInt64("x")
+	p := mmath.NewNamedVariableBool("p")
+	failing := mmath.NewFailingCalculation(errors.New("broken"))
+
+	testcases := map[string]struct {
+		calculation      interface{}
+		expectedKind     mmath.Kind
+		expectedChildren []interface{}
+		expectedParams   []interface{}
+	}{
+		"constantInt64": {
+			calculation:    mmath.NewConstantInt64(5),
+			expectedKind:   mmath.KindConstant,
+			expectedParams: []interface{}{int64(5)},
+		},
+		"constantBool": {
+			calculation:    mmath.NewTrue(),
+			expectedKind:   mmath.KindConstant,
+			expectedParams: []interface{}{true},
+		},
+		"variable": {
+			calculation:    x,
+			expectedKind:   mmath.KindVariable,
+			expectedParams: []interface{}{"x"},
+		},
+		"unnamedVariable": {
+			calculation:    mmath.NewVariableBool(),
+			expectedKind:   mmath.KindVariable,
+			expectedParams: []interface{}{""},
+		},
+		"failing": {
+			calculation:    failing,
+			expectedKind:   mmath.KindFailing,
+			expectedParams: []interface{}{failing.Err},
+		},
+		"sum": {
+			calculation:      mmath.NewSumInt64(x, x, x),
+			expectedKind:     mmath.KindSum,
+			expectedChildren: []interface{}{x, x, x},
+		},
+		"conditional": {
+			calculation:      mmath.NewConditionalInt64(p, x, x),
+			expectedKind:     mmath.KindConditional,
+			expectedChildren: []interface{}{p, x, x},
+		},
+		"checkedAbs": {
+			calculation:      mmath.NewCheckedAbsInt64(x),
+			expectedKind:     mmath.KindAbs,
+			expectedChildren: []interface{}{x},
+		},
+		"between": {
+			calculation:      mmath.NewInt64Between(x, failing, x),
+			expectedKind:     mmath.KindBetween,
+			expectedChildren: []interface{}{x, failing, x},
+		},
+		"and": {
+			calculation:      mmath.NewAnd(mmath.Strict, p, p),
+			expectedKind:     mmath.KindAnd,
+			expectedChildren: []interface{}{p, p},
+			expectedParams:   []interface{}{mmath.Strict},
+		},
+		"function": {
+			calculation:      mmath.NewCreateFallibleUnaryBool(func(b bool) (bool, error) { return b, nil })(p),
+			expectedKind:     mmath.KindFunction,
+			expectedChildren: []interface{}{p},
+		},
+	}
+
+	for name := range testcases {
+		testcase := testcases[name]
+
+		t.Run(
+			name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				node := mmath.NodeOf(testcase.calculation)
+
+				if kind := node.Kind(); kind != testcase.expectedKind {
+					t.Errorf("expected kind %s, got %s", testcase.expectedKind, kind)
+				}
+
+				children := node.Children()
+				if len(children) != len(testcase.expectedChildren) {
+					t.Fatalf("expected %d children, got %d", len(testcase.expectedChildren), len(children))
+				}
+				for i := range children {
+					if children[i] != testcase.expectedChildren[i] {
+						t.Errorf("expected child %d to be %+v, got %+v", i, testcase.expectedChildren[i], children[i])
+					}
+				}
+
+				if params := node.Params(); len(params) > 0 || len(testcase.expectedParams) > 0 {
+					if !reflect.DeepEqual(params, testcase.expectedParams) {
+						t.Errorf("expected params %+v, got %+v", testcase.expectedParams, params)
+					}
+				}
+			},
+		)
+	}
+}
+
+func TestNodeOfWrapsOpaqueCalculations(t *testing.T) {
+	calc := mmath.CalculationInt64Func(
+		func() (int64, error) {
+			return 42, nil
+		},
+	)
+
+	node := mmath.NodeOf(calc)
+
+	if kind := node.Kind(); kind != mmath.KindOpaque {
+		t.Errorf("expected kind %s, got %s", mmath.KindOpaque, kind)
+	}
+	if _, ok := node.(mmath.CalculationBool); ok {
+		t.Errorf("expected opaque int64 calculation not to be a bool calculation")
+	}
+	v, err := node.(mmath.CalculationInt64).CalculateInt64()
+	if v != 42 || err != nil {
+		t.Errorf("expected 42 and no error, got %d and %+v", v, err)
+	}
+
+	sum, err := mmath.NodeOf(mmath.NewSumInt64()).WithChildren(node, node)
+	if err != nil {
+		t.Fatalf("expected no error, got %+v", err)
+	}
+	children := sum.Children()
+	if kind := children[0].Kind(); kind != mmath.KindOpaque {
+		t.Errorf("expected child of kind %s, got %s", mmath.KindOpaque, kind)
+	}
+	v, err = sum.(mmath.CalculationInt64).CalculateInt64()
+	if v != 84 || err != nil {
+		t.Errorf("expected 84 and no error, got %d and %+v", v, err)
+	}
+}
+
+func TestConstructorsOfFunctionsCreateNodes(t *testing.T) {
+	t.Parallel()
+
+	x := mmath.NewNamedVariableInt64("x")
+	p := mmath.NewNamedVariableBool("p")
+	add := mmath.NewCreateBinaryInt64(func(left, right int64) int64 {
+		return left + right
+	})
+
+	testcases := map[string]struct {
+		calculation     interface{}
+		expectedKind    mmath.Kind
+		expectedFormula string
+	}{
+		"constantInt64": {mmath.NewConstantInt64(1), mmath.KindConstant, "1"},
+		"signum":        {mmath.NewSignumInt64(x), mmath.KindSignum, "sign(x)"},
+		"function":      {add(x, x), mmath.KindFunction, "function(x, x)"},
+		"constantBool":  {mmath.NewConstantBool(true), mmath.KindConstant, "true"},
+		"not":           {mmath.NewNot(p), mmath.KindNot, "!p"},
+		"equals":        {mmath.NewInt64Equals(x, mmath.NewConstantInt64(3)), mmath.KindEquals, "x == 3"},
+	}
+
+	for name, testcase := range testcases {
+		node := mmath.NodeOf(testcase.calculation)
+		if kind := node.Kind(); kind != testcase.expectedKind {
+			t.Errorf("%s: expected kind %s, got %s", name, testcase.expectedKind, kind)
+		}
+		if formula := mmath.FormatFormula(node); formula != testcase.expectedFormula {
+			t.Errorf("%s: expected formula %q, got %q", name, testcase.expectedFormula, formula)
+		}
+	}
+}
+
+func TestNodeWithChildren(t *testing.T) {
+	t.Parallel()
+
+	oneCalc := mmath.NewConstantInt64(1)
+	one := mmath.NodeOf(oneCalc)
+	two := mmath.NodeOf(mmath.NewConstantInt64(2))
+	three := mmath.NodeOf(mmath.NewConstantInt64(3))
+	yes := mmath.NodeOf(mmath.NewTrue())
+	no := mmath.NodeOf(mmath.NewFalse())
+
+	testcases := map[string]struct {
+		calculation   interface{}
+		children      []mmath.Node
+		expectedValue interface{}
+	}{
+		"sum": {
+			calculation:   mmath.NewSumInt64(),
+			children:      []mmath.Node{one, two, three},
+			expectedValue: int64(6),
+		},
+		"difference": {
+			calculation:   mmath.NewDifferenceInt64(oneCalc, oneCalc),
+			children:      []mmath.Node{three, one},
+			expectedValue: int64(2),
+		},
+		"conditional": {
+			calculation:   mmath.NewConditionalInt64(mmath.NewTrue(), oneCalc, mmath.NewConstantInt64(2)),
+			children:      []mmath.Node{no, one, two},
+			expectedValue: int64(2),
+		},
+		"reduceLeft": {
+			calculation: mmath.NewReduceLeft(
+				func(current, next int64) (int64, error) {
+					return current*10 + next, nil
+				},
+				oneCalc,
+				nil,
+			),
+			children:      []mmath.Node{one, two, three},
+			expectedValue: int64(123),
+		},
+		"custom": {
+			calculation: mmath.NewCreateBinaryInt64(
+				func(left, right int64) int64 {
+					return left<<8 | right
+				},
+			)(oneCalc, oneCalc),
+			children:      []mmath.Node{one, two},
+			expectedValue: int64(258),
+		},
+		"strictImplies": {
+			calculation:   mmath.NewImplies(mmath.Strict),
+			children:      []mmath.Node{yes, yes, no},
+			expectedValue: false,
+		},
+		"chain": {
+			calculation:   mmath.NewInt64ChainLess(oneCalc, mmath.NewConstantInt64(2)),
+			children:      []mmath.Node{one, three, two},
+			expectedValue: false,
+		},
+		"inRange": {
+			calculation:   mmath.NewInt64InRange(oneCalc, oneCalc, oneCalc),
+			children:      []mmath.Node{two, one, three},
+			expectedValue: true,
+		},
+	}
+
+	for name := range testcases {
+		testcase := testcases[name]
+
+		t.Run(
+			name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				node, err := mmath.NodeOf(testcase.calculation).WithChildren(testcase.children...)
+				if err != nil {
+					t.Fatalf("expected no error, got %+v", err)
+				}
+				if node.Kind() != mmath.NodeOf(testcase.calculation).Kind() {
+					t.Errorf("expected kind %s, got %s", mmath.NodeOf(testcase.calculation).Kind(), node.Kind())
+				}
+
+				var v interface{}
+				switch calc := node.(type) {
+				case mmath.CalculationInt64:
+					v, err = calc.CalculateInt64()
+				case mmath.CalculationBool:
+					v, err = calc.CalculateBool()
+				}
+				if err != nil {
+					t.Errorf("expected no error, got %+v", err)
+				}
+				if v != testcase.expectedValue {
+					t.Errorf("expected %v, got %v", testcase.expectedValue, v)
+				}
+			},
+		)
+	}
+}
+
+func TestNodeWithChildrenErrors(t *testing.T) {
+	t.Parallel()
+
+	oneCalc := mmath.NewConstantInt64(1)
+	one := mmath.NodeOf(oneCalc)
+	yes := mmath.NodeOf(mmath.NewTrue())
+
+	testcases := map[string]struct {
+		calculation interface{}
+		children    []mmath.Node
+	}{
+		"constant": {
+			calculation: mmath.NewConstantInt64(1),
+			children:    []mmath.Node{one},
+		},
+		"variable": {
+			calculation: mmath.NewVariableBool(),
+			children:    []mmath.Node{yes},
+		},
+		"childCount": {
+			calculation: mmath.NewNegationInt64(oneCalc),
+			children:    []mmath.Node{one, one},
+		},
+		"childType": {
+			calculation: mmath.NewSumInt64(oneCalc),
+			children:    []mmath.Node{one, yes},
+		},
+		"conditionType": {
+			calculation: mmath.NewConditionalInt64(mmath.NewTrue(), oneCalc, oneCalc),
+			children:    []mmath.Node{one, one, one},
+		},
+		"reduceLeftWithoutInitialValue": {
+			calculation: mmath.NewReduceLeft(nil, oneCalc, nil),
+			children:    []mmath.Node{},
+		},
+	}
+
+	for name := range testcases {
+		testcase := testcases[name]
+
+		t.Run(
+			name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				_, err := mmath.NodeOf(testcase.calculation).WithChildren(testcase.children...)
+				if err == nil {
+					t.Errorf("expected error")
+				}
+			},
+		)
+	}
+}
+
+func TestNodeWithoutChildrenReturnsItself(t *testing.T) {
+	v := mmath.NewVariableInt64()
+
+	node, err := mmath.NodeOf(v).WithChildren()
+	if err != nil {
+		t.Fatalf("expected no error, got %+v", err)
+	}
+	if node != mmath.NodeOf(v) {
+		t.Errorf("expected variable to be returned as is")
+	}
+}
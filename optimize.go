@@ -0,0 +1,215 @@
+package mmath
+
+// OptimizeInt64 returns a calculation which calculates the same results as
+// calculation, but is cheaper to calculate. It applies the following
+// simplifications until none of them is possible anymore:
+//
+//   - Calculations whose operands are all constants are replaced by their
+//     result, unless they fail or contain functions, e.g. those created via
+//     NewCreateBinaryInt64 or NewReduceLeft.
+//   - Operands 0 are removed from sums, operands 1 from products, and
+//     subtracting 0 is removed. A sum or product with a single operand is
+//     replaced by that operand, a negation of a negation by the original
+//     operand.
+//   - Sums contained in sums are merged, as are products contained in
+//     products.
+//   - Conditionals with a constant condition are replaced by the branch taken.
+//   - The negation of a negation via NewNot is replaced by the original
+//     operand.
+//
+// The optimized calculation fails if and only if calculation fails, but when
+// several operands fail, their errors may be combined differently, e.g. the
+// paths of OperandError change if sums are merged. Calculations which are
+// no nodes, e.g. a CalculationInt64Func, are kept as they are.
+func OptimizeInt64(calculation CalculationInt64) CalculationInt64 {
+	return calculationOf(newOptimizer().optimize(NodeOf(calculation))).(CalculationInt64)
+}
+
+// OptimizeBool works like OptimizeInt64, but for bool calculations.
+func OptimizeBool(calculation CalculationBool) CalculationBool {
+	return calculationOf(newOptimizer().optimize(NodeOf(calculation))).(CalculationBool)
+}
+
+// pureKinds contains the kinds whose results only depend on their operands
+// and params.
+var pureKinds = map[Kind]bool{
+	KindConditional:         true,
+	KindSum:                 true,
+	KindProduct:             true,
+	KindDifference:          true,
+	KindNegation:            true,
+	KindSignum:              true,
+	KindCheckedSum:          true,
+	KindCheckedProduct:      true,
+	KindCheckedDifference:   true,
+	KindCheckedNegation:     true,
+	KindAbs:                 true,
+	KindQuotient:            true,
+	KindRemainder:           true,
+	KindEuclideanQuotient:   true,
+	KindEuclideanRemainder:  true,
+	KindFlooredQuotient:     true,
+	KindFlooredModulo:       true,
+	KindNot:                 true,
+	KindAnd:                 true,
+	KindOr:                  true,
+	KindXor:                 true,
+	KindImplies:             true,
+	KindEquivalent:          true,
+	KindEquals:              true,
+	KindNotEquals:           true,
+	KindLess:                true,
+	KindLessOrEqual:         true,
+	KindGreater:             true,
+	KindGreaterOrEqual:      true,
+	KindChainLess:           true,
+	KindChainLessOrEqual:    true,
+	KindChainGreater:        true,
+	KindChainGreaterOrEqual: true,
+	KindBetween:             true,
+	KindInRange:             true,
+}
+
+type optimizer struct {
+	// results contains already optimized nodes.
+	results transformed[Node]
+}
+
+func newOptimizer() *optimizer {
+	return &optimizer{
+		results: make(transformed[Node]),
+	}
+}
+
+func (o *optimizer) optimize(node Node) Node {
+	return o.results.get(node, o.optimizeUncached)
+}
+
+func (o *optimizer) optimizeUncached(node Node) Node {
+	rebuilt := node
+	if children := node.Children(); len(children) > 0 {
+		optimizedChildren := make([]Node, len(children))
+		for i := range children {
+			optimizedChildren[i] = o.optimize(children[i])
+		}
+		var err error
+		rebuilt, err = node.WithChildren(optimizedChildren...)
+		if err != nil {
+			return node
+		}
+	}
+
+	if simplified, ok := simplify(rebuilt); ok {
+		// The simplified node may allow further simplifications.
+		return o.optimize(simplified)
+	}
+	return fold(rebuilt)
+}
+
+// simplify applies all simplifications except folding constants and returns
+// wether one was possible.
+func simplify(node Node) (Node, bool) {
+	children := node.Children()
+
+	switch node.Kind() {
+	case KindSum:
+		return simplifyOperands(node, children, 0, true)
+	case KindProduct:
+		return simplifyOperands(node, children, 1, true)
+	case KindCheckedSum:
+		return simplifyOperands(node, children, 0, false)
+	case KindCheckedProduct:
+		return simplifyOperands(node, children, 1, false)
+	case KindDifference, KindCheckedDifference:
+		if isConstantInt64(children[1], 0) {
+			return children[0], true
+		}
+	case KindNegation:
+		if children[0].Kind() == KindNegation {
+			return children[0].Children()[0], true
+		}
+	case KindNot:
+		if children[0].Kind() == KindNot {
+			return children[0].Children()[0], true
+		}
+	case KindConditional:
+		if condition, ok := constantValue(children[0]).(bool); ok {
+			if condition {
+				return children[1], true
+			}
+			return children[2], true
+		}
+	}
+	return node, false
+}
+
+// simplifyOperands removes operands equal to the neutral element from node.
+// If merge is true, operands of the same kind as node are merged into it. If
+// only one operand is left, it is returned instead of node.
+func simplifyOperands(node Node, children []Node, neutral int64, merge bool) (Node, bool) {
+	operands := []Node{}
+	changed := false
+	for i := range children {
+		switch {
+		case isConstantInt64(children[i], neutral):
+			changed = true
+		case merge && children[i].Kind() == node.Kind():
+			operands = append(operands, children[i].Children()...)
+			changed = true
+		default:
+			operands = append(operands, children[i])
+		}
+	}
+
+	switch {
+	case len(operands) == 0:
+		return NodeOf(NewConstantInt64(neutral)), true
+	case len(operands) == 1:
+		return operands[0], true
+	case !changed:
+		return node, false
+	}
+
+	rebuilt, err := node.WithChildren(operands...)
+	if err != nil {
+		return node, false
+	}
+	return rebuilt, true
+}
+
+// fold replaces node by a constant if possible.
+func fold(node Node) Node {
+	if !pureKinds[node.Kind()] {
+		return node
+	}
+	for _, child := range node.Children() {
+		if child.Kind() != KindConstant {
+			return node
+		}
+	}
+
+	switch calculation := node.(type) {
+	case CalculationInt64:
+		if value, err := calculation.CalculateInt64(); err == nil {
+			return NodeOf(NewConstantInt64(value))
+		}
+	case CalculationBool:
+		if value, err := calculation.CalculateBool(); err == nil {
+			return NodeOf(NewConstantBool(value))
+		}
+	}
+	return node
+}
+
+// constantValue returns the value of node if it is a constant, else nil.
+func constantValue(node Node) interface{} {
+	if node.Kind() != KindConstant {
+		return nil
+	}
+	return node.Params()[0]
+}
+
+func isConstantInt64(node Node, value int64) bool {
+	i, ok := constantValue(node).(int64)
+	return ok && i == value
+}
@@ -0,0 +1,29 @@
+package mmath_test
+
+import (
+	"github.com/GodsBoss/mmath"
+
+	"fmt"
+)
+
+func ExampleOptimizeInt64() {
+	price := mmath.NewNamedVariableInt64("price")
+	discountEnabled := mmath.NewFalse()
+
+	total := mmath.NewSumInt64(
+		mmath.NewProductInt64(price, mmath.NewConstantInt64(1)),
+		mmath.NewConditionalInt64(
+			discountEnabled,
+			mmath.NewNegationInt64(mmath.NewConstantInt64(10)),
+			mmath.NewConstantInt64(0),
+		),
+		mmath.NewSumInt64(mmath.NewConstantInt64(2), mmath.NewConstantInt64(3)),
+	)
+
+	fmt.Println(mmath.FormatFormula(total))
+	fmt.Println(mmath.FormatFormula(mmath.OptimizeInt64(total)))
+
+	// Output:
+	// price * 1 + if(false, -(10), 0) + (2 + 3)
+	// price + 5
+}
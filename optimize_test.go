@@ -0,0 +1,174 @@
+package mmath_test
+
+import (
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+)
+
+func TestOptimizeInt64(t *testing.T) {
+	x := mmath.NewNamedVariableInt64("x")
+	y := mmath.NewNamedVariableInt64("y")
+	p := mmath.NewNamedVariableBool("p")
+	c := mmath.NewConstantInt64
+
+	testcases := map[string]struct {
+		calculation     mmath.CalculationInt64
+		expectedFormula string
+	}{
+		"constants": {
+			calculation:     mmath.NewSumInt64(c(2), mmath.NewProductInt64(c(3), c(4))),
+			expectedFormula: "14",
+		},
+		"partial constants": {
+			calculation:     mmath.NewProductInt64(x, mmath.NewDifferenceInt64(c(10), c(3))),
+			expectedFormula: "x * 7",
+		},
+		"failing constants are kept": {
+			calculation:     mmath.NewQuotientInt64(c(1), c(0)),
+			expectedFormula: "1 / 0",
+		},
+		"neutral operands": {
+			calculation:     mmath.NewSumInt64(c(0), mmath.NewProductInt64(c(1), x, c(1)), mmath.NewDifferenceInt64(y, c(0))),
+			expectedFormula: "x + y",
+		},
+		"checked neutral operands": {
+			calculation:     mmath.NewCheckedProductInt64(c(1), mmath.NewCheckedSumInt64(x, c(0))),
+			expectedFormula: "x",
+		},
+		"single operands": {
+			calculation:     mmath.NewSumInt64(mmath.NewProductInt64(x)),
+			expectedFormula: "x",
+		},
+		"empty sum": {
+			calculation:     mmath.NewProductInt64(mmath.NewSumInt64(), x),
+			expectedFormula: "0 * x",
+		},
+		"nested sums": {
+			calculation:     mmath.NewSumInt64(x, mmath.NewSumInt64(y, mmath.NewSumInt64(x, c(5))), c(1)),
+			expectedFormula: "x + y + x + 5 + 1",
+		},
+		"nested checked sums are kept": {
+			calculation:     mmath.NewCheckedSumInt64(x, mmath.NewCheckedSumInt64(y, x)),
+			expectedFormula: "checkedSum(x, checkedSum(y, x))",
+		},
+		"nested products": {
+			calculation:     mmath.NewProductInt64(mmath.NewProductInt64(x, y), mmath.NewProductInt64(y, x)),
+			expectedFormula: "x * y * y * x",
+		},
+		"double negation": {
+			calculation:     mmath.NewNegationInt64(mmath.NewNegationInt64(mmath.NewSumInt64(x, y))),
+			expectedFormula: "x + y",
+		},
+		"dead branch": {
+			calculation:     mmath.NewConditionalInt64(mmath.NewNot(mmath.NewInt64Less(c(1), c(2))), x, mmath.NewSumInt64(y, c(0))),
+			expectedFormula: "y",
+		},
+		"constant condition": {
+			calculation:     mmath.NewConditionalInt64(mmath.NewNot(mmath.NewNot(mmath.NewTrue())), x, y),
+			expectedFormula: "x",
+		},
+		"constants among variables": {
+			calculation:     mmath.NewSumInt64(x, c(0), mmath.NewProductInt64(c(2), c(3))),
+			expectedFormula: "x + 6",
+		},
+		"constant signum": {
+			calculation:     mmath.NewProductInt64(x, mmath.NewSignumInt64(c(-5))),
+			expectedFormula: "x * -1",
+		},
+		"variable condition": {
+			calculation:     mmath.NewConditionalInt64(mmath.NewNot(mmath.NewNot(p)), x, mmath.NewSumInt64(y, c(0))),
+			expectedFormula: "if(p, x, y)",
+		},
+	}
+
+	for name := range testcases {
+		testcase := testcases[name]
+		t.Run(
+			name,
+			func(t *testing.T) {
+				optimized := mmath.OptimizeInt64(testcase.calculation)
+
+				if formula := mmath.FormatFormula(optimized); formula != testcase.expectedFormula {
+					t.Errorf("expected formula '%s', got '%s'", testcase.expectedFormula, formula)
+				}
+
+				for _, values := range [][3]int64{{0, 0, 0}, {3, -4, 1}, {-7, 2, 0}} {
+					x.Set(values[0])
+					y.Set(values[1])
+					p.Set(values[2] == 1)
+
+					expectedValue, expectedErr := testcase.calculation.CalculateInt64()
+					value, err := optimized.CalculateInt64()
+					if value != expectedValue || (err == nil) != (expectedErr == nil) {
+						t.Errorf("values %v: expected %d and error %+v, got %d and %+v", values, expectedValue, expectedErr, value, err)
+					}
+				}
+			},
+		)
+	}
+}
+
+func TestOptimizeBool(t *testing.T) {
+	p := mmath.NewNamedVariableBool("p")
+	x := mmath.NewNamedVariableInt64("x")
+
+	testcases := map[string]struct {
+		calculation     mmath.CalculationBool
+		expectedFormula string
+	}{
+		"double not": {
+			calculation:     mmath.NewNot(mmath.NewNot(mmath.NewNot(mmath.NewNot(p)))),
+			expectedFormula: "p",
+		},
+		"constants": {
+			calculation:     mmath.NewAnd(mmath.ShortCircuit, mmath.NewTrue(), mmath.NewInt64Less(mmath.NewConstantInt64(1), mmath.NewConstantInt64(2))),
+			expectedFormula: "true",
+		},
+		"constant equality": {
+			calculation:     mmath.NewNot(mmath.NewInt64Equals(mmath.NewConstantInt64(2), mmath.NewConstantInt64(3))),
+			expectedFormula: "true",
+		},
+		"constant bool": {
+			calculation:     mmath.NewNot(mmath.NewNot(mmath.NewConstantBool(false))),
+			expectedFormula: "false",
+		},
+		"partial constants": {
+			calculation:     mmath.NewOr(mmath.Strict, p, mmath.NewInt64Equals(x, mmath.NewSumInt64(mmath.NewConstantInt64(0), mmath.NewConstantInt64(3)))),
+			expectedFormula: "p || x == 3",
+		},
+	}
+
+	for name := range testcases {
+		testcase := testcases[name]
+		t.Run(
+			name,
+			func(t *testing.T) {
+				optimized := mmath.OptimizeBool(testcase.calculation)
+
+				if formula := mmath.FormatFormula(optimized); formula != testcase.expectedFormula {
+					t.Errorf("expected formula '%s', got '%s'", testcase.expectedFormula, formula)
+				}
+			},
+		)
+	}
+}
+
+func TestOptimizeKeepsFunctions(t *testing.T) {
+	calls := 0
+	double := mmath.NewCreateFallibleUnaryInt64(
+		func(value int64) (int64, error) {
+			calls++
+			return 2 * value, nil
+		},
+	)
+
+	optimized := mmath.OptimizeInt64(double(mmath.NewSumInt64(mmath.NewConstantInt64(2), mmath.NewConstantInt64(3))))
+
+	if calls != 0 {
+		t.Errorf("expected function not to be called while optimizing, got %d calls", calls)
+	}
+	if formula := mmath.FormatFormula(optimized); formula != "function(5)" {
+		t.Errorf("expected formula 'function(5)', got '%s'", formula)
+	}
+}
@@ -0,0 +1,283 @@
+package mmath
+
+import (
+	"sync"
+)
+
+// NewParallelInt64 returns a calculation which works like calculation, but
+// calculates the operands of sums, products, comparisons and other operators
+// always calculating all of their operands concurrently. At most workers
+// operands are calculated at the same time, including the calculation done
+// by the calling goroutine. If workers is less than 2, all operands are
+// calculated sequentially.
+//
+// Results and errors are exactly the same as when calculating calculation
+// directly, errors of several operands are combined in the order of the
+// operands. Operands which are not always calculated, like the branches of a
+// conditional or the operands of logical operators using ShortCircuit, are
+// still calculated sequentially, so they are not calculated needlessly.
+//
+// Parallel calculation is only worth it if calculating operands is expensive,
+// e.g. because a CalculationInt64Func queries a database. All calculations
+// involved must be safe for concurrent use.
+func NewParallelInt64(calculation CalculationInt64, workers int) CalculationInt64 {
+	return newParallelizer(workers).parallelize(NodeOf(calculation)).(CalculationInt64)
+}
+
+// NewParallelBool works like NewParallelInt64, but for bool calculations.
+func NewParallelBool(calculation CalculationBool, workers int) CalculationBool {
+	return newParallelizer(workers).parallelize(NodeOf(calculation)).(CalculationBool)
+}
+
+// parallelKinds contains all kinds which always calculate all of their
+// operands. Logical operators only do so when using Strict.
+var parallelKinds = map[Kind]bool{
+	KindFunction:            true,
+	KindSum:                 true,
+	KindProduct:             true,
+	KindDifference:          true,
+	KindCheckedSum:          true,
+	KindCheckedProduct:      true,
+	KindCheckedDifference:   true,
+	KindQuotient:            true,
+	KindRemainder:           true,
+	KindEuclideanQuotient:   true,
+	KindEuclideanRemainder:  true,
+	KindFlooredQuotient:     true,
+	KindFlooredModulo:       true,
+	KindEquals:              true,
+	KindNotEquals:           true,
+	KindLess:                true,
+	KindLessOrEqual:         true,
+	KindGreater:             true,
+	KindGreaterOrEqual:      true,
+	KindChainLess:           true,
+	KindChainLessOrEqual:    true,
+	KindChainGreater:        true,
+	KindChainGreaterOrEqual: true,
+	KindBetween:             true,
+	KindInRange:             true,
+}
+
+var logicalOperatorKinds = map[Kind]bool{
+	KindAnd:        true,
+	KindOr:         true,
+	KindXor:        true,
+	KindImplies:    true,
+	KindEquivalent: true,
+}
+
+type parallelizer struct {
+	// workers limits the number of additional goroutines. It is shared by all
+	// nodes, so nested parallel calculations do not exceed the limit.
+	workers chan struct{}
+
+	// results contains already parallelized nodes.
+	results transformed[interface{}]
+}
+
+func newParallelizer(workers int) *parallelizer {
+	if workers < 1 {
+		workers = 1
+	}
+	return &parallelizer{
+		workers: make(chan struct{}, workers-1),
+		results: make(transformed[interface{}]),
+	}
+}
+
+// parallelize returns a calculation working like node, with all nodes
+// calculating their operands concurrently where possible.
+func (p *parallelizer) parallelize(node Node) interface{} {
+	return p.results.get(node, p.parallelizeUncached)
+}
+
+func (p *parallelizer) parallelizeUncached(node Node) interface{} {
+	children := node.Children()
+	if len(children) == 0 {
+		return calculationOf(node)
+	}
+
+	parallelChildren := make([]Node, len(children))
+	for i := range children {
+		parallelChildren[i] = NodeOf(p.parallelize(children[i]))
+	}
+	rebuilt, err := node.WithChildren(parallelChildren...)
+	if err != nil {
+		return calculationOf(node)
+	}
+	if len(children) < 2 || !isParallelKind(node) {
+		return calculationOf(rebuilt)
+	}
+
+	switch n := rebuilt.(type) {
+	case *int64Node:
+		if calculate := p.int64Operation(n); calculate != nil {
+			return &parallelInt64Node{
+				Node:      rebuilt,
+				calculate: calculate,
+			}
+		}
+	case *boolNode:
+		if calculate := p.boolOperation(n); calculate != nil {
+			return &parallelBoolNode{
+				Node:      rebuilt,
+				calculate: calculate,
+			}
+		}
+	}
+	return calculationOf(rebuilt)
+}
+
+func isParallelKind(node Node) bool {
+	if logicalOperatorKinds[node.Kind()] {
+		params := node.Params()
+		return len(params) == 1 && params[0] == Strict
+	}
+	return parallelKinds[node.Kind()]
+}
+
+// int64Operation returns a function calculating the operands of node
+// concurrently, then applying the operation of node to their values. It
+// returns nil if the operation of node is not known.
+func (p *parallelizer) int64Operation(node *int64Node) func() (int64, error) {
+	operands := int64Operands(node.children)
+	switch operation := node.operation.(type) {
+	case func(left, right int64) (int64, error):
+		return parallelCalculation(p, operands, func(values []int64) (int64, error) {
+			return operation(values[0], values[1])
+		})
+	case func(values []int64) (int64, error):
+		return parallelCalculation(p, operands, operation)
+	case reduceLeft:
+		// Only reductions whose initial value is no child, e.g. sums, always
+		// calculate all of their children.
+		if operation.firstIndex != 0 {
+			return nil
+		}
+		return parallelCalculation(p, operands, func(values []int64) (int64, error) {
+			initialValue, err := operation.initialValue.CalculateInt64()
+			if err != nil {
+				return 0, err
+			}
+			return operation.fold(initialValue, values)
+		})
+	}
+	return nil
+}
+
+// boolOperation works like int64Operation, but for bool nodes.
+func (p *parallelizer) boolOperation(node *boolNode) func() (bool, error) {
+	switch operation := node.operation.(type) {
+	case func(first, second int64) bool:
+		return parallelCalculation(p, int64Operands(node.children), func(values []int64) (bool, error) {
+			return holdsForNeighbours(operation, values), nil
+		})
+	case func(values []int64) bool:
+		return parallelCalculation(p, int64Operands(node.children), func(values []int64) (bool, error) {
+			return operation(values), nil
+		})
+	case func(left, right bool) (bool, error):
+		return parallelCalculation(p, boolOperands(node.children), func(values []bool) (bool, error) {
+			return operation(values[0], values[1])
+		})
+	case func(values []bool) (bool, error):
+		return parallelCalculation(p, boolOperands(node.children), operation)
+	case func(values []bool) bool:
+		return parallelCalculation(p, boolOperands(node.children), func(values []bool) (bool, error) {
+			return operation(values), nil
+		})
+	}
+	return nil
+}
+
+func int64Operands(children []interface{}) []func() (int64, error) {
+	operands := make([]func() (int64, error), len(children))
+	for i := range children {
+		operands[i] = children[i].(CalculationInt64).CalculateInt64
+	}
+	return operands
+}
+
+func boolOperands(children []interface{}) []func() (bool, error) {
+	operands := make([]func() (bool, error), len(children))
+	for i := range children {
+		operands[i] = children[i].(CalculationBool).CalculateBool
+	}
+	return operands
+}
+
+// parallelCalculation returns a function calculating all operands
+// concurrently. If one or more of them fail, an error combining those errors
+// is returned, else the result of applying operation to their values.
+func parallelCalculation[T, R any](
+	p *parallelizer,
+	operands []func() (T, error),
+	operation func(values []T) (R, error),
+) func() (R, error) {
+	return func() (R, error) {
+		values := make([]T, len(operands))
+		errs := make([]error, len(operands))
+		tasks := make([]func(), len(operands))
+		for i := range operands {
+			i := i
+			tasks[i] = func() {
+				values[i], errs[i] = operands[i]()
+			}
+		}
+		p.run(tasks)
+
+		if err := CombineErrors(errs...); err != nil {
+			var zero R
+			return zero, err
+		}
+		return operation(values)
+	}
+}
+
+// run runs all tasks and waits for them to finish. Tasks are run in new
+// goroutines as long as the worker limit allows it, else in the calling
+// goroutine. Never waiting for a free worker prevents deadlocks caused by
+// nested parallel calculations.
+func (p *parallelizer) run(tasks []func()) {
+	var wg sync.WaitGroup
+	for i := range tasks {
+		if i == len(tasks)-1 {
+			tasks[i]()
+			break
+		}
+		select {
+		case p.workers <- struct{}{}:
+			wg.Add(1)
+			go func(task func()) {
+				defer func() {
+					<-p.workers
+					wg.Done()
+				}()
+				task()
+			}(tasks[i])
+		default:
+			tasks[i]()
+		}
+	}
+	wg.Wait()
+}
+
+// parallelInt64Node calculates its operands concurrently.
+type parallelInt64Node struct {
+	Node
+	calculate func() (int64, error)
+}
+
+func (node *parallelInt64Node) CalculateInt64() (int64, error) {
+	return node.calculate()
+}
+
+type parallelBoolNode struct {
+	Node
+	calculate func() (bool, error)
+}
+
+func (node *parallelBoolNode) CalculateBool() (bool, error) {
+	return node.calculate()
+}
@@ -0,0 +1,35 @@
+package mmath_test
+
+import (
+	"github.com/GodsBoss/mmath"
+
+	"fmt"
+	"time"
+)
+
+func ExampleNewParallelInt64() {
+	// lookup simulates an expensive calculation, e.g. a database query.
+	lookup := func(value int64) mmath.CalculationInt64 {
+		return mmath.CalculationInt64Func(
+			func() (int64, error) {
+				time.Sleep(10 * time.Millisecond)
+				return value, nil
+			},
+		)
+	}
+
+	total := mmath.NewParallelInt64(
+		mmath.NewSumInt64(lookup(100), lookup(20), lookup(3)),
+		3,
+	)
+
+	v, err := total.CalculateInt64()
+
+	fmt.Printf("Value is %d.\n", v)
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+	}
+
+	// Output:
+	// Value is 123.
+}
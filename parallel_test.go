@@ -0,0 +1,272 @@
+package mmath_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/GodsBoss/mmath"
+)
+
+func TestParallelInt64CalculatesLikeCalculation(t *testing.T) {
+	x := mmath.NewVariableInt64()
+	y := mmath.NewVariableInt64()
+	calculation := mmath.NewSumInt64(
+		mmath.NewProductInt64(x, mmath.NewConstantInt64(3)),
+		mmath.NewConditionalInt64(
+			mmath.NewInt64Less(x, y),
+			mmath.NewDifferenceInt64(y, x),
+			mmath.NewQuotientInt64(x, y),
+		),
+		mmath.NewCheckedSumInt64(x, y, mmath.NewConstantInt64(1)),
+	)
+	parallel := mmath.NewParallelInt64(calculation, 4)
+
+	for _, values := range [][2]int64{{1, 2}, {5, 2}, {3, 0}, {-7, -7}} {
+		x.Set(values[0])
+		y.Set(values[1])
+
+		expectedValue, expectedErr := calculation.CalculateInt64()
+		value, err := parallel.CalculateInt64()
+
+		if value != expectedValue {
+			t.Errorf("x=%d, y=%d: expected %d, got %d", values[0], values[1], expectedValue, value)
+		}
+		if (err == nil) != (expectedErr == nil) || (err != nil && err.Error() != expectedErr.Error()) {
+			t.Errorf("x=%d, y=%d: expected error %+v, got %+v", values[0], values[1], expectedErr, err)
+		}
+	}
+}
+
+func TestParallelInt64KeepsOrderOfErrors(t *testing.T) {
+	delayedFailure := func(delay time.Duration, message string) mmath.CalculationInt64 {
+		return mmath.CalculationInt64Func(
+			func() (int64, error) {
+				time.Sleep(delay)
+				return 0, errors.New(message)
+			},
+		)
+	}
+	calculation := mmath.NewParallelInt64(
+		mmath.NewSumInt64(
+			delayedFailure(30*time.Millisecond, "first"),
+			mmath.NewConstantInt64(1),
+			delayedFailure(20*time.Millisecond, "second"),
+			delayedFailure(10*time.Millisecond, "third"),
+		),
+		4,
+	)
+
+	_, err := calculation.CalculateInt64()
+
+	expected := "multiple errors: first; second; third"
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected error '%s', got %+v", expected, err)
+	}
+}
+
+func TestParallelCalculatesOperandsOnlyOnce(t *testing.T) {
+	var count int64
+	counted := mmath.CalculationInt64Func(
+		func() (int64, error) {
+			atomic.AddInt64(&count, 1)
+			return 1, nil
+		},
+	)
+	failing := mmath.NewFailingCalculation(errors.New("broken"))
+	failingInt64 := mmath.NewCreateFallibleUnaryInt64(
+		func(int64) (int64, error) {
+			return 0, errors.New("broken")
+		},
+	)(mmath.NewConstantInt64(0))
+	calculations := map[string]func() error{
+		"sum": func() error {
+			_, err := mmath.NewParallelInt64(mmath.NewSumInt64(counted, failing), 2).CalculateInt64()
+			return err
+		},
+		"quotient": func() error {
+			_, err := mmath.NewParallelInt64(mmath.NewQuotientInt64(counted, mmath.NewConstantInt64(0)), 2).CalculateInt64()
+			return err
+		},
+		"comparison": func() error {
+			_, err := mmath.NewParallelBool(mmath.NewInt64Less(counted, failing), 2).CalculateBool()
+			return err
+		},
+		"customNode": func() error {
+			_, err := mmath.NewParallelInt64(&customSum{children: []mmath.Node{countedLeaf{&count}, mmath.NodeOf(failingInt64)}}, 2).CalculateInt64()
+			return err
+		},
+		"strictAnd": func() error {
+			_, err := mmath.NewParallelBool(
+				mmath.NewAnd(mmath.Strict, mmath.NewInt64Less(counted, counted), mmath.NewFailingCalculation(errors.New("broken"))),
+				2,
+			).CalculateBool()
+			return err
+		},
+	}
+	expectedCounts := map[string]int64{
+		"sum":        1,
+		"quotient":   1,
+		"comparison": 1,
+		"customNode": 1,
+		"strictAnd":  2,
+	}
+
+	for name, calculate := range calculations {
+		atomic.StoreInt64(&count, 0)
+
+		if err := calculate(); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+		if actual := atomic.LoadInt64(&count); actual != expectedCounts[name] {
+			t.Errorf("%s: expected %d calculations, got %d", name, expectedCounts[name], actual)
+		}
+	}
+}
+
+// customSum is a sum not created by this package. It rejects opaque children.
+type customSum struct {
+	children []mmath.Node
+}
+
+func (sum *customSum) Kind() mmath.Kind       { return mmath.KindSum }
+func (sum *customSum) Children() []mmath.Node { return sum.children }
+func (sum *customSum) Params() []interface{}  { return nil }
+func (sum *customSum) WithChildren(children ...mmath.Node) (mmath.Node, error) {
+	for i := range children {
+		if children[i].Kind() == mmath.KindOpaque {
+			return nil, errors.New("opaque children are not supported")
+		}
+	}
+	return &customSum{children: children}, nil
+}
+
+func (sum *customSum) CalculateInt64() (int64, error) {
+	operands := make([]mmath.CalculationInt64, len(sum.children))
+	for i := range sum.children {
+		operands[i] = sum.children[i].(mmath.CalculationInt64)
+	}
+	return mmath.NewSumInt64(operands...).CalculateInt64()
+}
+
+// countedLeaf counts how often it is calculated.
+type countedLeaf struct {
+	count *int64
+}
+
+func (leaf countedLeaf) Kind() mmath.Kind       { return "counted" }
+func (leaf countedLeaf) Children() []mmath.Node { return nil }
+func (leaf countedLeaf) Params() []interface{}  { return nil }
+func (leaf countedLeaf) WithChildren(children ...mmath.Node) (mmath.Node, error) {
+	return leaf, nil
+}
+
+func (leaf countedLeaf) CalculateInt64() (int64, error) {
+	atomic.AddInt64(leaf.count, 1)
+	return 1, nil
+}
+
+func TestParallelInt64CalculatesOperandsConcurrently(t *testing.T) {
+	var started sync.WaitGroup
+	started.Add(3)
+	operand := mmath.CalculationInt64Func(
+		func() (int64, error) {
+			started.Done()
+			started.Wait()
+			return 1, nil
+		},
+	)
+	calculation := mmath.NewParallelInt64(mmath.NewProductInt64(operand, operand, operand), 3)
+
+	result := make(chan int64)
+	go func() {
+		v, _ := calculation.CalculateInt64()
+		result <- v
+	}()
+
+	select {
+	case v := <-result:
+		if v != 1 {
+			t.Errorf("expected 1, got %d", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("operands were not calculated concurrently")
+	}
+}
+
+func TestParallelInt64LimitsWorkers(t *testing.T) {
+	workers := 3
+	running := int32(0)
+	maxRunning := int32(0)
+	operand := mmath.CalculationInt64Func(
+		func() (int64, error) {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return 1, nil
+		},
+	)
+	inner := func() mmath.CalculationInt64 {
+		return mmath.NewSumInt64(operand, operand, operand, operand)
+	}
+	calculation := mmath.NewParallelInt64(mmath.NewSumInt64(inner(), inner(), inner()), workers)
+
+	v, err := calculation.CalculateInt64()
+	if v != 12 || err != nil {
+		t.Errorf("expected 12 and no error, got %d and %+v", v, err)
+	}
+	if maxRunning > int32(workers) {
+		t.Errorf("expected at most %d operands calculated at once, got %d", workers, maxRunning)
+	}
+}
+
+func TestParallelBoolKeepsShortCircuitEvaluation(t *testing.T) {
+	calls := int32(0)
+	counted := mmath.CalculationBoolFunc(
+		func() (bool, error) {
+			atomic.AddInt32(&calls, 1)
+			return true, nil
+		},
+	)
+
+	testcases := map[string]struct {
+		strategy      mmath.EvaluationStrategy
+		expectedCalls int32
+	}{
+		"short-circuit": {
+			strategy:      mmath.ShortCircuit,
+			expectedCalls: 0,
+		},
+		"strict": {
+			strategy:      mmath.Strict,
+			expectedCalls: 2,
+		},
+	}
+
+	for name := range testcases {
+		testcase := testcases[name]
+		t.Run(
+			name,
+			func(t *testing.T) {
+				atomic.StoreInt32(&calls, 0)
+				calculation := mmath.NewParallelBool(mmath.NewAnd(testcase.strategy, mmath.NewFalse(), counted, counted), 4)
+
+				b, err := calculation.CalculateBool()
+				if b || err != nil {
+					t.Errorf("expected false and no error, got %t and %+v", b, err)
+				}
+				if calls != testcase.expectedCalls {
+					t.Errorf("expected %d calls, got %d", testcase.expectedCalls, calls)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,48 @@
+// Package parser builds calculations from formulas written in a small infix
+// expression language, e.g.
+//
+//	if(stock < 10, price * 2, price)
+//
+// The language knows two types, int64 and bool.
+//
+// Literals are decimal integers like 42 and the boolean constants true and
+// false.
+//
+// Identifiers refer to variables, which must be passed to the parser via
+// Variables. Identifiers start with a letter or an underscore, followed by
+// letters, digits and underscores.
+//
+// Operators, from lowest to highest precedence:
+//
+//	||                  logical or (short-circuit)
+//	&&                  logical and (short-circuit)
+//	== != < <= > >=     comparisons
+//	+ -                 sum, difference
+//	* / %               product, quotient, remainder
+//	- !                 unary negation, logical not
+//
+// == and != compare either two int64 or two bool values and cannot be
+// chained. The ordering comparisons may be chained like in a < b < c, which is
+// true if every single comparison is true. Different comparisons cannot be
+// mixed in one chain, e.g. a < b <= c and a < b == c are rejected, so
+// parentheses are needed. Arithmetic wraps around on overflow like
+// NewSumInt64, division and remainder truncate towards zero and fail when
+// dividing by zero.
+//
+// Functions:
+//
+//	if(condition, ifTrue, ifFalse)   conditional, branches must be int64
+//	abs(x)                           absolute value, fails for math.MinInt64
+//	sign(x)                          signum
+//	between(x, lower, upper)         lower <= x <= upper
+//
+// Parentheses can be used for grouping. Expressions may be nested at most 1000
+// levels deep.
+//
+// mmath.FormatFormula prints calculations using this syntax, so formulas of
+// calculations built only from constants, named variables and the operators
+// and functions above can be parsed again. Other calculations, e.g. checked
+// arithmetic, reductions, failing calculations, functions created via
+// mmath.NewCreateBinaryInt64 and the like or calculations which are no nodes,
+// are printed in a notation this package does not parse.
+package parser
@@ -0,0 +1,27 @@
+package parser
+
+import (
+	"fmt"
+)
+
+// SyntaxError is returned if an expression cannot be parsed, including type
+// errors like adding a bool to an int64 and references to unknown variables.
+type SyntaxError struct {
+	// Position is the 1-based position of the character in the input where the
+	// error was detected.
+	Position int
+
+	// Message describes the error.
+	Message string
+}
+
+func newSyntaxError(position int, format string, args ...interface{}) *SyntaxError {
+	return &SyntaxError{
+		Position: position,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+func (err *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at position %d: %s", err.Position, err.Message)
+}
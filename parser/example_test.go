@@ -0,0 +1,52 @@
+package parser_test
+
+import (
+	"github.com/GodsBoss/mmath"
+	"github.com/GodsBoss/mmath/parser"
+
+	"fmt"
+)
+
+func ExampleParseInt64() {
+	stock := mmath.NewVariableInt64()
+	price := mmath.NewVariableInt64()
+
+	calc, err := parser.ParseInt64(
+		"if(stock < 10, price * 2, price)",
+		parser.Variables{
+			Int64: map[string]mmath.VariableInt64{
+				"stock": stock,
+				"price": price,
+			},
+		},
+	)
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+		return
+	}
+
+	price.Set(30)
+	for _, s := range []int64{5, 50} {
+		stock.Set(s)
+
+		v, err := calc.CalculateInt64()
+
+		fmt.Printf("Value is %d.\n", v)
+		if err != nil {
+			fmt.Printf("Error is: %v\n", err)
+		}
+	}
+
+	// Output:
+	// Value is 60.
+	// Value is 30.
+}
+
+func ExampleSyntaxError() {
+	_, err := parser.ParseInt64("1 + * 2", parser.Variables{})
+
+	fmt.Println(err)
+
+	// Output:
+	// syntax error at position 5: unexpected '*'
+}
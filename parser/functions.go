@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"github.com/GodsBoss/mmath"
+)
+
+// function creates a calculation from already parsed arguments. Arguments have
+// already been checked to match the parameter types.
+type function struct {
+	parameters []string
+	create     func(args []value, position int) value
+}
+
+var functions = map[string]function{
+	"if": {
+		parameters: []string{"bool", "int64", "int64"},
+		create: func(args []value, position int) value {
+			return int64Value(mmath.NewConditionalInt64(args[0].boolCalc, args[1].int64Calc, args[2].int64Calc), position)
+		},
+	},
+	"abs": {
+		parameters: []string{"int64"},
+		create: func(args []value, position int) value {
+			return int64Value(mmath.NewCheckedAbsInt64(args[0].int64Calc), position)
+		},
+	},
+	"sign": {
+		parameters: []string{"int64"},
+		create: func(args []value, position int) value {
+			return int64Value(mmath.NewSignumInt64(args[0].int64Calc), position)
+		},
+	},
+	"between": {
+		parameters: []string{"int64", "int64", "int64"},
+		create: func(args []value, position int) value {
+			return boolValue(mmath.NewInt64Between(args[0].int64Calc, args[1].int64Calc, args[2].int64Calc), position)
+		},
+	},
+}
+
+func (p *parser) parseCall(name token) (value, error) {
+	f, ok := functions[name.text]
+	if !ok {
+		return value{}, newSyntaxError(name.position, "unknown function '%s'", name.text)
+	}
+
+	// Skip the left parenthesis, which has already been checked by the caller.
+	p.next()
+
+	var args []value
+	if p.peek().kind != tokenRightParen {
+		for {
+			arg, err := p.parseExpression()
+			if err != nil {
+				return value{}, err
+			}
+			args = append(args, arg)
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.next()
+		}
+	}
+	closing, err := p.expect(tokenRightParen, "',' or ')'")
+	if err != nil {
+		return value{}, err
+	}
+
+	if len(args) != len(f.parameters) {
+		return value{}, newSyntaxError(closing.position, "%s expects %d arguments, got %d", name.text, len(f.parameters), len(args))
+	}
+	for i := range args {
+		if args[i].typeName() != f.parameters[i] {
+			return value{}, newSyntaxError(
+				args[i].position,
+				"argument %d of %s must be %s, got %s",
+				i+1,
+				name.text,
+				f.parameters[i],
+				args[i].typeName(),
+			)
+		}
+	}
+
+	return f.create(args, name.position), nil
+}
@@ -0,0 +1,124 @@
+package parser
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenInteger
+	tokenIdentifier
+	tokenOperator
+	tokenLeftParen
+	tokenRightParen
+	tokenComma
+)
+
+type token struct {
+	kind tokenKind
+
+	// text is the token as it appears in the input.
+	text string
+
+	// position is the 1-based position of the first character of the token.
+	position int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of input"
+	}
+	return "'" + t.text + "'"
+}
+
+// operators contains all operators, longer ones first so that the lexer
+// prefers "<=" over "<".
+var operators = []string{
+	"||", "&&", "==", "!=", "<=", ">=",
+	"<", ">", "+", "-", "*", "/", "%", "!",
+}
+
+// tokenize splits input into tokens. The last token is always of kind tokenEOF.
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+
+	// i is the byte offset in input, position the 1-based position of the
+	// character at i. Apart from unexpected characters, all characters in
+	// tokens are ASCII, so they advance both by one.
+	position := 1
+	for i := 0; i < len(input); {
+		r, size := utf8.DecodeRuneInString(input[i:])
+		start, startPosition := i, position
+
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			i++
+			position++
+			continue
+		case isDigit(r):
+			for i < len(input) && isDigit(rune(input[i])) {
+				i++
+			}
+			position += i - start
+			tokens = append(tokens, token{kind: tokenInteger, text: input[start:i], position: startPosition})
+			continue
+		case isIdentifierStart(r):
+			for i < len(input) && (isIdentifierStart(rune(input[i])) || isDigit(rune(input[i]))) {
+				i++
+			}
+			position += i - start
+			tokens = append(tokens, token{kind: tokenIdentifier, text: input[start:i], position: startPosition})
+			continue
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLeftParen, text: "(", position: startPosition})
+			i++
+			position++
+			continue
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRightParen, text: ")", position: startPosition})
+			i++
+			position++
+			continue
+		case r == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", position: startPosition})
+			i++
+			position++
+			continue
+		}
+
+		operator := matchOperator(input[i:])
+		if operator == "" {
+			if r == utf8.RuneError && size == 1 {
+				return nil, newSyntaxError(startPosition, "invalid UTF-8")
+			}
+			return nil, newSyntaxError(startPosition, "unexpected character '%c'", r)
+		}
+		tokens = append(tokens, token{kind: tokenOperator, text: operator, position: startPosition})
+		i += len(operator)
+		position += len(operator)
+	}
+
+	return append(tokens, token{kind: tokenEOF, position: position}), nil
+}
+
+// matchOperator returns the operator rest starts with, or an empty string if
+// there is none.
+func matchOperator(rest string) string {
+	for _, operator := range operators {
+		if strings.HasPrefix(rest, operator) {
+			return operator
+		}
+	}
+	return ""
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isIdentifierStart(r rune) bool {
+	return r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
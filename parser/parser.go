@@ -0,0 +1,430 @@
+package parser
+
+import (
+	"strconv"
+
+	"github.com/GodsBoss/mmath"
+)
+
+// Variables binds identifiers used in expressions to variables. Names must be
+// unique across both maps.
+type Variables = mmath.Variables
+
+// ParseInt64 parses an expression which must be of type int64. If the input
+// cannot be parsed, a *SyntaxError is returned.
+func ParseInt64(input string, variables Variables) (mmath.CalculationInt64, error) {
+	v, err := parse(input, variables)
+	if err != nil {
+		return nil, err
+	}
+	return v.asInt64()
+}
+
+// ParseBool parses an expression which must be of type bool. If the input
+// cannot be parsed, a *SyntaxError is returned.
+func ParseBool(input string, variables Variables) (mmath.CalculationBool, error) {
+	v, err := parse(input, variables)
+	if err != nil {
+		return nil, err
+	}
+	return v.asBool()
+}
+
+func parse(input string, variables Variables) (value, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return value{}, err
+	}
+	p := &parser{
+		tokens:    tokens,
+		variables: variables,
+	}
+	v, err := p.parseExpression()
+	if err != nil {
+		return value{}, err
+	}
+	if next := p.peek(); next.kind != tokenEOF {
+		return value{}, newSyntaxError(next.position, "unexpected %s", next)
+	}
+	return v, nil
+}
+
+// value is a parsed sub-expression. Exactly one of int64Calc and boolCalc is
+// set.
+type value struct {
+	int64Calc mmath.CalculationInt64
+	boolCalc  mmath.CalculationBool
+
+	// position is the position of the first character of the sub-expression.
+	position int
+}
+
+func int64Value(calc mmath.CalculationInt64, position int) value {
+	return value{int64Calc: calc, position: position}
+}
+
+func boolValue(calc mmath.CalculationBool, position int) value {
+	return value{boolCalc: calc, position: position}
+}
+
+func (v value) typeName() string {
+	if v.int64Calc != nil {
+		return "int64"
+	}
+	return "bool"
+}
+
+func (v value) asInt64() (mmath.CalculationInt64, error) {
+	if v.int64Calc == nil {
+		return nil, newSyntaxError(v.position, "expected int64 expression, got %s", v.typeName())
+	}
+	return v.int64Calc, nil
+}
+
+func (v value) asBool() (mmath.CalculationBool, error) {
+	if v.boolCalc == nil {
+		return nil, newSyntaxError(v.position, "expected bool expression, got %s", v.typeName())
+	}
+	return v.boolCalc, nil
+}
+
+// maxDepth is the maximum nesting depth of expressions, e.g. of parentheses,
+// function calls and unary operators. It prevents hostile input from
+// exhausting the stack.
+const maxDepth = 1000
+
+type parser struct {
+	tokens    []token
+	current   int
+	variables Variables
+
+	// depth is the current nesting depth, see maxDepth.
+	depth int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.current]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.current]
+	if t.kind != tokenEOF {
+		p.current++
+	}
+	return t
+}
+
+func (p *parser) isOperator(operators ...string) bool {
+	t := p.peek()
+	if t.kind != tokenOperator {
+		return false
+	}
+	for _, operator := range operators {
+		if t.text == operator {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) expect(kind tokenKind, description string) (token, error) {
+	t := p.next()
+	if t.kind != kind {
+		return t, newSyntaxError(t.position, "expected %s, got %s", description, t)
+	}
+	return t, nil
+}
+
+func (p *parser) parseExpression() (value, error) {
+	return p.parseLogical("||", p.parseAnd, mmath.NewOr)
+}
+
+func (p *parser) parseAnd() (value, error) {
+	return p.parseLogical("&&", p.parseComparison, mmath.NewAnd)
+}
+
+// parseLogical parses a sequence of operands joined by operator and combines
+// all of them into a single calculation.
+func (p *parser) parseLogical(
+	operator string,
+	parseOperand func() (value, error),
+	create func(mmath.EvaluationStrategy, ...mmath.CalculationBool) mmath.CalculationBool,
+) (value, error) {
+	first, err := parseOperand()
+	if err != nil {
+		return value{}, err
+	}
+	if !p.isOperator(operator) {
+		return first, nil
+	}
+
+	firstCalc, err := first.asBool()
+	if err != nil {
+		return value{}, err
+	}
+	operands := []mmath.CalculationBool{firstCalc}
+	for p.isOperator(operator) {
+		p.next()
+		operand, err := parseOperand()
+		if err != nil {
+			return value{}, err
+		}
+		calc, err := operand.asBool()
+		if err != nil {
+			return value{}, err
+		}
+		operands = append(operands, calc)
+	}
+	return boolValue(create(mmath.ShortCircuit, operands...), first.position), nil
+}
+
+var orderingComparisons = map[string]func(...mmath.CalculationInt64) mmath.CalculationBool{
+	"<":  mmath.NewInt64ChainLess,
+	"<=": mmath.NewInt64ChainLessOrEqual,
+	">":  mmath.NewInt64ChainGreater,
+	">=": mmath.NewInt64ChainGreaterOrEqual,
+}
+
+func (p *parser) parseComparison() (value, error) {
+	first, err := p.parseAdditive()
+	if err != nil {
+		return value{}, err
+	}
+
+	if p.isOperator("==", "!=") {
+		operator := p.next()
+		second, err := p.parseAdditive()
+		if err != nil {
+			return value{}, err
+		}
+		if p.isOperator("==", "!=", "<", "<=", ">", ">=") {
+			return value{}, newSyntaxError(p.peek().position, "'%s' cannot be chained", operator.text)
+		}
+		return p.createEquality(operator, first, second)
+	}
+
+	if !p.isOperator("<", "<=", ">", ">=") {
+		return first, nil
+	}
+	operator := p.peek()
+	firstCalc, err := first.asInt64()
+	if err != nil {
+		return value{}, err
+	}
+	operands := []mmath.CalculationInt64{firstCalc}
+	for p.isOperator("<", "<=", ">", ">=", "==", "!=") {
+		t := p.next()
+		if t.text != operator.text {
+			return value{}, newSyntaxError(t.position, "'%s' cannot be chained with '%s'", t.text, operator.text)
+		}
+		operand, err := p.parseAdditive()
+		if err != nil {
+			return value{}, err
+		}
+		calc, err := operand.asInt64()
+		if err != nil {
+			return value{}, err
+		}
+		operands = append(operands, calc)
+	}
+	return boolValue(orderingComparisons[operator.text](operands...), first.position), nil
+}
+
+func (p *parser) createEquality(operator token, first, second value) (value, error) {
+	if first.typeName() != second.typeName() {
+		return value{}, newSyntaxError(operator.position, "cannot compare %s with %s", first.typeName(), second.typeName())
+	}
+	if first.int64Calc != nil {
+		if operator.text == "==" {
+			return boolValue(mmath.NewInt64Equals(first.int64Calc, second.int64Calc), first.position), nil
+		}
+		return boolValue(mmath.NewInt64NotEquals(first.int64Calc, second.int64Calc), first.position), nil
+	}
+	if operator.text == "==" {
+		return boolValue(mmath.NewEquivalent(mmath.ShortCircuit, first.boolCalc, second.boolCalc), first.position), nil
+	}
+	return boolValue(mmath.NewXor(mmath.ShortCircuit, first.boolCalc, second.boolCalc), first.position), nil
+}
+
+func (p *parser) parseAdditive() (value, error) {
+	return p.parseArithmetic(
+		[]string{"+", "-"},
+		p.parseMultiplicative,
+		"+",
+		mmath.NewSumInt64,
+		map[string]func(left, right mmath.CalculationInt64) mmath.CalculationInt64{
+			"-": func(left, right mmath.CalculationInt64) mmath.CalculationInt64 {
+				return mmath.NewDifferenceInt64(left, right)
+			},
+		},
+	)
+}
+
+func (p *parser) parseMultiplicative() (value, error) {
+	return p.parseArithmetic(
+		[]string{"*", "/", "%"},
+		p.parseUnary,
+		"*",
+		mmath.NewProductInt64,
+		map[string]func(left, right mmath.CalculationInt64) mmath.CalculationInt64{
+			"/": func(left, right mmath.CalculationInt64) mmath.CalculationInt64 {
+				return mmath.NewQuotientInt64(left, right)
+			},
+			"%": func(left, right mmath.CalculationInt64) mmath.CalculationInt64 {
+				return mmath.NewRemainderInt64(left, right)
+			},
+		},
+	)
+}
+
+// parseArithmetic parses left-associative arithmetic operators of the same
+// precedence. Consecutive operands joined by the associative operator are
+// combined into a single n-ary calculation created by nary, e.g. a + b + c
+// becomes one sum, other operators are binary.
+func (p *parser) parseArithmetic(
+	operators []string,
+	parseOperand func() (value, error),
+	associative string,
+	nary func(...mmath.CalculationInt64) mmath.CalculationInt64,
+	binary map[string]func(left, right mmath.CalculationInt64) mmath.CalculationInt64,
+) (value, error) {
+	first, err := parseOperand()
+	if err != nil {
+		return value{}, err
+	}
+	if !p.isOperator(operators...) {
+		return first, nil
+	}
+
+	firstCalc, err := first.asInt64()
+	if err != nil {
+		return value{}, err
+	}
+	operands := []mmath.CalculationInt64{firstCalc}
+
+	// combine returns the calculation represented by the operands collected so
+	// far.
+	combine := func() mmath.CalculationInt64 {
+		if len(operands) == 1 {
+			return operands[0]
+		}
+		return nary(operands...)
+	}
+
+	for p.isOperator(operators...) {
+		operator := p.next()
+		operand, err := parseOperand()
+		if err != nil {
+			return value{}, err
+		}
+		calc, err := operand.asInt64()
+		if err != nil {
+			return value{}, err
+		}
+		if operator.text == associative {
+			operands = append(operands, calc)
+			continue
+		}
+		operands = []mmath.CalculationInt64{binary[operator.text](combine(), calc)}
+	}
+
+	return int64Value(combine(), first.position), nil
+}
+
+func (p *parser) parseUnary() (value, error) {
+	// Every nested expression is parsed via parseUnary, so this is the place
+	// for limiting the depth.
+	p.depth++
+	defer func() {
+		p.depth--
+	}()
+	if p.depth > maxDepth {
+		return value{}, newSyntaxError(p.peek().position, "expression nested too deeply")
+	}
+
+	if p.isOperator("!") {
+		operator := p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return value{}, err
+		}
+		calc, err := operand.asBool()
+		if err != nil {
+			return value{}, err
+		}
+		return boolValue(mmath.NewNot(calc), operator.position), nil
+	}
+
+	if p.isOperator("-") {
+		operator := p.next()
+
+		// A minus directly followed by an integer is a negative literal. This
+		// makes the smallest int64 expressible.
+		if literal := p.peek(); literal.kind == tokenInteger {
+			p.next()
+			return p.parseInteger("-"+literal.text, operator.position)
+		}
+
+		operand, err := p.parseUnary()
+		if err != nil {
+			return value{}, err
+		}
+		calc, err := operand.asInt64()
+		if err != nil {
+			return value{}, err
+		}
+		return int64Value(mmath.NewNegationInt64(calc), operator.position), nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (value, error) {
+	t := p.next()
+
+	switch t.kind {
+	case tokenInteger:
+		return p.parseInteger(t.text, t.position)
+	case tokenIdentifier:
+		if p.peek().kind == tokenLeftParen {
+			return p.parseCall(t)
+		}
+		return p.parseIdentifier(t)
+	case tokenLeftParen:
+		v, err := p.parseExpression()
+		if err != nil {
+			return value{}, err
+		}
+		if _, err := p.expect(tokenRightParen, "')'"); err != nil {
+			return value{}, err
+		}
+		v.position = t.position
+		return v, nil
+	default:
+		return value{}, newSyntaxError(t.position, "unexpected %s", t)
+	}
+}
+
+func (p *parser) parseInteger(text string, position int) (value, error) {
+	i, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return value{}, newSyntaxError(position, "integer %s out of range", text)
+	}
+	return int64Value(mmath.NewConstantInt64(i), position), nil
+}
+
+func (p *parser) parseIdentifier(t token) (value, error) {
+	switch t.text {
+	case "true":
+		return boolValue(mmath.NewTrue(), t.position), nil
+	case "false":
+		return boolValue(mmath.NewFalse(), t.position), nil
+	}
+	if v, ok := p.variables.Int64[t.text]; ok {
+		return int64Value(v, t.position), nil
+	}
+	if v, ok := p.variables.Bool[t.text]; ok {
+		return boolValue(v, t.position), nil
+	}
+	return value{}, newSyntaxError(t.position, "unknown variable '%s'", t.text)
+}
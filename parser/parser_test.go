@@ -0,0 +1,291 @@
+package parser_test
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+	"github.com/GodsBoss/mmath/parser"
+)
+
+func TestParseInt64(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		input         string
+		expectedValue int64
+	}{
+		"literal":             {input: "42", expectedValue: 42},
+		"negativeLiteral":     {input: "-42", expectedValue: -42},
+		"minimum":             {input: "-9223372036854775808", expectedValue: math.MinInt64},
+		"variable":            {input: "price", expectedValue: 25},
+		"sum":                 {input: "1 + 2 + 3", expectedValue: 6},
+		"difference":          {input: "10 - 2 - 3", expectedValue: 5},
+		"mixedAdditive":       {input: "10 - 2 + 3", expectedValue: 11},
+		"precedence":          {input: "2 + 3 * 4", expectedValue: 14},
+		"parentheses":         {input: "(2 + 3) * 4", expectedValue: 20},
+		"quotient":            {input: "17 / 5", expectedValue: 3},
+		"remainder":           {input: "17 % 5", expectedValue: 2},
+		"leftAssociative":     {input: "100 / 10 / 5", expectedValue: 2},
+		"mixedMultiplicative": {input: "7 * 6 / 4 * 2", expectedValue: 20},
+		"negation":            {input: "-(price)", expectedValue: -25},
+		"doubleNegation":      {input: "--price", expectedValue: 25},
+		"conditionalTrue":     {input: "if(stock < 10, price * 2, price)", expectedValue: 50},
+		"conditionalFalse":    {input: "if(stock > 10, price * 2, price)", expectedValue: 25},
+		"abs":                 {input: "abs(3 - stock)", expectedValue: 4},
+		"sign":                {input: "sign(3 - stock)", expectedValue: -1},
+		"whitespace":          {input: " \t1+\n2 ", expectedValue: 3},
+		"deepParentheses":     {input: strings.Repeat("(", 999) + "1" + strings.Repeat(")", 999), expectedValue: 1},
+	}
+
+	for name := range testcases {
+		testcase := testcases[name]
+
+		t.Run(
+			name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				calc, err := parser.ParseInt64(testcase.input, testVariables())
+				if err != nil {
+					t.Fatalf("expected no error, got %+v", err)
+				}
+				v, err := calc.CalculateInt64()
+				if err != nil {
+					t.Fatalf("expected no calculation error, got %+v", err)
+				}
+				if v != testcase.expectedValue {
+					t.Errorf("expected %d, got %d", testcase.expectedValue, v)
+				}
+			},
+		)
+	}
+}
+
+func TestParseBool(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		input         string
+		expectedValue bool
+	}{
+		"true":             {input: "true", expectedValue: true},
+		"false":            {input: "false", expectedValue: false},
+		"variable":         {input: "active", expectedValue: true},
+		"not":              {input: "!active", expectedValue: false},
+		"and":              {input: "active && stock < 10 && price > 20", expectedValue: true},
+		"or":               {input: "!active || false", expectedValue: false},
+		"precedence":       {input: "true || false && false", expectedValue: true},
+		"parentheses":      {input: "(true || false) && false", expectedValue: false},
+		"equalsInt64":      {input: "price == 25", expectedValue: true},
+		"notEqualsInt64":   {input: "price != 25", expectedValue: false},
+		"equalsBool":       {input: "active == true", expectedValue: true},
+		"notEqualsBool":    {input: "active != true", expectedValue: false},
+		"chainLess":        {input: "1 < stock < 10", expectedValue: true},
+		"chainLessFalse":   {input: "1 < stock < 5", expectedValue: false},
+		"chainGreaterOrEq": {input: "10 >= stock >= 7", expectedValue: true},
+		"between":          {input: "between(stock, 7, 7)", expectedValue: true},
+		"arithmetic":       {input: "price - stock * 2 == 11", expectedValue: true},
+	}
+
+	for name := range testcases {
+		testcase := testcases[name]
+
+		t.Run(
+			name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				calc, err := parser.ParseBool(testcase.input, testVariables())
+				if err != nil {
+					t.Fatalf("expected no error, got %+v", err)
+				}
+				v, err := calc.CalculateBool()
+				if err != nil {
+					t.Fatalf("expected no calculation error, got %+v", err)
+				}
+				if v != testcase.expectedValue {
+					t.Errorf("expected %t, got %t", testcase.expectedValue, v)
+				}
+			},
+		)
+	}
+}
+
+func TestSyntaxErrors(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		input            string
+		parseBool        bool
+		expectedPosition int
+	}{
+		"empty":               {input: "", expectedPosition: 1},
+		"unexpectedCharacter": {input: "1 + $", expectedPosition: 5},
+		"missingOperand":      {input: "1 +", expectedPosition: 4},
+		"trailingToken":       {input: "1 2", expectedPosition: 3},
+		"unclosedParenthesis": {input: "(1 + 2", expectedPosition: 7},
+		"unknownVariable":     {input: "price + cost", expectedPosition: 9},
+		"unknownFunction":     {input: "max(1, 2)", expectedPosition: 1},
+		"tooFewArguments":     {input: "if(true, 1)", expectedPosition: 11},
+		"argumentType":        {input: "if(1, 2, 3)", expectedPosition: 4},
+		"operandType":         {input: "1 + true", expectedPosition: 5},
+		"logicalOperandType":  {input: "active && 1", expectedPosition: 11},
+		"resultTypeInt64":     {input: "true"},
+		"resultTypeBool":      {input: "1", parseBool: true},
+		"mixedEquality":       {input: "1 == true", parseBool: true, expectedPosition: 3},
+		"chainedEquality":     {input: "1 == 1 == 1", parseBool: true, expectedPosition: 8},
+		"mixedChain":          {input: "1 < 2 > 1", parseBool: true, expectedPosition: 7},
+		"mixedOrderingChain":  {input: "1 < 2 <= 3", parseBool: true, expectedPosition: 7},
+		"orderingEquality":    {input: "1 < 2 == true", parseBool: true, expectedPosition: 7},
+		"equalityOrdering":    {input: "1 == 2 < 3", parseBool: true, expectedPosition: 8},
+		"deepParentheses":     {input: strings.Repeat("(", 1001) + "1" + strings.Repeat(")", 1001), expectedPosition: 1001},
+		"deepNegations":       {input: strings.Repeat("!", 1001) + "true", parseBool: true, expectedPosition: 1001},
+		"integerRange":        {input: "9223372036854775808", expectedPosition: 1},
+		"missingComma":        {input: "abs(1 2)", expectedPosition: 7},
+		"nonASCIICharacter":   {input: "1 + ä", expectedPosition: 5},
+		"invalidUTF8":         {input: "1 + \xff", expectedPosition: 5},
+	}
+
+	for name := range testcases {
+		testcase := testcases[name]
+
+		t.Run(
+			name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				var err error
+				if testcase.parseBool {
+					_, err = parser.ParseBool(testcase.input, testVariables())
+				} else {
+					_, err = parser.ParseInt64(testcase.input, testVariables())
+				}
+
+				var syntaxErr *parser.SyntaxError
+				if !errors.As(err, &syntaxErr) {
+					t.Fatalf("expected syntax error, got %+v", err)
+				}
+				if testcase.expectedPosition != 0 && syntaxErr.Position != testcase.expectedPosition {
+					t.Errorf("expected error at position %d, got %+v", testcase.expectedPosition, syntaxErr)
+				}
+			},
+		)
+	}
+}
+
+func TestParseInt64CalculationErrors(t *testing.T) {
+	t.Parallel()
+
+	calc, err := parser.ParseInt64("price / (stock - 7)", testVariables())
+	if err != nil {
+		t.Fatalf("expected no error, got %+v", err)
+	}
+
+	_, err = calc.CalculateInt64()
+
+	var divisionErr *mmath.DivisionByZeroError
+	if !errors.As(err, &divisionErr) {
+		t.Errorf("expected division by zero error, got %+v", err)
+	}
+}
+
+// testVariables returns variables with price set to 25, stock set to 7 and
+// active set to true.
+func testVariables() parser.Variables {
+	price := mmath.NewVariableInt64()
+	price.Set(25)
+	stock := mmath.NewVariableInt64()
+	stock.Set(7)
+	active := mmath.NewVariableBool()
+	active.Set(true)
+
+	return parser.Variables{
+		Int64: map[string]mmath.VariableInt64{
+			"price": price,
+			"stock": stock,
+		},
+		Bool: map[string]mmath.VariableBool{
+			"active": active,
+		},
+	}
+}
+
+func TestFormattedFormulasCanBeParsed(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]struct {
+		input string
+		parse func(input string, variables parser.Variables) (interface{}, error)
+	}{
+		"conditional":  {input: "if(stock < 10, price * 2, price)", parse: parseInt64},
+		"precedence":   {input: "(price - stock) * -3 % 7", parse: parseInt64},
+		"negation":     {input: "price - (stock - 1) - -(5)", parse: parseInt64},
+		"functions":    {input: "-(price + stock) / sign(stock)", parse: parseInt64},
+		"logic":        {input: "active && (price > 20 || !active) && 1 <= stock <= 10", parse: parseBool},
+		"equality":     {input: "(price == 25) == active", parse: parseBool},
+		"compareLeft":  {input: "(price < stock) == active", parse: parseBool},
+		"compareRight": {input: "active != (price >= stock)", parse: parseBool},
+		"mixedChains":  {input: "(price == 25) == (1 < stock < 10)", parse: parseBool},
+		"constants":    {input: "-(-5) - -(5) - --price", parse: parseInt64},
+	}
+
+	for name := range testcases {
+		testcase := testcases[name]
+
+		t.Run(
+			name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				calc, err := testcase.parse(testcase.input, namedTestVariables())
+				if err != nil {
+					t.Fatalf("expected no error, got %+v", err)
+				}
+				formula := mmath.FormatFormula(calc)
+				if formula != testcase.input {
+					t.Errorf("expected formula '%s', got '%s'", testcase.input, formula)
+				}
+
+				reparsed, err := testcase.parse(formula, namedTestVariables())
+				if err != nil {
+					t.Fatalf("expected formula '%s' to be parsed again, got %+v", formula, err)
+				}
+				if mmath.FormatTree(reparsed) != mmath.FormatTree(calc) {
+					t.Errorf("expected tree\n%s\n\ngot\n%s", mmath.FormatTree(calc), mmath.FormatTree(reparsed))
+				}
+			},
+		)
+	}
+}
+
+func parseInt64(input string, variables parser.Variables) (interface{}, error) {
+	return parser.ParseInt64(input, variables)
+}
+
+func parseBool(input string, variables parser.Variables) (interface{}, error) {
+	return parser.ParseBool(input, variables)
+}
+
+// namedTestVariables works like testVariables, but the variables are named
+// like their keys.
+func namedTestVariables() parser.Variables {
+	price := mmath.NewNamedVariableInt64("price")
+	price.Set(25)
+	stock := mmath.NewNamedVariableInt64("stock")
+	stock.Set(7)
+	active := mmath.NewNamedVariableBool("active")
+	active.Set(true)
+
+	return parser.Variables{
+		Int64: map[string]mmath.VariableInt64{
+			"price": price,
+			"stock": stock,
+		},
+		Bool: map[string]mmath.VariableBool{
+			"active": active,
+		},
+	}
+}
@@ -0,0 +1,273 @@
+package mmath
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+// CalculationRat represents a calculation that returns an exact rational
+// number.
+//
+// Like with CalculationBigInt, all calculations provided by this package
+// return a newly allocated *big.Rat on every call and never modify values
+// returned by their operands.
+type CalculationRat interface {
+	// CalculateRat returns the *big.Rat value calculated by this calculator.
+	CalculateRat() (*big.Rat, error)
+}
+
+// CalculationRatFunc implements CalculationRat by wrapping a function.
+type CalculationRatFunc func() (*big.Rat, error)
+
+// CalculateRat calls f and returns its result.
+func (f CalculationRatFunc) CalculateRat() (*big.Rat, error) {
+	return f()
+}
+
+// NewConstantRat returns a calculation which always returns the same value and
+// no error. c is copied, so modifying it afterwards does not change the
+// calculation.
+func NewConstantRat(c *big.Rat) CalculationRatFunc {
+	value := new(big.Rat).Set(c)
+	return func() (*big.Rat, error) {
+		return new(big.Rat).Set(value), nil
+	}
+}
+
+// NewConstantRatFromString returns a calculation which always returns the
+// value represented by s, which may be a fraction like "3/7" or a decimal
+// number like "-1.25". If s cannot be parsed, an error is returned.
+func NewConstantRatFromString(s string) (CalculationRatFunc, error) {
+	value, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("invalid rational number '%s'", s)
+	}
+	return NewConstantRat(value), nil
+}
+
+// NewVariableRat creates a variable. In calculations, it returns the value it
+// was set to, initially zero. Calculating the result of a variable never fails.
+// The variable is safe for concurrent use.
+func NewVariableRat() VariableRat {
+	return &variableRat{
+		value: new(big.Rat),
+	}
+}
+
+// VariableRat represents a variable value, which can be set from the outside.
+type VariableRat interface {
+	CalculationRat
+
+	// Set sets the variable. Afterwards, calling CalculateRat() will return the
+	// value of r. r is copied, so modifying it afterwards does not change the
+	// variable.
+	Set(r *big.Rat)
+}
+
+type variableRat struct {
+	mutex sync.RWMutex
+	value *big.Rat
+}
+
+func (v *variableRat) CalculateRat() (*big.Rat, error) {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+	return new(big.Rat).Set(v.value), nil
+}
+
+func (v *variableRat) Set(r *big.Rat) {
+	value := new(big.Rat).Set(r)
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.value = value
+}
+
+// NewSumRat returns a calculation which returns the sum of all calculations
+// passed to it. If one or more calculations fail, an error wrapping all those
+// individual errors is returned.
+func NewSumRat(calculations ...CalculationRat) CalculationRat {
+	return NewReduceLeftRat(
+		func(current, next *big.Rat) (*big.Rat, error) {
+			return new(big.Rat).Add(current, next), nil
+		},
+		NewConstantRat(new(big.Rat)),
+		calculations,
+	)
+}
+
+// NewProductRat returns a calculation which returns the product of all
+// calculations passed to it. If one or more calculations fail, an error
+// wrapping all those individual errors is returned.
+func NewProductRat(calculations ...CalculationRat) CalculationRat {
+	return NewReduceLeftRat(
+		func(current, next *big.Rat) (*big.Rat, error) {
+			return new(big.Rat).Mul(current, next), nil
+		},
+		NewConstantRat(big.NewRat(1, 1)),
+		calculations,
+	)
+}
+
+// NewDifferenceRat returns a calculation which subtracts the result of
+// subtrahend from the result of minuend. If one or both fail, an error
+// combining those errors is returned.
+func NewDifferenceRat(minuend, subtrahend CalculationRat) CalculationRatFunc {
+	return NewCreateFallibleBinaryRat(
+		func(left, right *big.Rat) (*big.Rat, error) {
+			return new(big.Rat).Sub(left, right), nil
+		},
+	)(minuend, subtrahend)
+}
+
+// NewQuotientRat returns a calculation which divides the result of dividend by
+// the result of divisor. If one or both fail, an error combining those errors
+// is returned. If the divisor is zero, a *DivisionByZeroError is returned.
+func NewQuotientRat(dividend, divisor CalculationRat) CalculationRatFunc {
+	return NewCreateFallibleBinaryRat(
+		func(left, right *big.Rat) (*big.Rat, error) {
+			if right.Sign() == 0 {
+				return nil, newDivisionByZeroError("quotient", left)
+			}
+			return new(big.Rat).Quo(left, right), nil
+		},
+	)(dividend, divisor)
+}
+
+// NewNegationRat returns a calculation which negates the result of another
+// calculation. If that calculation fails, that error is returned.
+func NewNegationRat(calculation CalculationRat) CalculationRatFunc {
+	return NewCreateFallibleUnaryRat(
+		func(value *big.Rat) (*big.Rat, error) {
+			return new(big.Rat).Neg(value), nil
+		},
+	)(calculation)
+}
+
+func runCalculationsRat(calculations ...CalculationRat) ([]*big.Rat, error) {
+	var errs Errors
+	results := make([]*big.Rat, len(calculations))
+
+	for i := range calculations {
+		result, err := calculations[i].CalculateRat()
+		results[i] = result
+		if err != nil {
+			errs.add(i, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return results, nil
+}
+
+// NewConditionalRat returns a calculation which returns the result of ifTrue or
+// ifFalse, depending on wether boolCalc returns true or false. If boolCalc
+// returns an error, that error is returned instead.
+func NewConditionalRat(boolCalc CalculationBool, ifTrue, ifFalse CalculationRat) CalculationRat {
+	return ToRat(Conditional(FromBool(boolCalc), FromRat(ifTrue), FromRat(ifFalse)))
+}
+
+// NewCreateFallibleBinaryRat wraps a binary function on *big.Rat values and
+// returns a calculation constructor representing the same calculation. If one
+// or both operands fail, an error combining those errors is returned, else the
+// result of f. f must not modify its arguments.
+func NewCreateFallibleBinaryRat(
+	f func(left, right *big.Rat) (*big.Rat, error),
+) func(left, right CalculationRat) CalculationRatFunc {
+	return func(left, right CalculationRat) CalculationRatFunc {
+		return func() (*big.Rat, error) {
+			values, err := runCalculationsRat(left, right)
+			if err != nil {
+				return nil, err
+			}
+			return f(values[0], values[1])
+		}
+	}
+}
+
+// NewCreateFallibleUnaryRat wraps a unary function on a *big.Rat value and
+// returns a calculation constructor representing the same calculation. If the
+// operand fails, that error is returned, else the result of f. f must not
+// modify its argument.
+func NewCreateFallibleUnaryRat(
+	f func(value *big.Rat) (*big.Rat, error),
+) func(calculation CalculationRat) CalculationRatFunc {
+	return func(calculation CalculationRat) CalculationRatFunc {
+		return func() (*big.Rat, error) {
+			v, err := calculation.CalculateRat()
+			if err != nil {
+				return nil, err
+			}
+			return f(v)
+		}
+	}
+}
+
+// NewReduceLeftRat works like NewReduceLeft, but for *big.Rat values. The
+// result of reduce is passed as current to the next call of reduce, so reduce
+// may modify current and return it, but must not modify next.
+func NewReduceLeftRat(
+	reduce func(current *big.Rat, next *big.Rat) (*big.Rat, error),
+	initialValue CalculationRat,
+	calculations []CalculationRat,
+) CalculationRat {
+	return ToRat(ReduceLeft(reduce, FromRat(initialValue), fromSlice(calculations, FromRat)))
+}
+
+// NewInt64ToRat returns a calculation which converts the result of an int64
+// calculation to a *big.Rat. If that calculation fails, its error is returned.
+func NewInt64ToRat(calculation CalculationInt64) CalculationRatFunc {
+	return func() (*big.Rat, error) {
+		v, err := calculation.CalculateInt64()
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Rat).SetInt64(v), nil
+	}
+}
+
+// NewBigIntToRat returns a calculation which converts the result of a *big.Int
+// calculation to a *big.Rat. If that calculation fails, its error is returned.
+func NewBigIntToRat(calculation CalculationBigInt) CalculationRatFunc {
+	return func() (*big.Rat, error) {
+		v, err := calculation.CalculateBigInt()
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Rat).SetInt(v), nil
+	}
+}
+
+// NewRatToInt64 returns a calculation which rounds the result of a *big.Rat
+// calculation to an int64, using the given rounding mode. If that calculation
+// fails, its error is returned. If the rounded value does not fit into an
+// int64, a *RangeError is returned.
+func NewRatToInt64(calculation CalculationRat, mode RoundingMode) CalculationInt64Func {
+	return func() (int64, error) {
+		v, err := calculation.CalculateRat()
+		if err != nil {
+			return 0, err
+		}
+		rounded := roundRat(v, mode)
+		if !rounded.IsInt64() {
+			return 0, newRangeError(v.RatString(), "int64")
+		}
+		return rounded.Int64(), nil
+	}
+}
+
+// NewRatToBigInt returns a calculation which rounds the result of a *big.Rat
+// calculation to a *big.Int, using the given rounding mode. If that
+// calculation fails, its error is returned.
+func NewRatToBigInt(calculation CalculationRat, mode RoundingMode) CalculationBigIntFunc {
+	return func() (*big.Int, error) {
+		v, err := calculation.CalculateRat()
+		if err != nil {
+			return nil, err
+		}
+		return roundRat(v, mode), nil
+	}
+}
@@ -0,0 +1,82 @@
+package mmath
+
+// NewRatEquals returns wether the results of the first and second calculations
+// are equal. If one or both return an error, return an error combining those
+// errors instead.
+func NewRatEquals(first, second CalculationRat) CalculationBoolFunc {
+	return newRatComparison(
+		func(cmp int) bool {
+			return cmp == 0
+		},
+	)(first, second)
+}
+
+// NewRatNotEquals returns wether the results of the first and second
+// calculations differ. Errors are handled like in NewRatEquals.
+func NewRatNotEquals(first, second CalculationRat) CalculationBoolFunc {
+	return newRatComparison(
+		func(cmp int) bool {
+			return cmp != 0
+		},
+	)(first, second)
+}
+
+// NewRatLess returns wether the result of the first calculation is less than
+// the result of the second calculation. Errors are handled like in
+// NewRatEquals.
+func NewRatLess(first, second CalculationRat) CalculationBoolFunc {
+	return newRatComparison(
+		func(cmp int) bool {
+			return cmp < 0
+		},
+	)(first, second)
+}
+
+// NewRatLessOrEqual returns wether the result of the first calculation is less
+// than or equal to the result of the second calculation. Errors are handled
+// like in NewRatEquals.
+func NewRatLessOrEqual(first, second CalculationRat) CalculationBoolFunc {
+	return newRatComparison(
+		func(cmp int) bool {
+			return cmp <= 0
+		},
+	)(first, second)
+}
+
+// NewRatGreater returns wether the result of the first calculation is greater
+// than the result of the second calculation. Errors are handled like in
+// NewRatEquals.
+func NewRatGreater(first, second CalculationRat) CalculationBoolFunc {
+	return newRatComparison(
+		func(cmp int) bool {
+			return cmp > 0
+		},
+	)(first, second)
+}
+
+// NewRatGreaterOrEqual returns wether the result of the first calculation is
+// greater than or equal to the result of the second calculation. Errors are
+// handled like in NewRatEquals.
+func NewRatGreaterOrEqual(first, second CalculationRat) CalculationBoolFunc {
+	return newRatComparison(
+		func(cmp int) bool {
+			return cmp >= 0
+		},
+	)(first, second)
+}
+
+// newRatComparison creates a calculation constructor comparing the results of
+// two calculations. check receives the result of big.Rat's Cmp method.
+func newRatComparison(
+	check func(cmp int) bool,
+) func(first, second CalculationRat) CalculationBoolFunc {
+	return func(first, second CalculationRat) CalculationBoolFunc {
+		return func() (bool, error) {
+			values, err := runCalculationsRat(first, second)
+			if err != nil {
+				return false, err
+			}
+			return check(values[0].Cmp(values[1])), nil
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package mmath_test
+
+import (
+	"github.com/GodsBoss/mmath"
+
+	"fmt"
+)
+
+func ExampleNewConstantRatFromString() {
+	share, err := mmath.NewConstantRatFromString("3/7")
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+		return
+	}
+
+	total, err := mmath.NewConstantRatFromString("70.35")
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+		return
+	}
+
+	v, err := mmath.NewProductRat(share, total).CalculateRat()
+
+	fmt.Printf("Value is %s.\n", v.RatString())
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+	}
+
+	// Output:
+	// Value is 603/20.
+}
+
+func ExampleNewRatToInt64() {
+	half, _ := mmath.NewConstantRatFromString("5/2")
+
+	for _, mode := range []mmath.RoundingMode{mmath.RoundFloor, mmath.RoundHalfEven, mmath.RoundHalfUp} {
+		v, err := mmath.NewRatToInt64(half, mode).CalculateInt64()
+
+		fmt.Printf("Rounding %s: %d\n", mode, v)
+		if err != nil {
+			fmt.Printf("Error is: %v\n", err)
+		}
+	}
+
+	// Output:
+	// Rounding floor: 2
+	// Rounding half even: 2
+	// Rounding half up: 3
+}
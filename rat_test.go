@@ -0,0 +1,270 @@
+package mmath_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+)
+
+func TestRat(t *testing.T) {
+	t.Parallel()
+
+	testcases := map[string]testcaseRat{
+		"sum/no_summands": {
+			calculation:   mmath.NewSumRat(),
+			expectedValue: "0",
+		},
+		"sum/fractions": {
+			calculation:   mmath.NewSumRat(mustRat("1/3"), mustRat("1/6")),
+			expectedValue: "1/2",
+		},
+		"sum/errors": {
+			calculation: mmath.NewSumRat(
+				mmath.NewFailingCalculation(fmt.Errorf("foobar")),
+				mmath.NewFailingCalculation(fmt.Errorf("xyz")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("foobar"),
+				errorContainsString("xyz"),
+			),
+		},
+		"product/no_factors": {
+			calculation:   mmath.NewProductRat(),
+			expectedValue: "1",
+		},
+		"product/fractions": {
+			calculation:   mmath.NewProductRat(mustRat("3/7"), mustRat("-14/9")),
+			expectedValue: "-2/3",
+		},
+		"product/errors": {
+			calculation: mmath.NewProductRat(
+				mmath.NewFailingCalculation(fmt.Errorf("abc")),
+				mmath.NewFailingCalculation(fmt.Errorf("123")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("abc"),
+				errorContainsString("123"),
+			),
+		},
+		"difference/value": {
+			calculation:   mmath.NewDifferenceRat(mustRat("0.5"), mustRat("3/4")),
+			expectedValue: "-1/4",
+		},
+		"quotient/value": {
+			calculation:   mmath.NewQuotientRat(mustRat("1"), mustRat("3")),
+			expectedValue: "1/3",
+		},
+		"quotient/by_zero": {
+			calculation:       mmath.NewQuotientRat(mustRat("1/2"), mustRat("0")),
+			expectedErrorFunc: errorIsDivisionByZero("quotient"),
+		},
+		"quotient/errors": {
+			calculation: mmath.NewQuotientRat(
+				mmath.NewFailingCalculation(fmt.Errorf("dividend failed")),
+				mmath.NewFailingCalculation(fmt.Errorf("divisor failed")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("dividend failed"),
+				errorContainsString("divisor failed"),
+			),
+		},
+		"negation/value": {
+			calculation:   mmath.NewNegationRat(mustRat("2/5")),
+			expectedValue: "-2/5",
+		},
+		"conditional/false": {
+			calculation:   mmath.NewConditionalRat(mmath.NewFalse(), mustRat("1/2"), mustRat("1/3")),
+			expectedValue: "1/3",
+		},
+		"conditional/error": {
+			calculation:       mmath.NewConditionalRat(mmath.NewFailingCalculation(fmt.Errorf("hello")), mustRat("1"), mustRat("2")),
+			expectedErrorFunc: errorContainsString("hello"),
+		},
+		"fromInt64/value": {
+			calculation:   mmath.NewInt64ToRat(mmath.NewConstantInt64(-3)),
+			expectedValue: "-3",
+		},
+		"fromBigInt/value": {
+			calculation:   mmath.NewBigIntToRat(mmath.NewConstantBigInt(big.NewInt(12))),
+			expectedValue: "12",
+		},
+		"reduceLeft/reduceError": {
+			calculation: mmath.NewReduceLeftRat(
+				func(_, _ *big.Rat) (*big.Rat, error) {
+					return nil, fmt.Errorf("reduce failure")
+				},
+				mustRat("0"),
+				[]mmath.CalculationRat{mustRat("0")},
+			),
+			expectedErrorFunc: errorContainsString("reduce failure"),
+		},
+	}
+
+	runTestcasesRat(t, testcases)
+}
+
+func TestConstantRatFromStringInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := mmath.NewConstantRatFromString("three sevenths")
+	if err == nil {
+		t.Errorf("expected error")
+	}
+}
+
+func TestRatToInt64(t *testing.T) {
+	t.Parallel()
+
+	modes := map[string]mmath.RoundingMode{
+		"towardZero":   mmath.RoundTowardZero,
+		"awayFromZero": mmath.RoundAwayFromZero,
+		"floor":        mmath.RoundFloor,
+		"ceiling":      mmath.RoundCeiling,
+		"halfUp":       mmath.RoundHalfUp,
+		"halfDown":     mmath.RoundHalfDown,
+		"halfEven":     mmath.RoundHalfEven,
+	}
+
+	// expected maps inputs to the expected results for every mode.
+	expected := map[string]map[string]int64{
+		"5/2":  {"towardZero": 2, "awayFromZero": 3, "floor": 2, "ceiling": 3, "halfUp": 3, "halfDown": 2, "halfEven": 2},
+		"7/2":  {"towardZero": 3, "awayFromZero": 4, "floor": 3, "ceiling": 4, "halfUp": 4, "halfDown": 3, "halfEven": 4},
+		"-5/2": {"towardZero": -2, "awayFromZero": -3, "floor": -3, "ceiling": -2, "halfUp": -3, "halfDown": -2, "halfEven": -2},
+		"8/3":  {"towardZero": 2, "awayFromZero": 3, "floor": 2, "ceiling": 3, "halfUp": 3, "halfDown": 3, "halfEven": 3},
+		"-7/3": {"towardZero": -2, "awayFromZero": -3, "floor": -3, "ceiling": -2, "halfUp": -2, "halfDown": -2, "halfEven": -2},
+		"4":    {"towardZero": 4, "awayFromZero": 4, "floor": 4, "ceiling": 4, "halfUp": 4, "halfDown": 4, "halfEven": 4},
+	}
+
+	testcases := make(map[string]testcaseInt64)
+	for input, results := range expected {
+		for modeName, mode := range modes {
+			testcases[modeName+"/"+input] = testcaseInt64{
+				calculation:   mmath.NewRatToInt64(mustRat(input), mode),
+				expectedValue: results[modeName],
+			}
+		}
+	}
+
+	testcases["minimum"] = testcaseInt64{
+		calculation:   mmath.NewRatToInt64(mmath.NewInt64ToRat(mmath.NewConstantInt64(math.MinInt64)), mmath.RoundHalfEven),
+		expectedValue: math.MinInt64,
+	}
+	testcases["tooLarge"] = testcaseInt64{
+		calculation:       mmath.NewRatToInt64(mustRat("9223372036854775807.5"), mmath.RoundHalfUp),
+		expectedErrorFunc: errorIsRange("int64"),
+	}
+	testcases["error"] = testcaseInt64{
+		calculation:       mmath.NewRatToInt64(mmath.NewFailingCalculation(fmt.Errorf("rat failed")), mmath.RoundFloor),
+		expectedErrorFunc: errorContainsString("rat failed"),
+	}
+
+	runTestcasesInt64(t, testcases)
+}
+
+func TestRatComparison(t *testing.T) {
+	t.Parallel()
+
+	operators := map[string]struct {
+		create func(first, second mmath.CalculationRat) mmath.CalculationBoolFunc
+
+		// Expected results for comparing 1/3 with 1/2, 2/4 with 1/2 and 2/3
+		// with 1/2.
+		less, equal, greater bool
+	}{
+		"equals":         {create: mmath.NewRatEquals, less: false, equal: true, greater: false},
+		"notEquals":      {create: mmath.NewRatNotEquals, less: true, equal: false, greater: true},
+		"less":           {create: mmath.NewRatLess, less: true, equal: false, greater: false},
+		"lessOrEqual":    {create: mmath.NewRatLessOrEqual, less: true, equal: true, greater: false},
+		"greater":        {create: mmath.NewRatGreater, less: false, equal: false, greater: true},
+		"greaterOrEqual": {create: mmath.NewRatGreaterOrEqual, less: false, equal: true, greater: true},
+	}
+
+	testcases := make(map[string]testcaseBool)
+	for name, operator := range operators {
+		testcases[name+"/less"] = testcaseBool{
+			calculation:   operator.create(mustRat("1/3"), mustRat("1/2")),
+			expectedValue: operator.less,
+		}
+		testcases[name+"/equal"] = testcaseBool{
+			calculation:   operator.create(mustRat("2/4"), mustRat("1/2")),
+			expectedValue: operator.equal,
+		}
+		testcases[name+"/greater"] = testcaseBool{
+			calculation:   operator.create(mustRat("2/3"), mustRat("1/2")),
+			expectedValue: operator.greater,
+		}
+		testcases[name+"/errors"] = testcaseBool{
+			calculation: operator.create(
+				mmath.NewFailingCalculation(fmt.Errorf("broken")),
+				mmath.NewFailingCalculation(fmt.Errorf("meh")),
+			),
+			expectedErrorFunc: errorAnd(
+				errorContainsString("broken"),
+				errorContainsString("meh"),
+			),
+		}
+	}
+
+	runTestcasesBool(t, testcases)
+}
+
+func mustRat(s string) mmath.CalculationRat {
+	calculation, err := mmath.NewConstantRatFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return calculation
+}
+
+func runTestcasesRat(t *testing.T, testcases map[string]testcaseRat) {
+	for name := range testcases {
+		testcaseRat := testcases[name]
+
+		t.Run(
+			name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				actualValue, actualErr := testcaseRat.calculation.CalculateRat()
+
+				if testcaseRat.expectedValue != "" && (actualValue == nil || actualValue.RatString() != testcaseRat.expectedValue) {
+					t.Errorf(
+						"expected calculation result value to be %s, but got %v",
+						testcaseRat.expectedValue,
+						actualValue,
+					)
+				}
+
+				if testcaseRat.expectedErrorFunc == nil && actualErr != nil {
+					t.Errorf("expected no error, but got %+v", actualErr)
+				}
+
+				if testcaseRat.expectedErrorFunc != nil {
+					if actualErr != nil {
+						testcaseRat.expectedErrorFunc(t, actualErr)
+					}
+					if actualErr == nil {
+						t.Errorf("expected non-nil error")
+					}
+				}
+			},
+		)
+	}
+}
+
+type testcaseRat struct {
+	// calculation is the calculation executed by the test. It's result is
+	// compared against the expected values.
+	calculation mmath.CalculationRat
+
+	// expectedValue is the value the calculation should return, as returned by
+	// big.Rat's RatString method. If empty, the value is not checked.
+	expectedValue string
+
+	// expectedErrorFunc checks the error. This being nil is equivalent to
+	// checking wether the error should be nil.
+	expectedErrorFunc errorTest
+}
@@ -0,0 +1,408 @@
+package mmath
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// NewDerivedInt64 returns a calculation which keeps the result of another
+// calculation up to date and notifies subscribers when that result changes.
+// It subscribes to all variables and derived calculations contained in
+// calculation, so their changes are noticed automatically.
+//
+// Calculations which may change without notifying about it cannot be
+// subscribed to, e.g. a CalculationInt64Func or variables not created by this
+// package. If calculation contains any of them, the result is calculated again
+// every time it is requested, notifying subscribers if it changed. Calling
+// Refresh notifies subscribers about such changes without requesting the
+// result.
+//
+// Call Close when the derived calculation is not needed anymore, so it can be
+// garbage collected even if the variables live on.
+//
+// The derived calculation is safe for concurrent use. Subscribers may be
+// called from any goroutine setting a variable, possibly at the same time.
+func NewDerivedInt64(calculation CalculationInt64) *DerivedInt64 {
+	derived := &DerivedInt64{
+		calculation: calculation,
+	}
+	// Subscribing first makes sure that no change is missed.
+	derived.unsubscribe, derived.volatile = subscribeToVariables(calculation, derived, derived.Refresh)
+	derived.refresh()
+	return derived
+}
+
+// DerivedInt64 is an int64 calculation derived from another calculation, see
+// NewDerivedInt64.
+type DerivedInt64 struct {
+	mutex         sync.Mutex
+	calculation   CalculationInt64
+	value         int64
+	err           error
+	subscriptions subscriptions
+	unsubscribe   func()
+
+	// volatile is true if the calculation may change without notifying.
+	volatile bool
+}
+
+// CalculateInt64 returns the current result of the calculation. It is only
+// calculated again if the calculation is volatile, see NewDerivedInt64.
+func (derived *DerivedInt64) CalculateInt64() (int64, error) {
+	if derived.volatile {
+		derived.Refresh()
+	}
+	return derived.current()
+}
+
+// current returns the result of the calculation without calculating it again.
+func (derived *DerivedInt64) current() (int64, error) {
+	derived.mutex.Lock()
+	defer derived.mutex.Unlock()
+	return derived.value, derived.err
+}
+
+// Subscribe registers f, which is called with the new result every time the
+// result changes. A change of the error counts as a change, errors are
+// compared by their message. Calling unsubscribe unregisters f.
+func (derived *DerivedInt64) Subscribe(f func(value int64, err error)) (unsubscribe func()) {
+	return derived.subscriptions.add(
+		nil,
+		func() {
+			f(derived.current())
+		},
+	)
+}
+
+// Refresh calculates the result again and notifies subscribers if it changed.
+func (derived *DerivedInt64) Refresh() {
+	if derived.refresh() {
+		notify(derived.subscriptions.list())
+	}
+}
+
+// refresh calculates the result again and returns wether it changed.
+func (derived *DerivedInt64) refresh() bool {
+	derived.mutex.Lock()
+	defer derived.mutex.Unlock()
+	value, err := derived.calculation.CalculateInt64()
+	changed := value != derived.value || errorsDiffer(err, derived.err)
+	derived.value, derived.err = value, err
+	return changed
+}
+
+// Close unsubscribes from all variables and derived calculations. Afterwards,
+// the result only changes when calling Refresh.
+func (derived *DerivedInt64) Close() {
+	derived.unsubscribe()
+}
+
+func (derived *DerivedInt64) subscribe(owner interface{}, notify func()) func() {
+	return derived.subscriptions.add(owner, notify)
+}
+
+func (derived *DerivedInt64) isVolatile() bool {
+	return derived.volatile
+}
+
+// NewDerivedBool works like NewDerivedInt64, but for bool calculations.
+func NewDerivedBool(calculation CalculationBool) *DerivedBool {
+	derived := &DerivedBool{
+		calculation: calculation,
+	}
+	// Subscribing first makes sure that no change is missed.
+	derived.unsubscribe, derived.volatile = subscribeToVariables(calculation, derived, derived.Refresh)
+	derived.refresh()
+	return derived
+}
+
+// DerivedBool is a bool calculation derived from another calculation, see
+// NewDerivedBool.
+type DerivedBool struct {
+	mutex         sync.Mutex
+	calculation   CalculationBool
+	value         bool
+	err           error
+	subscriptions subscriptions
+	unsubscribe   func()
+
+	// volatile is true if the calculation may change without notifying.
+	volatile bool
+}
+
+// CalculateBool returns the current result of the calculation. It is only
+// calculated again if the calculation is volatile, see NewDerivedBool.
+func (derived *DerivedBool) CalculateBool() (bool, error) {
+	if derived.volatile {
+		derived.Refresh()
+	}
+	return derived.current()
+}
+
+// current returns the result of the calculation without calculating it again.
+func (derived *DerivedBool) current() (bool, error) {
+	derived.mutex.Lock()
+	defer derived.mutex.Unlock()
+	return derived.value, derived.err
+}
+
+// Subscribe registers f, which is called with the new result every time the
+// result changes, see DerivedInt64.Subscribe.
+func (derived *DerivedBool) Subscribe(f func(value bool, err error)) (unsubscribe func()) {
+	return derived.subscriptions.add(
+		nil,
+		func() {
+			f(derived.current())
+		},
+	)
+}
+
+// Refresh calculates the result again and notifies subscribers if it changed.
+func (derived *DerivedBool) Refresh() {
+	if derived.refresh() {
+		notify(derived.subscriptions.list())
+	}
+}
+
+// refresh calculates the result again and returns wether it changed.
+func (derived *DerivedBool) refresh() bool {
+	derived.mutex.Lock()
+	defer derived.mutex.Unlock()
+	value, err := derived.calculation.CalculateBool()
+	changed := value != derived.value || errorsDiffer(err, derived.err)
+	derived.value, derived.err = value, err
+	return changed
+}
+
+// Close unsubscribes from all variables and derived calculations. Afterwards,
+// the result only changes when calling Refresh.
+func (derived *DerivedBool) Close() {
+	derived.unsubscribe()
+}
+
+func (derived *DerivedBool) subscribe(owner interface{}, notify func()) func() {
+	return derived.subscriptions.add(owner, notify)
+}
+
+func (derived *DerivedBool) isVolatile() bool {
+	return derived.volatile
+}
+
+// Batch collects changes of variables, which are applied at once on Commit.
+// Subscribers are notified after all changes have been applied, and only once
+// per commit, even if they depend on several changed variables.
+//
+// A batch must not be used by multiple goroutines simultaneously. Committing
+// is not atomic: other goroutines calculating the variables while a commit is
+// in progress may see some of the changes, but not others.
+type Batch struct {
+	updates []batchUpdate
+}
+
+// NewBatch creates an empty batch.
+func NewBatch() *Batch {
+	return &Batch{}
+}
+
+// SetInt64 sets variable to value on Commit.
+func (batch *Batch) SetInt64(variable ObservableVariableInt64, value int64) {
+	update := batchUpdate{
+		apply: func() {
+			variable.Set(value)
+		},
+	}
+	if v, ok := variable.(*variableInt64); ok {
+		update.variable = v
+		update.apply = func() {
+			v.set(value)
+		}
+	}
+	batch.updates = append(batch.updates, update)
+}
+
+// SetBool sets variable to value on Commit.
+func (batch *Batch) SetBool(variable ObservableVariableBool, value bool) {
+	update := batchUpdate{
+		apply: func() {
+			variable.Set(value)
+		},
+	}
+	if v, ok := variable.(*variableBool); ok {
+		update.variable = v
+		update.apply = func() {
+			v.set(value)
+		}
+	}
+	batch.updates = append(batch.updates, update)
+}
+
+// Commit applies all changes in the order they were made, then notifies
+// subscribers of all variables whose value differs from the value before the
+// commit. Afterwards, the batch is empty and can be used again. Variables not
+// created by this package are set immediately, notifying their subscribers on
+// their own.
+func (batch *Batch) Commit() {
+	updates := batch.updates
+	batch.updates = nil
+
+	changed := make(map[batchVariable]func() bool)
+	variables := []batchVariable{}
+	for _, update := range updates {
+		if update.variable != nil {
+			if _, ok := changed[update.variable]; !ok {
+				changed[update.variable] = update.variable.snapshot()
+				variables = append(variables, update.variable)
+			}
+		}
+		update.apply()
+	}
+
+	pending := []*subscription{}
+	for _, variable := range variables {
+		if changed[variable]() {
+			pending = append(pending, variable.notifications()...)
+		}
+	}
+	notify(pending)
+}
+
+type batchUpdate struct {
+	// variable is nil for variables not created by this package.
+	variable batchVariable
+	apply    func()
+}
+
+// batchVariable is implemented by variables which can be set by a batch.
+type batchVariable interface {
+	// snapshot remembers the current value and returns a function which
+	// reports wether the value changed since then.
+	snapshot() func() bool
+
+	// notifications returns the subscriptions which must be notified about
+	// a change.
+	notifications() []*subscription
+}
+
+// observable is implemented by variables and derived calculations which
+// notify about changes.
+type observable interface {
+	subscribe(owner interface{}, notify func()) (unsubscribe func())
+
+	// isVolatile returns wether changes may happen without notifying.
+	isVolatile() bool
+}
+
+// subscribeToVariables subscribes notify to all observables in calculation and
+// returns a function unsubscribing from all of them. It also returns wether
+// calculation is volatile, i.e. contains calculations which may change
+// without notifying, like calculations which are no nodes.
+func subscribeToVariables(calculation interface{}, owner interface{}, notify func()) (func(), bool) {
+	unsubscribes := []func(){}
+	seen := make(map[observable]bool)
+	volatile := false
+	Inspect(
+		NodeOf(calculation),
+		func(node Node) bool {
+			if node == nil {
+				return false
+			}
+			if o, ok := calculationOf(node).(observable); ok {
+				if !seen[o] {
+					seen[o] = true
+					unsubscribes = append(unsubscribes, o.subscribe(owner, notify))
+					volatile = volatile || o.isVolatile()
+				}
+				return true
+			}
+			if len(node.Children()) == 0 && node.Kind() != KindConstant && node.Kind() != KindFailing {
+				volatile = true
+			}
+			return true
+		},
+	)
+	unsubscribe := func() {
+		for i := range unsubscribes {
+			unsubscribes[i]()
+		}
+	}
+	return unsubscribe, volatile
+}
+
+type subscription struct {
+	// owner identifies subscriptions which should only be notified once, even
+	// if they are subscribed to several changed variables. Subscriptions
+	// without owner are always notified.
+	owner  interface{}
+	notify func()
+
+	// active is 1 until the subscription is removed. It is accessed
+	// atomically, as notifying may happen concurrently.
+	active int32
+}
+
+func (entry *subscription) isActive() bool {
+	return atomic.LoadInt32(&entry.active) == 1
+}
+
+type subscriptions struct {
+	mutex   sync.Mutex
+	entries []*subscription
+}
+
+func (s *subscriptions) add(owner interface{}, notify func()) (unsubscribe func()) {
+	entry := &subscription{
+		owner:  owner,
+		notify: notify,
+		active: 1,
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.entries = append(s.entries, entry)
+	return func() {
+		s.remove(entry)
+	}
+}
+
+func (s *subscriptions) remove(entry *subscription) {
+	atomic.StoreInt32(&entry.active, 0)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for i := range s.entries {
+		if s.entries[i] == entry {
+			s.entries = append(s.entries[:i:i], s.entries[i+1:]...)
+			return
+		}
+	}
+}
+
+// list returns the current subscriptions. The result is not changed by
+// subscribing or unsubscribing.
+func (s *subscriptions) list() []*subscription {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]*subscription(nil), s.entries...)
+}
+
+// notify notifies all active subscriptions, every owner only once.
+func notify(pending []*subscription) {
+	notified := make(map[interface{}]bool)
+	for _, entry := range pending {
+		if !entry.isActive() {
+			continue
+		}
+		if entry.owner != nil {
+			if notified[entry.owner] {
+				continue
+			}
+			notified[entry.owner] = true
+		}
+		entry.notify()
+	}
+}
+
+func errorsDiffer(first, second error) bool {
+	if first == nil || second == nil {
+		return first != second
+	}
+	return first.Error() != second.Error()
+}
@@ -0,0 +1,31 @@
+package mmath_test
+
+import (
+	"github.com/GodsBoss/mmath"
+
+	"fmt"
+)
+
+func ExampleNewDerivedInt64() {
+	price := mmath.NewObservableVariableInt64()
+	quantity := mmath.NewObservableVariableInt64()
+	total := mmath.NewDerivedInt64(mmath.NewProductInt64(price, quantity))
+	total.Subscribe(
+		func(value int64, err error) {
+			fmt.Printf("Total is %d.\n", value)
+		},
+	)
+
+	price.Set(20)
+	quantity.Set(3)
+	quantity.Set(3)
+
+	batch := mmath.NewBatch()
+	batch.SetInt64(price, 15)
+	batch.SetInt64(quantity, 5)
+	batch.Commit()
+
+	// Output:
+	// Total is 60.
+	// Total is 75.
+}
@@ -0,0 +1,310 @@
+package mmath_test
+
+import (
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+)
+
+func TestObservableVariableInt64NotifiesOnlyOnChange(t *testing.T) {
+	x := mmath.NewObservableVariableInt64()
+	values := []int64{}
+	unsubscribe := x.Subscribe(
+		func(value int64) {
+			values = append(values, value)
+		},
+	)
+
+	x.Set(3)
+	x.Set(3)
+	x.Set(5)
+	unsubscribe()
+	x.Set(7)
+
+	expected := []int64{3, 5}
+	if !equalInt64s(values, expected) {
+		t.Errorf("expected notifications %v, got %v", expected, values)
+	}
+}
+
+func TestObservableVariableBoolNotifiesOnlyOnChange(t *testing.T) {
+	p := mmath.NewObservableVariableBool()
+	values := []bool{}
+	unsubscribe := p.Subscribe(
+		func(value bool) {
+			values = append(values, value)
+		},
+	)
+
+	p.Set(false)
+	p.Set(true)
+	p.Set(true)
+	unsubscribe()
+	p.Set(false)
+
+	if len(values) != 1 || !values[0] {
+		t.Errorf("expected notifications [true], got %v", values)
+	}
+}
+
+func TestDerivedInt64NotifiesAboutValueAndErrorChanges(t *testing.T) {
+	x := mmath.NewVariableInt64()
+	x.Set(5)
+	derived := mmath.NewDerivedInt64(mmath.NewQuotientInt64(mmath.NewConstantInt64(10), x))
+
+	type notification struct {
+		value int64
+		isErr bool
+	}
+	notifications := []notification{}
+	derived.Subscribe(
+		func(value int64, err error) {
+			notifications = append(notifications, notification{value: value, isErr: err != nil})
+		},
+	)
+
+	x.Set(-5) // -2
+	x.Set(-4) // -2, no change
+	x.Set(0)  // error
+	x.Set(0)  // no change
+	x.Set(10) // 1
+
+	expected := []notification{
+		{value: -2},
+		{isErr: true},
+		{value: 1},
+	}
+	if len(notifications) != len(expected) {
+		t.Fatalf("expected notifications %+v, got %+v", expected, notifications)
+	}
+	for i := range expected {
+		if notifications[i] != expected[i] {
+			t.Errorf("expected notification %d to be %+v, got %+v", i, expected[i], notifications[i])
+		}
+	}
+}
+
+func TestDerivedBoolStopsNotifyingWhenClosed(t *testing.T) {
+	x := mmath.NewVariableInt64()
+	derived := mmath.NewDerivedBool(mmath.NewInt64Less(x, mmath.NewConstantInt64(10)))
+	count := 0
+	derived.Subscribe(
+		func(bool, error) {
+			count++
+		},
+	)
+
+	x.Set(20)
+	derived.Close()
+	x.Set(0)
+
+	if count != 1 {
+		t.Errorf("expected 1 notification, got %d", count)
+	}
+	if v, _ := derived.CalculateBool(); v {
+		t.Errorf("expected closed derived calculation to keep its result")
+	}
+}
+
+func TestDerivedInt64RecalculatesVolatileCalculations(t *testing.T) {
+	external := int64(1)
+	derived := mmath.NewDerivedInt64(
+		mmath.CalculationInt64Func(
+			func() (int64, error) {
+				return external, nil
+			},
+		),
+	)
+	values := []int64{}
+	derived.Subscribe(
+		func(value int64, _ error) {
+			values = append(values, value)
+		},
+	)
+
+	external = 2
+	if v, _ := derived.CalculateInt64(); v != 2 {
+		t.Errorf("expected 2, got %d", v)
+	}
+	external = 3
+	derived.Refresh()
+	derived.Refresh()
+
+	expected := []int64{2, 3}
+	if !equalInt64s(values, expected) {
+		t.Errorf("expected notifications %v, got %v", expected, values)
+	}
+}
+
+func TestDerivedCalculationsOfBaselineConstructors(t *testing.T) {
+	x := mmath.NewVariableInt64()
+	b := mmath.NewVariableBool()
+	notB := mmath.NewDerivedBool(mmath.NewNot(b))
+	isThree := mmath.NewDerivedBool(mmath.NewInt64Equals(x, mmath.NewConstantInt64(3)))
+	notifications := 0
+	for _, derived := range []*mmath.DerivedBool{notB, isThree} {
+		derived.Subscribe(
+			func(bool, error) {
+				notifications++
+			},
+		)
+	}
+
+	b.Set(true)
+	x.Set(3)
+
+	if notifications != 2 {
+		t.Errorf("expected 2 notifications, got %d", notifications)
+	}
+	if v, _ := notB.CalculateBool(); v {
+		t.Errorf("expected negation to be false")
+	}
+	if v, _ := isThree.CalculateBool(); !v {
+		t.Errorf("expected equality to be true")
+	}
+}
+
+func TestDerivedBoolOfVolatileCalculationIsNotStale(t *testing.T) {
+	b := mmath.NewVariableBool()
+	opaque := mmath.CalculationBoolFunc(b.CalculateBool)
+	derived := mmath.NewDerivedBool(mmath.NewAnd(mmath.ShortCircuit, mmath.NewTrue(), opaque))
+	outer := mmath.NewDerivedBool(mmath.NewNot(derived))
+
+	b.Set(true)
+
+	if v, _ := derived.CalculateBool(); !v {
+		t.Errorf("expected derived calculation to be true")
+	}
+	if v, _ := outer.CalculateBool(); v {
+		t.Errorf("expected derived calculation of volatile derived calculation to be false")
+	}
+}
+
+func TestNewDerivedInt64DoesNotMissChangesWhileCreating(t *testing.T) {
+	x := mmath.NewVariableInt64()
+	done := make(chan struct{})
+	first := true
+
+	// The second operand changes x after it has been calculated, simulating
+	// another goroutine setting x while the derived calculation is created.
+	derived := mmath.NewDerivedInt64(
+		mmath.NewSumInt64(
+			x,
+			mmath.CalculationInt64Func(
+				func() (int64, error) {
+					if first {
+						first = false
+						go func() {
+							x.Set(7)
+							close(done)
+						}()
+						for v, _ := x.CalculateInt64(); v != 7; v, _ = x.CalculateInt64() {
+						}
+					}
+					return 0, nil
+				},
+			),
+		),
+	)
+	<-done
+
+	if v, _ := derived.CalculateInt64(); v != 7 {
+		t.Errorf("expected 7, got %d", v)
+	}
+}
+
+func TestDerivedCalculationsOfDerivedCalculations(t *testing.T) {
+	x := mmath.NewVariableInt64()
+	sum := mmath.NewDerivedInt64(mmath.NewSumInt64(x, mmath.NewConstantInt64(1)))
+	small := mmath.NewDerivedBool(mmath.NewInt64Less(sum, mmath.NewConstantInt64(10)))
+	negated := mmath.NewDerivedBool(mmath.NewNot(small))
+	values := []bool{}
+	negated.Subscribe(
+		func(value bool, _ error) {
+			values = append(values, value)
+		},
+	)
+
+	x.Set(20)
+	x.Set(30)
+	x.Set(0)
+	negated.Close()
+	x.Set(20)
+
+	if v, _ := small.CalculateBool(); v {
+		t.Errorf("expected inner derived calculation to be updated")
+	}
+	expected := []bool{true, false}
+	if len(values) != len(expected) || values[0] != expected[0] || values[1] != expected[1] {
+		t.Errorf("expected notifications %v, got %v", expected, values)
+	}
+}
+
+func TestBatchNotifiesDerivedCalculationsOnce(t *testing.T) {
+	x := mmath.NewObservableVariableInt64()
+	y := mmath.NewObservableVariableInt64()
+	p := mmath.NewObservableVariableBool()
+	derived := mmath.NewDerivedInt64(
+		mmath.NewConditionalInt64(p, mmath.NewSumInt64(x, y), mmath.NewDifferenceInt64(x, y)),
+	)
+	values := []int64{}
+	derived.Subscribe(
+		func(value int64, _ error) {
+			values = append(values, value)
+		},
+	)
+	xValues := []int64{}
+	x.Subscribe(
+		func(value int64) {
+			xValues = append(xValues, value)
+		},
+	)
+
+	batch := mmath.NewBatch()
+	batch.SetInt64(x, 3)
+	batch.SetInt64(y, 4)
+	batch.SetBool(p, true)
+	if v, _ := derived.CalculateInt64(); v != 0 {
+		t.Errorf("expected batch not to change anything before committing, got %d", v)
+	}
+	batch.Commit()
+
+	expected := []int64{7}
+	if !equalInt64s(values, expected) {
+		t.Errorf("expected notifications %v, got %v", expected, values)
+	}
+	if !equalInt64s(xValues, []int64{3}) {
+		t.Errorf("expected variable notifications [3], got %v", xValues)
+	}
+}
+
+func TestBatchIgnoresVariablesSetBackToTheirValue(t *testing.T) {
+	x := mmath.NewObservableVariableInt64()
+	count := 0
+	x.Subscribe(
+		func(int64) {
+			count++
+		},
+	)
+
+	batch := mmath.NewBatch()
+	batch.SetInt64(x, 5)
+	batch.SetInt64(x, 0)
+	batch.Commit()
+
+	if count != 0 {
+		t.Errorf("expected no notifications, got %d", count)
+	}
+}
+
+func equalInt64s(actual, expected []int64) bool {
+	if len(actual) != len(expected) {
+		return false
+	}
+	for i := range actual {
+		if actual[i] != expected[i] {
+			return false
+		}
+	}
+	return true
+}
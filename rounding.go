@@ -0,0 +1,107 @@
+package mmath
+
+import (
+	"math/big"
+)
+
+// RoundingMode determines how a value is rounded if it cannot be represented
+// exactly by the target type, e.g. when converting a float64 to an int64.
+type RoundingMode int
+
+const (
+	// RoundTowardZero discards the fractional part, like Go's conversion from
+	// float64 to int64 does.
+	RoundTowardZero RoundingMode = iota
+
+	// RoundAwayFromZero rounds to the next integer with a greater absolute value
+	// if there is a fractional part.
+	RoundAwayFromZero
+
+	// RoundFloor rounds towards negative infinity.
+	RoundFloor
+
+	// RoundCeiling rounds towards positive infinity.
+	RoundCeiling
+
+	// RoundHalfUp rounds to the nearest integer. Ties are rounded away from
+	// zero, e.g. 2.5 becomes 3 and -2.5 becomes -3.
+	RoundHalfUp
+
+	// RoundHalfDown rounds to the nearest integer. Ties are rounded towards
+	// zero, e.g. 2.5 becomes 2 and -2.5 becomes -2.
+	RoundHalfDown
+
+	// RoundHalfEven rounds to the nearest integer. Ties are rounded to the even
+	// neighbour, e.g. 2.5 becomes 2 and 3.5 becomes 4.
+	RoundHalfEven
+)
+
+// RoundBankers is another name for RoundHalfEven, often used in financial
+// contexts.
+const RoundBankers = RoundHalfEven
+
+// String returns a human-readable name for the rounding mode.
+func (mode RoundingMode) String() string {
+	switch mode {
+	case RoundTowardZero:
+		return "toward zero"
+	case RoundAwayFromZero:
+		return "away from zero"
+	case RoundFloor:
+		return "floor"
+	case RoundCeiling:
+		return "ceiling"
+	case RoundHalfUp:
+		return "half up"
+	case RoundHalfDown:
+		return "half down"
+	case RoundHalfEven:
+		return "half even"
+	default:
+		return "unknown"
+	}
+}
+
+// roundRat rounds r to an integer, using the given rounding mode.
+func roundRat(r *big.Rat, mode RoundingMode) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient
+	}
+
+	// Rounding away from zero means adding the sign of r to the quotient, which
+	// has been truncated towards zero.
+	awayFromZero := func() *big.Int {
+		return quotient.Add(quotient, big.NewInt(int64(r.Sign())))
+	}
+
+	// half is the result of comparing the absolute remainder with half of the
+	// denominator.
+	half := new(big.Int).Lsh(remainder.Abs(remainder), 1).Cmp(r.Denom())
+
+	switch mode {
+	case RoundAwayFromZero:
+		return awayFromZero()
+	case RoundFloor:
+		if r.Sign() < 0 {
+			return awayFromZero()
+		}
+	case RoundCeiling:
+		if r.Sign() > 0 {
+			return awayFromZero()
+		}
+	case RoundHalfUp:
+		if half >= 0 {
+			return awayFromZero()
+		}
+	case RoundHalfDown:
+		if half > 0 {
+			return awayFromZero()
+		}
+	case RoundHalfEven:
+		if half > 0 || (half == 0 && quotient.Bit(0) == 1) {
+			return awayFromZero()
+		}
+	}
+	return quotient
+}
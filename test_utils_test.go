@@ -1,8 +1,11 @@
 package mmath_test
 
 import (
+	"errors"
 	"strings"
 	"testing"
+
+	"github.com/GodsBoss/mmath"
 )
 
 type errorTest func(t *testing.T, actualErr error)
@@ -22,3 +25,63 @@ func errorContainsString(s string) errorTest {
 		}
 	}
 }
+
+func errorIsOverflow(operation string) errorTest {
+	return func(t *testing.T, actualErr error) {
+		var overflowErr *mmath.OverflowError
+		if !errors.As(actualErr, &overflowErr) {
+			t.Errorf("expected error %+v to be an overflow error", actualErr)
+			return
+		}
+		if overflowErr.Operation != operation {
+			t.Errorf("expected overflow in %s, but got overflow in %s", operation, overflowErr.Operation)
+		}
+	}
+}
+
+func errorIsDivisionByZero(operation string) errorTest {
+	return func(t *testing.T, actualErr error) {
+		var divisionErr *mmath.DivisionByZeroError
+		if !errors.As(actualErr, &divisionErr) {
+			t.Errorf("expected error %+v to be a division by zero error", actualErr)
+			return
+		}
+		if divisionErr.Operation != operation {
+			t.Errorf("expected division by zero in %s, but got division by zero in %s", operation, divisionErr.Operation)
+		}
+	}
+}
+
+func errorNotContainsString(s string) errorTest {
+	return func(t *testing.T, actualErr error) {
+		if strings.Contains(actualErr.Error(), s) {
+			t.Errorf("expected error %+v not to contain string '%s'", actualErr, s)
+		}
+	}
+}
+
+func errorIsNonFinite(operation string) errorTest {
+	return func(t *testing.T, actualErr error) {
+		var nonFiniteErr *mmath.NonFiniteError
+		if !errors.As(actualErr, &nonFiniteErr) {
+			t.Errorf("expected error %+v to be a non-finite error", actualErr)
+			return
+		}
+		if nonFiniteErr.Operation != operation {
+			t.Errorf("expected non-finite result in %s, but got one in %s", operation, nonFiniteErr.Operation)
+		}
+	}
+}
+
+func errorIsRange(target string) errorTest {
+	return func(t *testing.T, actualErr error) {
+		var rangeErr *mmath.RangeError
+		if !errors.As(actualErr, &rangeErr) {
+			t.Errorf("expected error %+v to be a range error", actualErr)
+			return
+		}
+		if rangeErr.Target != target {
+			t.Errorf("expected range error for %s, but got one for %s", target, rangeErr.Target)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package mmath
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// TraceInt64 calculates calculation once and records the result of every
+// sub-calculation involved, explaining how the result was reached.
+func TraceInt64(calculation CalculationInt64) *Trace {
+	traced, recorder := traceNode(NodeOf(calculation))
+	if _, ok := traced.(*tracedInt64); !ok {
+		traced = &tracedInt64{calculation: calculation, recorder: recorder}
+	}
+	traced.(CalculationInt64).CalculateInt64()
+	return recorder.result()
+}
+
+// TraceBool works like TraceInt64, but for bool calculations.
+func TraceBool(calculation CalculationBool) *Trace {
+	traced, recorder := traceNode(NodeOf(calculation))
+	if _, ok := traced.(*tracedBool); !ok {
+		traced = &tracedBool{calculation: calculation, recorder: recorder}
+	}
+	traced.(CalculationBool).CalculateBool()
+	return recorder.result()
+}
+
+// Trace is the record of calculating a calculation, see TraceInt64.
+type Trace struct {
+	// Node is the calculation which was calculated.
+	Node Node
+
+	// Value is the result of the calculation, an int64 or a bool.
+	Value interface{}
+
+	// Err is the error returned by the calculation, if any.
+	Err error
+
+	// Duration is the time the calculation took, including the time taken by
+	// its operands.
+	Duration time.Duration
+
+	// Operands contains one trace per child of Node, see Node.Children. It is
+	// nil for operands which were not calculated, e.g. the branch of a
+	// conditional not taken. Operands of calculations which are no nodes are
+	// not traced, e.g. those calculated by a CalculationInt64Func.
+	Operands []*Trace
+}
+
+// String returns the trace as tree, see Tree.
+func (trace *Trace) String() string {
+	return trace.Tree(false)
+}
+
+// Tree returns an indented dump of the trace, similar to FormatTree. Every
+// line contains one calculation and its result or error (except for
+// constants, whose result is obvious), operands are
+// indented by two spaces below the calculation using them. If durations is
+// true, the duration of every calculation is added.
+func (trace *Trace) Tree(durations bool) string {
+	f := newFormatter(trace.Node)
+	lines := []string{}
+	trace.tree(f, durations, 0, &lines)
+	return strings.Join(lines, "\n")
+}
+
+func (trace *Trace) tree(f *formatter, durations bool, depth int, lines *[]string) {
+	indentation := strings.Repeat("  ", depth)
+	line := indentation + f.label(trace.Node)
+	switch {
+	case trace.Err != nil:
+		line += " failed: " + trace.Err.Error()
+	case trace.Node.Kind() != KindConstant:
+		line += " = " + formatConstant(trace.Value)
+	}
+	if durations {
+		line += " [" + trace.Duration.String() + "]"
+	}
+	*lines = append(*lines, line)
+
+	children := trace.Node.Children()
+	for i := range trace.Operands {
+		if trace.Operands[i] == nil {
+			*lines = append(*lines, indentation+"  "+f.label(children[i])+" (not calculated)")
+			continue
+		}
+		trace.Operands[i].tree(f, durations, depth+1, lines)
+	}
+}
+
+// MarshalJSON encodes the trace as a JSON object containing the label of the
+// calculation like in Tree, its formula, its value or error, its duration in
+// nanoseconds and its operands, e.g.
+//
+//	{"label":"sum","formula":"x + 1","value":3,"durationNanos":1200,"operands":[...]}
+//
+// Operands which were not calculated are encoded as null.
+func (trace *Trace) MarshalJSON() ([]byte, error) {
+	return json.Marshal(trace.encode(newFormatter(trace.Node)))
+}
+
+type jsonTrace struct {
+	Label         string       `json:"label"`
+	Formula       string       `json:"formula"`
+	Value         interface{}  `json:"value,omitempty"`
+	Error         string       `json:"error,omitempty"`
+	DurationNanos int64        `json:"durationNanos"`
+	Operands      []*jsonTrace `json:"operands,omitempty"`
+}
+
+func (trace *Trace) encode(f *formatter) *jsonTrace {
+	encoded := &jsonTrace{
+		Label:         f.label(trace.Node),
+		DurationNanos: trace.Duration.Nanoseconds(),
+	}
+	encoded.Formula, _ = f.formula(trace.Node)
+	if trace.Err != nil {
+		encoded.Error = trace.Err.Error()
+	} else {
+		encoded.Value = trace.Value
+	}
+	for i := range trace.Operands {
+		if trace.Operands[i] == nil {
+			encoded.Operands = append(encoded.Operands, nil)
+			continue
+		}
+		encoded.Operands = append(encoded.Operands, trace.Operands[i].encode(f))
+	}
+	return encoded
+}
+
+// traceNode returns a calculation working like node, which records its result
+// in the returned recorder. Nodes used more than once get a recorder per usage.
+func traceNode(node Node) (interface{}, *traceRecorder) {
+	recorder := &traceRecorder{
+		node: node,
+	}
+
+	calculation := calculationOf(node)
+	if children := node.Children(); len(children) > 0 {
+		tracedChildren := make([]Node, len(children))
+		recorders := make([]*traceRecorder, len(children))
+		for i := range children {
+			var traced interface{}
+			traced, recorders[i] = traceNode(children[i])
+			tracedChildren[i] = NodeOf(traced)
+		}
+		if rebuilt, err := node.WithChildren(tracedChildren...); err == nil {
+			calculation = calculationOf(rebuilt)
+			recorder.operands = recorders
+		}
+	}
+
+	traced := byResultType(
+		calculation,
+		func(calculation CalculationInt64) interface{} {
+			return &tracedInt64{calculation: calculation, recorder: recorder}
+		},
+		func(calculation CalculationBool) interface{} {
+			return &tracedBool{calculation: calculation, recorder: recorder}
+		},
+		calculation,
+	)
+	return traced, recorder
+}
+
+type traceRecorder struct {
+	node       Node
+	calculated bool
+	value      interface{}
+	err        error
+	duration   time.Duration
+
+	// operands is nil if the operands of node could not be traced.
+	operands []*traceRecorder
+}
+
+func (recorder *traceRecorder) record(start time.Time, value interface{}, err error) {
+	recorder.calculated = true
+	recorder.value = value
+	recorder.err = err
+	recorder.duration = time.Since(start)
+}
+
+// result returns the recorded trace, or nil if node was not calculated.
+func (recorder *traceRecorder) result() *Trace {
+	if !recorder.calculated {
+		return nil
+	}
+	trace := &Trace{
+		Node:     recorder.node,
+		Value:    recorder.value,
+		Err:      recorder.err,
+		Duration: recorder.duration,
+	}
+	if recorder.operands != nil {
+		trace.Operands = make([]*Trace, len(recorder.operands))
+		for i := range recorder.operands {
+			trace.Operands[i] = recorder.operands[i].result()
+		}
+	}
+	return trace
+}
+
+type tracedInt64 struct {
+	calculation CalculationInt64
+	recorder    *traceRecorder
+}
+
+func (traced *tracedInt64) CalculateInt64() (int64, error) {
+	start := time.Now()
+	value, err := traced.calculation.CalculateInt64()
+	traced.recorder.record(start, value, err)
+	return value, err
+}
+
+type tracedBool struct {
+	calculation CalculationBool
+	recorder    *traceRecorder
+}
+
+func (traced *tracedBool) CalculateBool() (bool, error) {
+	start := time.Now()
+	value, err := traced.calculation.CalculateBool()
+	traced.recorder.record(start, value, err)
+	return value, err
+}
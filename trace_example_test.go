@@ -0,0 +1,32 @@
+package mmath_test
+
+import (
+	"github.com/GodsBoss/mmath"
+
+	"fmt"
+)
+
+func ExampleTraceInt64() {
+	price := mmath.NewNamedVariableInt64("price")
+	discount := mmath.NewNamedVariableInt64("discount")
+	price.Set(80)
+	discount.Set(15)
+
+	total := mmath.NewConditionalInt64(
+		mmath.NewInt64GreaterOrEqual(price, mmath.NewConstantInt64(100)),
+		mmath.NewDifferenceInt64(price, discount),
+		price,
+	)
+
+	trace := mmath.TraceInt64(total)
+
+	fmt.Println(trace)
+
+	// Output:
+	// conditional = 80
+	//   greaterOrEqual = false
+	//     price = 80
+	//     100
+	//   difference (not calculated)
+	//   price = 80
+}
@@ -0,0 +1,124 @@
+package mmath_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+)
+
+func TestTraceInt64RecordsOperands(t *testing.T) {
+	x := mmath.NewNamedVariableInt64("x")
+	x.Set(4)
+	calculation := mmath.NewSumInt64(
+		mmath.NewProductInt64(x, mmath.NewConstantInt64(3)),
+		mmath.NewQuotientInt64(mmath.NewConstantInt64(1), mmath.NewDifferenceInt64(x, x)),
+	)
+
+	trace := mmath.TraceInt64(calculation)
+
+	if trace.Err == nil {
+		t.Errorf("expected error")
+	}
+	if len(trace.Operands) != 2 {
+		t.Fatalf("expected 2 operands, got %d", len(trace.Operands))
+	}
+	if v := trace.Operands[0].Value; v != int64(12) {
+		t.Errorf("expected first operand to be 12, got %v", v)
+	}
+	if trace.Operands[0].Duration > trace.Duration {
+		t.Errorf("expected operand to take less time than the calculation using it")
+	}
+
+	expected := strings.Join(
+		[]string{
+			"sum failed: multiple errors: division by zero in quotient of 1",
+			"  product = 12",
+			"    x = 4",
+			"    3",
+			"  quotient failed: division by zero in quotient of 1",
+			"    1",
+			"    difference = 0",
+			"      x = 4",
+			"      x = 4",
+		},
+		"\n",
+	)
+	if actual := trace.String(); actual != expected {
+		t.Errorf("expected trace\n%s\ngot\n%s", expected, actual)
+	}
+}
+
+func TestTraceBoolOmitsOperandsNotCalculated(t *testing.T) {
+	p := mmath.NewNamedVariableBool("p")
+	calculation := mmath.NewOr(
+		mmath.ShortCircuit,
+		mmath.NewNot(p),
+		mmath.NewNot(mmath.NewFailingCalculation(errors.New("unused"))),
+	)
+
+	trace := mmath.TraceBool(calculation)
+
+	if trace.Value != true || trace.Err != nil {
+		t.Errorf("expected true and no error, got %v and %+v", trace.Value, trace.Err)
+	}
+	if len(trace.Operands) != 2 || trace.Operands[0] == nil || trace.Operands[1] != nil {
+		t.Fatalf("expected only the first operand to be calculated, got %+v", trace.Operands)
+	}
+	if !strings.Contains(trace.String(), "not (not calculated)") {
+		t.Errorf("expected trace to mention operand not calculated, got\n%s", trace)
+	}
+}
+
+func TestTraceInt64DoesNotTraceOpaqueOperands(t *testing.T) {
+	opaque := mmath.CalculationInt64Func(
+		func() (int64, error) {
+			return 5, nil
+		},
+	)
+
+	trace := mmath.TraceInt64(mmath.NewNegationInt64(opaque))
+
+	if trace.Value != int64(-5) || len(trace.Operands) != 1 {
+		t.Fatalf("expected -5 and one operand, got %v and %+v", trace.Value, trace.Operands)
+	}
+	if operand := trace.Operands[0]; operand.Value != int64(5) || operand.Operands != nil {
+		t.Errorf("expected opaque operand 5 without operands, got %+v", operand)
+	}
+}
+
+func TestTraceMarshalJSON(t *testing.T) {
+	x := mmath.NewNamedVariableInt64("x")
+	x.Set(-3)
+	calculation := mmath.NewConditionalInt64(
+		mmath.NewInt64Less(x, mmath.NewConstantInt64(0)),
+		mmath.NewNegationInt64(x),
+		x,
+	)
+
+	data, err := json.Marshal(mmath.TraceInt64(calculation))
+	if err != nil {
+		t.Fatalf("expected no error, got %+v", err)
+	}
+
+	var decoded struct {
+		Label    string `json:"label"`
+		Formula  string `json:"formula"`
+		Value    int64  `json:"value"`
+		Operands []*struct {
+			Label string `json:"label"`
+		} `json:"operands"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("expected no error, got %+v", err)
+	}
+
+	if decoded.Label != "conditional" || decoded.Formula != "if(x < 0, -x, x)" || decoded.Value != 3 {
+		t.Errorf("unexpected trace %s", data)
+	}
+	if len(decoded.Operands) != 3 || decoded.Operands[1].Label != "negation" || decoded.Operands[2] != nil {
+		t.Errorf("unexpected operands in trace %s", data)
+	}
+}
@@ -0,0 +1,8 @@
+package mmath
+
+// Variables binds names to variables, e.g. for decoding calculations
+// referencing variables by name. Names must be unique across both maps.
+type Variables struct {
+	Int64 map[string]VariableInt64
+	Bool  map[string]VariableBool
+}
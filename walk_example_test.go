@@ -0,0 +1,50 @@
+package mmath_test
+
+import (
+	"github.com/GodsBoss/mmath"
+
+	"fmt"
+)
+
+func ExampleInspect() {
+	price := mmath.NewNamedVariableInt64("price")
+	stock := mmath.NewNamedVariableInt64("stock")
+
+	calc := mmath.NewConditionalInt64(
+		mmath.NewInt64Less(stock, mmath.NewConstantInt64(10)),
+		mmath.NewProductInt64(price, mmath.NewConstantInt64(2)),
+		price,
+	)
+
+	mmath.Inspect(
+		mmath.NodeOf(calc),
+		func(node mmath.Node) bool {
+			if node != nil && node.Kind() == mmath.KindVariable {
+				fmt.Println(node.Params()[0])
+			}
+			return true
+		},
+	)
+
+	// Output:
+	// stock
+	// price
+	// price
+}
+
+func ExampleNode_WithChildren() {
+	calc := mmath.NewSumInt64(mmath.NewConstantInt64(2), mmath.NewConstantInt64(3))
+
+	node := mmath.NodeOf(calc)
+	children := node.Children()
+	swapped, err := node.WithChildren(children[1], children[0], mmath.NodeOf(mmath.NewConstantInt64(4)))
+	if err != nil {
+		fmt.Printf("Error is: %v\n", err)
+		return
+	}
+
+	fmt.Println(swapped)
+
+	// Output:
+	// 3 + 2 + 4
+}
@@ -0,0 +1,80 @@
+package mmath_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GodsBoss/mmath"
+)
+
+type recordingVisitor struct {
+	visits *[]string
+}
+
+func (v recordingVisitor) Visit(node mmath.Node) mmath.Visitor {
+	if node == nil {
+		*v.visits = append(*v.visits, "end")
+		return nil
+	}
+	*v.visits = append(*v.visits, string(node.Kind()))
+	if node.Kind() == mmath.KindAbs {
+		return nil
+	}
+	return v
+}
+
+func TestWalk(t *testing.T) {
+	x := mmath.NewVariableInt64()
+	calc := mmath.NewConditionalInt64(
+		mmath.NewInt64Less(x, mmath.NewConstantInt64(0)),
+		mmath.NewCheckedAbsInt64(x),
+		x,
+	)
+
+	visits := []string{}
+	mmath.Walk(recordingVisitor{visits: &visits}, mmath.NodeOf(calc))
+
+	expected := []string{
+		"conditional",
+		"less",
+		"variable", "end",
+		"constant", "end",
+		"end",
+		"abs",
+		"variable", "end",
+		"end",
+	}
+	if !reflect.DeepEqual(visits, expected) {
+		t.Errorf("expected visits %v, got %v", expected, visits)
+	}
+}
+
+func TestInspect(t *testing.T) {
+	calc := mmath.NewSumInt64(
+		mmath.NewConstantInt64(1),
+		mmath.NewProductInt64(mmath.NewConstantInt64(2), mmath.NewConstantInt64(3)),
+		mmath.NewConstantInt64(4),
+	)
+
+	constants := []int64{}
+	mmath.Inspect(
+		mmath.NodeOf(calc),
+		func(node mmath.Node) bool {
+			if node == nil {
+				return false
+			}
+			if node.Kind() == mmath.KindProduct {
+				return false
+			}
+			if node.Kind() == mmath.KindConstant {
+				constants = append(constants, node.Params()[0].(int64))
+			}
+			return true
+		},
+	)
+
+	expected := []int64{1, 4}
+	if !reflect.DeepEqual(constants, expected) {
+		t.Errorf("expected constants %v, got %v", expected, constants)
+	}
+}